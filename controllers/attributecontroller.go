@@ -0,0 +1,584 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	attributeNamePattern  = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	attributeQueryPattern = regexp.MustCompile(`^attr\[([a-z][a-z0-9_]*)\](?:\[(min|max)\])?$`)
+)
+
+func GetCategoryAttributes(c *fiber.Ctx) error {
+	// Ambil ID kategori dari parameter URL
+	categoryId := c.Params("id")
+
+	var category models.Category
+	if err := initialize.DB.Preload("Attributes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Where("category_id = ?", categoryId).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgCategoryNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgCategoryFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   category.Attributes,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func UpdateCategoryAttributes(c *fiber.Ctx) error {
+	// Ambil ID kategori dari parameter URL
+	categoryId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidCategoryID)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	var category models.Category
+	if err := initialize.DB.Preload("Attributes").First(&category, categoryId).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgCategoryNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	var req models.CategoryAttributesRequest
+	if err := c.BodyParser(&req); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	// Validasi skema atribut
+	errorsMap := make(map[string][]string)
+	if err := validate.Struct(&req); err != nil {
+		errorsMap = helpers.ValidationMessages(c, err)
+	}
+	seen := make(map[string]bool)
+	for i, attr := range req.Attributes {
+		field := fmt.Sprintf("Attributes[%d].Name", i)
+		if attr.Name != "" && !attributeNamePattern.MatchString(attr.Name) {
+			errorsMap[field] = append(errorsMap[field], helpers.Message(c, helpers.MsgAttributeNameInvalid, field))
+		}
+		if seen[attr.Name] {
+			errorsMap[field] = append(errorsMap[field], helpers.Message(c, helpers.MsgAttributeDuplicated, field))
+		}
+		seen[attr.Name] = true
+		if attr.Min != nil && attr.Max != nil && *attr.Min > *attr.Max {
+			field := fmt.Sprintf("Attributes[%d].Min", i)
+			errorsMap[field] = append(errorsMap[field], helpers.Message(c, helpers.MsgAttributeMinGreaterThanMax, field))
+		}
+	}
+	if len(errorsMap) > 0 {
+		response := helpers.NewResponseError(c, errorsMap)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	existing := make(map[string]models.CategoryAttribute)
+	for _, attr := range category.Attributes {
+		existing[attr.Name] = attr
+	}
+
+	// Atribut dicocokkan berdasarkan name agar nilai produk yang sudah ada tetap tersimpan
+	err = initialize.DB.Transaction(func(tx *gorm.DB) error {
+		var keepIds []int64
+		for i, reqAttr := range req.Attributes {
+			attr, found := existing[reqAttr.Name]
+			typeChanged := found && attr.Type != reqAttr.Type
+
+			attr.CategoryId = categoryId
+			attr.Name = reqAttr.Name
+			attr.Label = reqAttr.Label
+			attr.Type = reqAttr.Type
+			attr.Unit = reqAttr.Unit
+			attr.Options = nil
+			if reqAttr.Type == models.AttributeTypeEnum {
+				attr.Options = reqAttr.Options
+			}
+			attr.Min = reqAttr.Min
+			attr.Max = reqAttr.Max
+			attr.Required = reqAttr.Required
+			attr.Position = i
+			if err := tx.Save(&attr).Error; err != nil {
+				return err
+			}
+			keepIds = append(keepIds, attr.AttributeId)
+
+			// Nilai lama tidak lagi sesuai dengan tipe atau pilihan yang baru
+			if typeChanged {
+				if err := tx.Where("attribute_id = ?", attr.AttributeId).Delete(&models.ProductAttributeValue{}).Error; err != nil {
+					return err
+				}
+			} else if attr.Type == models.AttributeTypeEnum {
+				if err := tx.Where("attribute_id = ? AND text_value NOT IN ?", attr.AttributeId, attr.Options).Delete(&models.ProductAttributeValue{}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		removed := tx.Where("category_id = ?", categoryId)
+		if len(keepIds) > 0 {
+			removed = removed.Where("attribute_id NOT IN ?", keepIds)
+		}
+		var removedIds []int64
+		if err := removed.Model(&models.CategoryAttribute{}).Pluck("attribute_id", &removedIds).Error; err != nil {
+			return err
+		}
+		if len(removedIds) == 0 {
+			return nil
+		}
+		if err := tx.Where("attribute_id IN ?", removedIds).Delete(&models.ProductAttributeValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("attribute_id IN ?", removedIds).Delete(&models.CategoryAttribute{}).Error
+	})
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgCategoryAttributesUpdateFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	var attributes []models.CategoryAttribute
+	initialize.DB.Where("category_id = ?", categoryId).Order("position ASC").Find(&attributes)
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   attributes,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// attributeNumber menerima angka JSON maupun string angka dari form
+func attributeNumber(raw interface{}) (float64, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func attributeInBounds(attr models.CategoryAttribute, value float64) bool {
+	if attr.Min != nil && value < *attr.Min {
+		return false
+	}
+	if attr.Max != nil && value > *attr.Max {
+		return false
+	}
+	return true
+}
+
+func attributeBoundsError(attr models.CategoryAttribute) error {
+	switch {
+	case attr.Min != nil && attr.Max != nil:
+		return helpers.NewError(helpers.MsgAttributeBetween, attr.Name, *attr.Min, *attr.Max)
+	case attr.Min != nil:
+		return helpers.NewError(helpers.MsgAttributeAtLeast, attr.Name, *attr.Min)
+	default:
+		return helpers.NewError(helpers.MsgAttributeAtMost, attr.Name, *attr.Max)
+	}
+}
+
+// attributeValue memvalidasi satu nilai mentah terhadap skema atribut
+func attributeValue(attr models.CategoryAttribute, raw interface{}) (models.ProductAttributeValue, error) {
+	value := models.ProductAttributeValue{AttributeId: attr.AttributeId}
+
+	switch attr.Type {
+	case models.AttributeTypeNumber:
+		number, ok := attributeNumber(raw)
+		if !ok {
+			return value, helpers.NewError(helpers.MsgAttributeNotNumber, attr.Name)
+		}
+		if !attributeInBounds(attr, number) {
+			return value, attributeBoundsError(attr)
+		}
+		value.NumberValue = &number
+
+	case models.AttributeTypeRange:
+		// Rentang dikirim sebagai {"min": 800, "max": 1200} atau [800, 1200]
+		var low, high interface{}
+		switch v := raw.(type) {
+		case map[string]interface{}:
+			low, high = v["min"], v["max"]
+		case []interface{}:
+			if len(v) == 2 {
+				low, high = v[0], v[1]
+			}
+		}
+		lowNumber, okLow := attributeNumber(low)
+		highNumber, okHigh := attributeNumber(high)
+		if !okLow || !okHigh {
+			return value, helpers.NewError(helpers.MsgAttributeNotRange, attr.Name)
+		}
+		if lowNumber > highNumber {
+			return value, helpers.NewError(helpers.MsgAttributeRangeInverted, attr.Name)
+		}
+		if !attributeInBounds(attr, lowNumber) || !attributeInBounds(attr, highNumber) {
+			return value, attributeBoundsError(attr)
+		}
+		value.NumberValue = &lowNumber
+		value.MaxValue = &highNumber
+
+	case models.AttributeTypeEnum:
+		text, ok := raw.(string)
+		if !ok {
+			return value, helpers.NewError(helpers.MsgAttributeNotOption, attr.Name, strings.Join(attr.Options, ", "))
+		}
+		for _, option := range attr.Options {
+			if option == text {
+				value.TextValue = text
+				return value, nil
+			}
+		}
+		return value, helpers.NewError(helpers.MsgAttributeNotOption, attr.Name, strings.Join(attr.Options, ", "))
+
+	case models.AttributeTypeBoolean:
+		var flag bool
+		switch v := raw.(type) {
+		case bool:
+			flag = v
+		case string:
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return value, helpers.NewError(helpers.MsgAttributeNotBoolean, attr.Name)
+			}
+			flag = parsed
+		default:
+			return value, helpers.NewError(helpers.MsgAttributeNotBoolean, attr.Name)
+		}
+		value.BoolValue = &flag
+	}
+	return value, nil
+}
+
+// attributesFromForm membaca field "attributes" (objek JSON name -> nilai) dan memvalidasinya terhadap skema kategori.
+// Dengan creating=true field yang kosong tetap divalidasi agar atribut wajib tidak terlewat saat produk dibuat.
+func attributesFromForm(c *fiber.Ctx, categoryId int64, creating bool) ([]models.ProductAttributeValue, bool, map[string][]string, error) {
+	raw := c.FormValue("attributes")
+	if raw == "" {
+		if !creating {
+			return nil, false, nil, nil
+		}
+		values, errorsMap, err := validateAttributes(helpers.Locale(c), categoryId, map[string]interface{}{})
+		return values, false, errorsMap, err
+	}
+
+	var input map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &input); err != nil {
+		return nil, true, map[string][]string{"attributes": {helpers.Message(c, helpers.MsgAttributesNotObject)}}, nil
+	}
+
+	values, errorsMap, err := validateAttributes(helpers.Locale(c), categoryId, input)
+	return values, true, errorsMap, err
+}
+
+// movedAttributes memindahkan nilai atribut tersimpan ke skema kategori baru berdasarkan nama atribut.
+// Atribut yang tidak ada di kategori baru dibuang, atribut wajib kategori baru yang belum terisi ditolak
+func movedAttributes(locale string, productId int64, categoryId int64) ([]models.ProductAttributeValue, map[string][]string, error) {
+	var stored []models.ProductAttributeValue
+	if err := initialize.DB.Preload("Attribute").Where("product_id = ?", productId).Find(&stored).Error; err != nil {
+		return nil, nil, err
+	}
+	schema, err := categoryAttributeSchema(categoryId)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, errorsMap := checkAttributes(locale, schema, movedAttributeInput(schema, stored))
+	return values, errorsMap, nil
+}
+
+// movedAttributeInput menyusun input atribut dari nilai tersimpan, hanya untuk nama yang ada di skema tujuan
+func movedAttributeInput(schema []models.CategoryAttribute, stored []models.ProductAttributeValue) map[string]interface{} {
+	known := make(map[string]bool, len(schema))
+	for _, attr := range schema {
+		known[attr.Name] = true
+	}
+	input := make(map[string]interface{})
+	for name, value := range productAttributeMap(stored) {
+		// Pointer dari kolom tersimpan dinormalkan ke bentuk JSON seperti input dari form
+		if known[name] {
+			input[name] = normalizeRevisionValue(value)
+		}
+	}
+	return input
+}
+
+// categoryAttributeSchema memuat skema atribut kategori sesuai urutan posisi
+func categoryAttributeSchema(categoryId int64) ([]models.CategoryAttribute, error) {
+	var schema []models.CategoryAttribute
+	err := initialize.DB.Where("category_id = ?", categoryId).Order("position ASC").Find(&schema).Error
+	return schema, err
+}
+
+// validateAttributes memvalidasi nilai atribut (name -> nilai) terhadap skema kategori, pesan kesalahan memakai bahasa locale.
+// Kesalahan database dikembalikan terpisah agar tidak dianggap sebagai kategori tanpa skema
+func validateAttributes(locale string, categoryId int64, input map[string]interface{}) ([]models.ProductAttributeValue, map[string][]string, error) {
+	schema, err := categoryAttributeSchema(categoryId)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, errorsMap := checkAttributes(locale, schema, input)
+	return values, errorsMap, nil
+}
+
+// checkAttributes memvalidasi nilai atribut terhadap skema yang sudah dimuat
+func checkAttributes(locale string, schema []models.CategoryAttribute, input map[string]interface{}) ([]models.ProductAttributeValue, map[string][]string) {
+	errorsMap := make(map[string][]string)
+	var values []models.ProductAttributeValue
+	known := make(map[string]bool)
+	for _, attr := range schema {
+		known[attr.Name] = true
+		field := "attributes." + attr.Name
+		rawValue, ok := input[attr.Name]
+		if !ok || rawValue == nil || rawValue == "" {
+			if attr.Required {
+				errorsMap[field] = append(errorsMap[field], helpers.MessageIn(locale, helpers.MsgFieldRequired, attr.Name))
+			}
+			continue
+		}
+		value, err := attributeValue(attr, rawValue)
+		if err != nil {
+			errorsMap[field] = append(errorsMap[field], helpers.ErrorMessageIn(locale, err))
+			continue
+		}
+		values = append(values, value)
+	}
+	for name := range input {
+		if !known[name] {
+			field := "attributes." + name
+			errorsMap[field] = append(errorsMap[field], helpers.MessageIn(locale, helpers.MsgAttributeNotDefined, name))
+		}
+	}
+
+	if len(errorsMap) > 0 {
+		return nil, errorsMap
+	}
+	return values, nil
+}
+
+// saveProductAttributes mengganti seluruh nilai atribut produk
+func saveProductAttributes(tx *gorm.DB, productId int64, values []models.ProductAttributeValue) error {
+	if err := tx.Where("product_id = ?", productId).Delete(&models.ProductAttributeValue{}).Error; err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	for i := range values {
+		values[i].ProductId = productId
+	}
+	return tx.Omit("Attribute").Create(&values).Error
+}
+
+// productAttributeMap menyusun nilai atribut (dengan Attribute ter-preload) menjadi map name -> nilai
+func productAttributeMap(values []models.ProductAttributeValue) map[string]interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make(map[string]interface{}, len(values))
+	for _, value := range values {
+		switch value.Attribute.Type {
+		case models.AttributeTypeNumber:
+			result[value.Attribute.Name] = value.NumberValue
+		case models.AttributeTypeRange:
+			result[value.Attribute.Name] = map[string]*float64{"min": value.NumberValue, "max": value.MaxValue}
+		case models.AttributeTypeEnum:
+			result[value.Attribute.Name] = value.TextValue
+		case models.AttributeTypeBoolean:
+			result[value.Attribute.Name] = value.BoolValue
+		}
+	}
+	return result
+}
+
+// productAttributeRows mengubah nilai atribut menjadi pasangan label dan teks nilai berurutan sesuai posisi atribut
+func productAttributeRows(values []models.ProductAttributeValue) []models.BrochureAttribute {
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Attribute.Position < values[j].Attribute.Position
+	})
+	rows := make([]models.BrochureAttribute, 0, len(values))
+	for _, value := range values {
+		var text string
+		switch value.Attribute.Type {
+		case models.AttributeTypeNumber:
+			text = helpers.SpreadsheetText(value.NumberValue)
+		case models.AttributeTypeRange:
+			// Format rentang sama dengan kolom attr.<name> pada impor
+			if value.NumberValue != nil && value.MaxValue != nil {
+				text = helpers.SpreadsheetText(value.NumberValue) + "-" + helpers.SpreadsheetText(value.MaxValue)
+			}
+		case models.AttributeTypeEnum:
+			text = value.TextValue
+		case models.AttributeTypeBoolean:
+			text = helpers.SpreadsheetText(value.BoolValue)
+		}
+		if text == "" {
+			continue
+		}
+		if value.Attribute.Unit != "" && (value.Attribute.Type == models.AttributeTypeNumber || value.Attribute.Type == models.AttributeTypeRange) {
+			text += " " + value.Attribute.Unit
+		}
+		label := value.Attribute.Label
+		if label == "" {
+			label = value.Attribute.Name
+		}
+		rows = append(rows, models.BrochureAttribute{Label: label, Value: text})
+	}
+	return rows
+}
+
+// productAttributeText menuliskan nilai atribut sebagai teks, misalnya "Pixel Pitch: 3.9 mm; IP Rating: IP65"
+func productAttributeText(values []models.ProductAttributeValue) string {
+	rows := productAttributeRows(values)
+	parts := make([]string, 0, len(rows))
+	for _, row := range rows {
+		parts = append(parts, row.Label+": "+row.Value)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// attributeTypeByName mencari tipe atribut, dibatasi ke kategori jika categoryID diisi
+func attributeTypeByName(name string, categoryID string) (string, bool) {
+	query := initialize.DB.Model(&models.CategoryAttribute{}).Where("name = ?", name)
+	// Atribut subkategori juga berlaku saat memfilter kategori induknya
+	if categoryID != "" {
+		categoryIDs, err := categoryFilterIDs(categoryID)
+		if err != nil {
+			return "", false
+		}
+		query = query.Where("category_id IN ?", categoryIDs)
+	}
+	var attr models.CategoryAttribute
+	if err := query.Order("attribute_id ASC").First(&attr).Error; err != nil {
+		return "", false
+	}
+	return attr.Type, true
+}
+
+// applyAttributeFilters menerapkan filter attr[name]=nilai, attr[name][min] dan attr[name][max] dari query string
+func applyAttributeFilters(c *fiber.Ctx, query *gorm.DB, categoryID string) (*gorm.DB, error) {
+	return applyAttributeFiltersExcept(c, query, categoryID, "")
+}
+
+// applyAttributeFiltersExcept sama dengan applyAttributeFilters tetapi melewati atribut except, dipakai untuk menghitung facet
+func applyAttributeFiltersExcept(c *fiber.Ctx, query *gorm.DB, categoryID string, except string) (*gorm.DB, error) {
+	queries := c.Queries()
+	keys := make([]string, 0, len(queries))
+	for key := range queries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		match := attributeQueryPattern.FindStringSubmatch(key)
+		if match == nil || queries[key] == "" {
+			continue
+		}
+		name, bound, raw := match[1], match[2], queries[key]
+		if name == except {
+			continue
+		}
+		attrType, ok := attributeTypeByName(name, categoryID)
+		if !ok {
+			return nil, helpers.NewError(helpers.MsgAttributeUnknown, name)
+		}
+
+		var condition string
+		var arg interface{}
+		switch attrType {
+		case models.AttributeTypeNumber, models.AttributeTypeRange:
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, helpers.NewError(helpers.MsgAttributeNotNumber, name)
+			}
+			arg = number
+			switch {
+			case attrType == models.AttributeTypeNumber && bound == "min":
+				condition = "pav.number_value >= ?"
+			case attrType == models.AttributeTypeNumber && bound == "max":
+				condition = "pav.number_value <= ?"
+			case attrType == models.AttributeTypeNumber:
+				condition = "pav.number_value = ?"
+			// Rentang produk harus beririsan dengan filter
+			case bound == "min":
+				condition = "pav.max_value >= ?"
+			case bound == "max":
+				condition = "pav.number_value <= ?"
+			default:
+				condition = "pav.number_value <= ? AND pav.max_value >= ?"
+				arg = []interface{}{number, number}
+			}
+		case models.AttributeTypeEnum:
+			if bound != "" {
+				return nil, helpers.NewError(helpers.MsgAttributeBoundUnsupported, name, bound)
+			}
+			condition = "pav.text_value IN ?"
+			arg = strings.Split(raw, ",")
+		case models.AttributeTypeBoolean:
+			flag, err := strconv.ParseBool(raw)
+			if err != nil || bound != "" {
+				return nil, helpers.NewError(helpers.MsgAttributeNotBoolean, name)
+			}
+			condition = "pav.bool_value = ?"
+			arg = flag
+		}
+
+		args := []interface{}{name}
+		if multiple, ok := arg.([]interface{}); ok {
+			args = append(args, multiple...)
+		} else {
+			args = append(args, arg)
+		}
+		query = query.Where("products.product_id IN (SELECT pav.product_id FROM product_attribute_values pav "+
+			"JOIN category_attributes ca ON ca.attribute_id = pav.attribute_id WHERE ca.name = ? AND "+condition+")", args...)
+	}
+	return query, nil
+}
+
+// applyAttributeSort mengurutkan berdasarkan nilai atribut jika sort_by berbentuk attr[name]
+func applyAttributeSort(query *gorm.DB, sortBy string, direction string, categoryID string) (*gorm.DB, bool) {
+	match := attributeQueryPattern.FindStringSubmatch(sortBy)
+	if match == nil || match[2] != "" {
+		return query, false
+	}
+	attrType, ok := attributeTypeByName(match[1], categoryID)
+	if !ok {
+		return query, false
+	}
+
+	column := "number_value"
+	switch attrType {
+	case models.AttributeTypeEnum:
+		column = "text_value"
+	case models.AttributeTypeBoolean:
+		column = "bool_value"
+	}
+	order := "ASC"
+	if strings.EqualFold(direction, "desc") {
+		order = "DESC"
+	}
+	return query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL: "(SELECT pav." + column + " FROM product_attribute_values pav " +
+			"JOIN category_attributes ca ON ca.attribute_id = pav.attribute_id " +
+			"WHERE pav.product_id = products.product_id AND ca.name = ? LIMIT 1) " + order,
+		Vars: []interface{}{match[1]},
+	}}), true
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/models"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func boolPtr(v bool) *bool { return &v }
+
+var attributeSchema = []models.CategoryAttribute{
+	{AttributeId: 1, Name: "pitch", Type: models.AttributeTypeNumber, Min: float64Ptr(1), Max: float64Ptr(20), Required: true},
+	{AttributeId: 2, Name: "brightness", Type: models.AttributeTypeRange, Min: float64Ptr(0)},
+	{AttributeId: 3, Name: "placement", Type: models.AttributeTypeEnum, Options: []string{"indoor", "outdoor"}},
+	{AttributeId: 4, Name: "waterproof", Type: models.AttributeTypeBoolean},
+}
+
+func TestAttributeValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		attr    models.CategoryAttribute
+		raw     interface{}
+		want    models.ProductAttributeValue
+		wantErr string
+	}{
+		{"number", attributeSchema[0], 3.9, models.ProductAttributeValue{AttributeId: 1, NumberValue: float64Ptr(3.9)}, ""},
+		{"number from form", attributeSchema[0], " 10 ", models.ProductAttributeValue{AttributeId: 1, NumberValue: float64Ptr(10)}, ""},
+		{"number not numeric", attributeSchema[0], "p10", models.ProductAttributeValue{}, helpers.MsgAttributeNotNumber},
+		{"number out of bounds", attributeSchema[0], 25.0, models.ProductAttributeValue{}, helpers.MsgAttributeBetween},
+		{"range object", attributeSchema[1], map[string]interface{}{"min": 800.0, "max": "1200"}, models.ProductAttributeValue{AttributeId: 2, NumberValue: float64Ptr(800), MaxValue: float64Ptr(1200)}, ""},
+		{"range array", attributeSchema[1], []interface{}{800.0, 1200.0}, models.ProductAttributeValue{AttributeId: 2, NumberValue: float64Ptr(800), MaxValue: float64Ptr(1200)}, ""},
+		{"range inverted", attributeSchema[1], []interface{}{1200.0, 800.0}, models.ProductAttributeValue{}, helpers.MsgAttributeRangeInverted},
+		{"range below minimum", attributeSchema[1], []interface{}{-1.0, 800.0}, models.ProductAttributeValue{}, helpers.MsgAttributeAtLeast},
+		{"range malformed", attributeSchema[1], []interface{}{800.0}, models.ProductAttributeValue{}, helpers.MsgAttributeNotRange},
+		{"enum", attributeSchema[2], "outdoor", models.ProductAttributeValue{AttributeId: 3, TextValue: "outdoor"}, ""},
+		{"enum unknown option", attributeSchema[2], "semi-outdoor", models.ProductAttributeValue{}, helpers.MsgAttributeNotOption},
+		{"boolean", attributeSchema[3], true, models.ProductAttributeValue{AttributeId: 4, BoolValue: boolPtr(true)}, ""},
+		{"boolean from form", attributeSchema[3], "false", models.ProductAttributeValue{AttributeId: 4, BoolValue: boolPtr(false)}, ""},
+		{"boolean invalid", attributeSchema[3], "ya", models.ProductAttributeValue{}, helpers.MsgAttributeNotBoolean},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := attributeValue(tt.attr, tt.raw)
+			if tt.wantErr != "" {
+				var appErr *helpers.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantErr {
+					t.Fatalf("err = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(value, tt.want) {
+				t.Errorf("value = %+v, want %+v", value, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      map[string]interface{}
+		wantIds    []int64
+		wantFields []string
+	}{
+		{"valid", map[string]interface{}{"pitch": 10.0, "placement": "indoor", "waterproof": ""}, []int64{1, 3}, nil},
+		{"required attribute missing on create", map[string]interface{}{}, nil, []string{"attributes.pitch"}},
+		{"required attribute blank", map[string]interface{}{"pitch": ""}, nil, []string{"attributes.pitch"}},
+		{"unknown and invalid attributes", map[string]interface{}{"pitch": 10.0, "placement": "roof", "color": "red"}, nil, []string{"attributes.color", "attributes.placement"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, errorsMap := checkAttributes(models.LocaleEnglish, attributeSchema, tt.input)
+			var ids []int64
+			for _, value := range values {
+				ids = append(ids, value.AttributeId)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIds) {
+				t.Errorf("attribute ids = %v, want %v", ids, tt.wantIds)
+			}
+			var fields []string
+			for field, messages := range errorsMap {
+				fields = append(fields, field)
+				if len(messages) == 0 || messages[0] == "" {
+					t.Errorf("%s has no message", field)
+				}
+			}
+			sort.Strings(fields)
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("error fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestMovedAttributeInput(t *testing.T) {
+	stored := []models.ProductAttributeValue{
+		{AttributeId: 11, Attribute: models.CategoryAttribute{Name: "pitch", Type: models.AttributeTypeNumber}, NumberValue: float64Ptr(4)},
+		{AttributeId: 12, Attribute: models.CategoryAttribute{Name: "brightness", Type: models.AttributeTypeRange}, NumberValue: float64Ptr(800), MaxValue: float64Ptr(1200)},
+		{AttributeId: 13, Attribute: models.CategoryAttribute{Name: "color", Type: models.AttributeTypeEnum}, TextValue: "red"},
+	}
+
+	tests := []struct {
+		name       string
+		stored     []models.ProductAttributeValue
+		wantIds    []int64
+		wantFields []string
+	}{
+		{"matching names move to the new schema", stored, []int64{1, 2}, nil},
+		{"required attribute missing in old category", stored[1:], nil, []string{"attributes.pitch"}},
+		{"no stored values", nil, nil, []string{"attributes.pitch"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, errorsMap := checkAttributes(models.LocaleEnglish, attributeSchema, movedAttributeInput(attributeSchema, tt.stored))
+			var ids []int64
+			for _, value := range values {
+				ids = append(ids, value.AttributeId)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIds) {
+				t.Errorf("attribute ids = %v, want %v", ids, tt.wantIds)
+			}
+			var fields []string
+			for field := range errorsMap {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("error fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+	"image"
+	"os"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func GetUserAvatar(c *fiber.Ctx) error {
+	// Ambil ID pengguna dari parameter URL
+	userId := c.Params("id")
+
+	var user models.User
+	if err := initialize.DB.Preload("File").Where("user_id = ?", userId).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgUserNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Foto yang diunggah pengguna
+	if user.FileId != nil && user.File.Path != "" {
+		if _, err := os.Stat("." + user.File.Path); err == nil {
+			c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+			return c.SendFile("." + user.File.Path)
+		}
+	}
+
+	// Tanpa foto, buat avatar inisial dari nama lengkap
+	size, err := strconv.Atoi(c.Query("size", "128"))
+	if err != nil || size < 16 || size > 1024 {
+		size = 128
+	}
+	c.Set(fiber.HeaderContentType, "image/svg+xml")
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.SendString(helpers.InitialsAvatarSVG(user.FullName, size))
+}
+
+// avatarCropRect membaca area crop dari form, default persegi di tengah gambar
+func avatarCropRect(c *fiber.Ctx, file models.File) image.Rectangle {
+	x, errX := strconv.Atoi(c.FormValue("crop_x"))
+	y, errY := strconv.Atoi(c.FormValue("crop_y"))
+	width, errW := strconv.Atoi(c.FormValue("crop_width"))
+	height, errH := strconv.Atoi(c.FormValue("crop_height"))
+	if errX != nil || errY != nil || errW != nil || errH != nil || width <= 0 || height <= 0 {
+		return helpers.CenterSquare(file.Width, file.Height)
+	}
+	return image.Rect(x, y, x+width, y+height)
+}
+
+func UploadMyAvatar(c *fiber.Ctx) error {
+	// Ambil ID pengguna dari lokal konteks
+	userID, ok := c.Locals("userID").(float64)
+	if !ok {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTokenUserFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	var user models.User
+	if err := initialize.DB.Where("user_id = ?", int64(userID)).First(&user).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgUserNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	upload, err := c.FormFile("file")
+	if err != nil {
+		response := helpers.NewResponseError(c, map[string][]string{"file": {helpers.Message(c, helpers.MsgFileRequired)}})
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	fileModel, err := saveUpload(c, upload)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Avatar hanya menerima gambar yang bisa di-crop
+	if fileModel.MimeType != "image/jpeg" && fileModel.MimeType != "image/png" {
+		deleteFileIfUnused(fileModel.FileId)
+		response := helpers.NewResponseError(c, map[string][]string{"file": {helpers.Message(c, helpers.MsgAvatarInvalidImage)}})
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	// Crop lalu hitung ulang ukuran dan checksum file
+	if err := helpers.CropImageFile("."+fileModel.Path, avatarCropRect(c, fileModel)); err != nil {
+		deleteFileIfUnused(fileModel.FileId)
+		response := helpers.NewResponseError(c, map[string][]string{"crop": {helpers.ErrorMessage(c, err)}})
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	info, err := helpers.ProbeMediaFile("."+fileModel.Path, "")
+	if err == nil {
+		err = initialize.DB.Model(&fileModel).Updates(map[string]interface{}{
+			"size":     info.Size,
+			"checksum": info.Checksum,
+			"width":    info.Width,
+			"height":   info.Height,
+		}).Error
+	}
+	if err != nil {
+		deleteFileIfUnused(fileModel.FileId)
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Ganti foto pengguna, foto lama dihapus jika tidak dipakai data lain
+	previousFileId := user.FileId
+	if err := initialize.DB.Model(&user).Update("file_id", fileModel.FileId).Error; err != nil {
+		deleteFileIfUnused(fileModel.FileId)
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgUserSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if previousFileId != nil {
+		deleteFileIfUnused(*previousFileId)
+	}
+
+	user.FileId = &fileModel.FileId
+	user.File = fileModel
+	user.AvatarUrl = user.AvatarPath()
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   user,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func DeleteMyAvatar(c *fiber.Ctx) error {
+	// Ambil ID pengguna dari lokal konteks
+	userID, ok := c.Locals("userID").(float64)
+	if !ok {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTokenUserFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	var user models.User
+	if err := initialize.DB.Where("user_id = ?", int64(userID)).First(&user).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgUserNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	if user.FileId != nil {
+		previousFileId := *user.FileId
+		if err := initialize.DB.Model(&user).Update("file_id", nil).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgUserSaveFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		deleteFileIfUnused(previousFileId)
+	}
+
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgAvatarDeleted)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -0,0 +1,211 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// brochureCacheDir adalah folder PDF yang sudah dibuat, dapat diganti lewat BROCHURE_CACHE_DIR
+func brochureCacheDir() string {
+	if dir := os.Getenv("BROCHURE_CACHE_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "matahariled-brochures")
+}
+
+// brochureImage mengubah file produk menjadi gambar brosur, gambar bawaan "tidak ditemukan" tidak ikut dicetak
+func brochureImage(path string, file models.File) *models.BrochureImage {
+	if path == "" || sharedAssetPaths[path] {
+		return nil
+	}
+	return &models.BrochureImage{Path: path, MimeType: file.MimeType, Checksum: file.Checksum}
+}
+
+// brochureProduct menyusun isi brosur dari produk yang sudah diterjemahkan beserta relasinya
+func brochureProduct(product models.Product) models.BrochureProduct {
+	content := models.BrochureProduct{
+		ProductId:     product.ProductId,
+		Title:         product.Title,
+		Category:      product.Category.Category,
+		Description:   product.Description,
+		Specification: product.Specification,
+		Attributes:    productAttributeRows(product.Attributes),
+		UpdatedAt:     product.UpdatedAt.Format("2006-01-02"),
+	}
+	if product.FileId != 0 {
+		content.Image = brochureImage(product.File.Path, product.File)
+	}
+	for _, gallery := range product.Gallery {
+		if image := brochureImage(gallery.Path, gallery.File); image != nil {
+			content.Gallery = append(content.Gallery, *image)
+		}
+	}
+	return content
+}
+
+// sendBrochure mengirim PDF dari cache. Kunci cache adalah hash isi brosur, branding dan bahasa,
+// sehingga PDF baru dibuat hanya jika produk, terjemahan, gambar atau branding berubah.
+func sendBrochure(c *fiber.Ctx, key string, filename string, content interface{}, render func(w io.Writer) error) error {
+	locale := helpers.Locale(c)
+	branding := helpers.BrandingFromEnv()
+	fingerprint, err := json.Marshal(map[string]interface{}{"branding": branding, "locale": locale, "content": content})
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgBrochureFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	sum := sha256.Sum256(fingerprint)
+	hash := hex.EncodeToString(sum[:8])
+
+	etag := `"` + hash + `"`
+	c.Set(fiber.HeaderETag, etag)
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	dir := brochureCacheDir()
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s-%s.pdf", key, locale, hash))
+	if _, err := os.Stat(path); err != nil {
+		if err := writeBrochure(dir, path, render); err != nil {
+			log.Printf("brochure %s: %v", key, err)
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgBrochureFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		// Versi lama brosur yang sama sudah tidak terpakai
+		stale, _ := filepath.Glob(filepath.Join(dir, fmt.Sprintf("%s-%s-*.pdf", key, locale)))
+		for _, old := range stale {
+			if old != path {
+				os.Remove(old)
+			}
+		}
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s"`, filename))
+	c.Set(fiber.HeaderContentLanguage, locale)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgBrochureFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	return c.Send(data)
+}
+
+// writeBrochure membuat PDF ke file sementara lalu memindahkannya, sehingga permintaan bersamaan tidak membaca file setengah jadi
+func writeBrochure(dir string, path string, render func(w io.Writer) error) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "render-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := render(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// brochureFilename membuat nama file PDF yang aman dari slug atau judul
+func brochureFilename(name string, fallback string) string {
+	name = unsafeFilenameChars.ReplaceAllString(name, "-")
+	if name == "" {
+		name = fallback
+	}
+	return name + ".pdf"
+}
+
+// GetProductBrochure mengirim brosur PDF satu produk berisi gambar utama, deskripsi, spesifikasi, atribut dan galeri
+func GetProductBrochure(c *fiber.Ctx) error {
+	productId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidID)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	var product models.Product
+	if err := initialize.DB.Preload("Category").Preload("File").Preload("Gallery.File").Preload("Attributes.Attribute").
+		Scopes(visibleScope(c, "products")).Where("product_id = ?", productId).First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgProductNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	products := []models.Product{product}
+	translateProducts(c, products)
+	content := brochureProduct(products[0])
+
+	locale := helpers.Locale(c)
+	branding := helpers.BrandingFromEnv()
+	key := "product-" + strconv.FormatInt(productId, 10)
+	return sendBrochure(c, key, brochureFilename(product.Slug, key), content, func(w io.Writer) error {
+		return helpers.RenderProductBrochure(w, branding, locale, content)
+	})
+}
+
+// GetCategoryCatalog mengirim katalog PDF untuk semua produk dalam kategori beserta subkategorinya
+func GetCategoryCatalog(c *fiber.Ctx) error {
+	var category models.Category
+	if err := initialize.DB.Where("category_id = ?", c.Params("id")).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgCategoryNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	categoryIDs, err := categoryFilterIDs(strconv.FormatInt(category.CategoryId, 10))
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusInternalServerError, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	var products []models.Product
+	if err := initialize.DB.Preload("Category").Preload("File").Preload("Gallery.File").Preload("Attributes.Attribute").
+		Scopes(visibleScope(c, "products")).Where("category_id IN ?", categoryIDs).
+		Order("title ASC, product_id ASC").Find(&products).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if len(products) == 0 {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgCatalogEmpty)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+	categories := []models.Category{category}
+	translateCategories(c, categories)
+	translateProducts(c, products)
+
+	title := categories[0].Category
+	contents := make([]models.BrochureProduct, 0, len(products))
+	for _, product := range products {
+		contents = append(contents, brochureProduct(product))
+	}
+
+	locale := helpers.Locale(c)
+	branding := helpers.BrandingFromEnv()
+	key := "category-" + strconv.FormatInt(category.CategoryId, 10)
+	content := map[string]interface{}{"title": title, "products": contents}
+	return sendBrochure(c, key, brochureFilename(category.Slug, key), content, func(w io.Writer) error {
+		return helpers.RenderCatalog(w, branding, locale, title, contents)
+	})
+}
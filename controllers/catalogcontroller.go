@@ -0,0 +1,371 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// catalogFilter adalah filter katalog yang sedang aktif
+type catalogFilter struct {
+	categoryID string
+	tagIDs     []int64
+	search     string
+}
+
+type categoryFacet struct {
+	CategoryId int64  `json:"category_id"`
+	Category   string `json:"category"`
+	Slug       string `json:"slug"`
+	ParentId   *int64 `json:"parent_id"`
+	Count      int64  `json:"count"`
+}
+
+type tagFacet struct {
+	TagId int64  `json:"tag_id"`
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+type attributeFacetValue struct {
+	Value interface{} `json:"value"`
+	Count int64       `json:"count"`
+}
+
+type attributeFacet struct {
+	Name   string                `json:"name"`
+	Label  string                `json:"label"`
+	Type   string                `json:"type"`
+	Unit   string                `json:"unit,omitempty"`
+	Values []attributeFacetValue `json:"values,omitempty"`
+	Min    *float64              `json:"min,omitempty"`
+	Max    *float64              `json:"max,omitempty"`
+	Count  int64                 `json:"count"`
+}
+
+// catalogProductTags adalah tabel turunan pasangan produk dan tag, gabungan tag langsung dan tag kategori
+const catalogProductTags = "(SELECT product_product_id AS product_id, tag_tag_id AS tag_id FROM ProductTag" +
+	" UNION SELECT p.product_id, ct.tag_tag_id FROM products p JOIN CategoryTag ct ON ct.category_category_id = p.category_id)"
+
+// catalogQuery menyusun query produk untuk filter aktif; except melewati satu dimensi ("category", "tag" atau "attr:<name>")
+// sehingga facet dimensi itu tetap menampilkan pilihan lain yang bisa dipilih
+func catalogQuery(c *fiber.Ctx, filter catalogFilter, except string) (*gorm.DB, error) {
+	// Katalog publik hanya berisi produk yang sudah terbit
+	query := initialize.DB.Model(&models.Product{}).Scopes(publishedScope("products"))
+	// Kategori mencakup seluruh subkategorinya
+	if filter.categoryID != "" && except != "category" {
+		categoryIDs, err := categoryFilterIDs(filter.categoryID)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("products.category_id IN ?", categoryIDs)
+	}
+	// Tag produk berasal dari tag langsung maupun tag kategorinya, beberapa tag berarti salah satu cocok
+	if len(filter.tagIDs) > 0 && except != "tag" {
+		query = query.Where("products.product_id IN (SELECT product_id FROM "+catalogProductTags+" WHERE tag_id IN ?)", filter.tagIDs)
+	}
+	if filter.search != "" {
+		query = query.Where("products.title LIKE ?", "%"+filter.search+"%")
+	}
+	return applyAttributeFiltersExcept(c, query, filter.categoryID, strings.TrimPrefix(except, "attr:"))
+}
+
+func catalogCategoryFacets(c *fiber.Ctx, filter catalogFilter) ([]categoryFacet, error) {
+	query, err := catalogQuery(c, filter, "category")
+	if err != nil {
+		return nil, err
+	}
+	var counts []struct {
+		CategoryId int64
+		Count      int64
+	}
+	if err := query.Select("products.category_id AS category_id, COUNT(*) AS count").Group("products.category_id").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	var categories []models.Category
+	if err := initialize.DB.Order("category ASC").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	translateCategories(c, categories)
+	tree, err := loadCategoryTree()
+	if err != nil {
+		return nil, err
+	}
+	// Jumlah produk subkategori ikut dihitung pada setiap induknya
+	countByCategory := make(map[int64]int64)
+	for _, row := range counts {
+		for _, category := range tree.ancestors(row.CategoryId) {
+			countByCategory[category.CategoryId] += row.Count
+		}
+	}
+	facets := []categoryFacet{}
+	for _, category := range categories {
+		if count := countByCategory[category.CategoryId]; count > 0 {
+			facets = append(facets, categoryFacet{category.CategoryId, category.Category, category.Slug, category.ParentId, count})
+		}
+	}
+	return facets, nil
+}
+
+func catalogTagFacets(c *fiber.Ctx, filter catalogFilter) ([]tagFacet, error) {
+	query, err := catalogQuery(c, filter, "tag")
+	if err != nil {
+		return nil, err
+	}
+	var counts []struct {
+		TagId int64
+		Count int64
+	}
+	if err := query.Joins("JOIN " + catalogProductTags + " pt ON pt.product_id = products.product_id").
+		Select("pt.tag_id AS tag_id, COUNT(DISTINCT products.product_id) AS count").
+		Group("pt.tag_id").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	var tags []models.Tag
+	if err := initialize.DB.Order("tag ASC").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	countByTag := make(map[int64]int64)
+	for _, row := range counts {
+		countByTag[row.TagId] = row.Count
+	}
+	facets := []tagFacet{}
+	for _, tag := range tags {
+		if count := countByTag[tag.TagId]; count > 0 {
+			facets = append(facets, tagFacet{tag.TagId, tag.Tag, count})
+		}
+	}
+	return facets, nil
+}
+
+func catalogAttributeFacets(c *fiber.Ctx, filter catalogFilter) ([]attributeFacet, error) {
+	// Tanpa kategori, atribut dengan nama yang sama dari beberapa kategori digabung
+	var schema []models.CategoryAttribute
+	query := initialize.DB.Order("position ASC, attribute_id ASC")
+	if filter.categoryID != "" {
+		categoryIDs, err := categoryFilterIDs(filter.categoryID)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("category_id IN ?", categoryIDs)
+	}
+	if err := query.Find(&schema).Error; err != nil {
+		return nil, err
+	}
+
+	facets := []attributeFacet{}
+	seen := make(map[string]bool)
+	for _, attr := range schema {
+		if seen[attr.Name] {
+			continue
+		}
+		seen[attr.Name] = true
+
+		base, err := catalogQuery(c, filter, "attr:"+attr.Name)
+		if err != nil {
+			return nil, err
+		}
+		base = base.Joins("JOIN product_attribute_values pav ON pav.product_id = products.product_id").
+			Joins("JOIN category_attributes ca ON ca.attribute_id = pav.attribute_id AND ca.name = ?", attr.Name)
+
+		facet := attributeFacet{Name: attr.Name, Label: attr.Label, Type: attr.Type, Unit: attr.Unit}
+		switch attr.Type {
+		case models.AttributeTypeEnum:
+			var rows []struct {
+				Value string
+				Count int64
+			}
+			if err := base.Select("pav.text_value AS value, COUNT(DISTINCT products.product_id) AS count").
+				Group("pav.text_value").Scan(&rows).Error; err != nil {
+				return nil, err
+			}
+			// Urutkan sesuai urutan pilihan pada skema
+			countByValue := make(map[string]int64)
+			for _, row := range rows {
+				countByValue[row.Value] = row.Count
+				facet.Count += row.Count
+			}
+			for _, option := range attr.Options {
+				if count, ok := countByValue[option]; ok {
+					facet.Values = append(facet.Values, attributeFacetValue{option, count})
+					delete(countByValue, option)
+				}
+			}
+			for _, row := range rows {
+				if _, ok := countByValue[row.Value]; ok {
+					facet.Values = append(facet.Values, attributeFacetValue{row.Value, row.Count})
+				}
+			}
+		case models.AttributeTypeBoolean:
+			var rows []struct {
+				Value bool
+				Count int64
+			}
+			if err := base.Select("pav.bool_value AS value, COUNT(DISTINCT products.product_id) AS count").
+				Group("pav.bool_value").Order("pav.bool_value DESC").Scan(&rows).Error; err != nil {
+				return nil, err
+			}
+			for _, row := range rows {
+				facet.Values = append(facet.Values, attributeFacetValue{row.Value, row.Count})
+				facet.Count += row.Count
+			}
+		default:
+			var row struct {
+				Min   *float64
+				Max   *float64
+				Count int64
+			}
+			if err := base.Select("MIN(pav.number_value) AS min, MAX(COALESCE(pav.max_value, pav.number_value)) AS max, COUNT(DISTINCT products.product_id) AS count").
+				Scan(&row).Error; err != nil {
+				return nil, err
+			}
+			facet.Min, facet.Max, facet.Count = row.Min, row.Max, row.Count
+		}
+
+		if facet.Count > 0 {
+			facets = append(facets, facet)
+		}
+	}
+	return facets, nil
+}
+
+// catalogFacets menghitung jumlah produk per kategori, tag dan atribut untuk filter aktif
+func catalogFacets(c *fiber.Ctx, filter catalogFilter) (map[string]interface{}, error) {
+	categories, err := catalogCategoryFacets(c, filter)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := catalogTagFacets(c, filter)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := catalogAttributeFacets(c, filter)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"categories": categories,
+		"tags":       tags,
+		"attributes": attributes,
+	}, nil
+}
+
+func GetCatalog(c *fiber.Ctx) error {
+	// Ambil nilai parameter limit, page, sort, sort_by, dan filter dari query string
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	page, _ := strconv.Atoi(c.Query("page"))
+	sort := c.Query("sort")
+	sortBy := c.Query("sort_by")
+	if limit <= 0 || limit > 100 {
+		limit = 12
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	filter := catalogFilter{
+		categoryID: c.Query("category_id"),
+		search:     strings.TrimSpace(c.Query("search")),
+	}
+	// Kategori juga bisa dipilih dengan slug
+	if slug := c.Query("category"); slug != "" && filter.categoryID == "" {
+		var category models.Category
+		if err := initialize.DB.Where("slug = ?", slug).First(&category).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgCategoryNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		filter.categoryID = strconv.FormatInt(category.CategoryId, 10)
+	}
+	for _, raw := range strings.Split(c.Query("tag_ids"), ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		tagId, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidTagID)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		filter.tagIDs = append(filter.tagIDs, tagId)
+	}
+
+	query, err := catalogQuery(c, filter, "")
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	var totalRecords int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgCatalogFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Katalog publik hanya mengizinkan pengurutan kolom tertentu atau atribut
+	var sorted bool
+	query, sorted = applyAttributeSort(query, sortBy, sort, filter.categoryID)
+	if !sorted {
+		direction := "ASC"
+		if strings.EqualFold(sort, "desc") {
+			direction = "DESC"
+		}
+		switch sortBy {
+		case "title", "created_at", "updated_at":
+			query = query.Order("products." + sortBy + " " + direction)
+		default:
+			query = query.Order("products.created_at DESC")
+		}
+	}
+
+	var products []models.Product
+	if err := query.Preload("File").Preload("Category").Preload("Attributes.Attribute").Limit(limit).Offset(offset).Find(&products).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgCatalogFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	translateProducts(c, products)
+
+	items := make([]models.ProductResponse, 0, len(products))
+	for _, product := range products {
+		items = append(items, models.ProductResponse{
+			ProductId:     product.ProductId,
+			Title:         product.Title,
+			Slug:          product.Slug,
+			Specification: product.Specification,
+			Description:   product.Description,
+			CreatedAt:     product.CreatedAt,
+			UpdatedAt:     product.UpdatedAt,
+			FileId:        product.FileId,
+			CategoryId:    product.CategoryId,
+			PathFile:      product.File.Path,
+			Category:      product.Category.Category,
+			Attributes:    productAttributeMap(product.Attributes),
+		})
+	}
+
+	facets, err := catalogFacets(c, filter)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgCatalogFacetsFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"products":     items,
+			"current_page": page,
+			"per_page":     limit,
+			"last_page":    int(math.Ceil(float64(totalRecords) / float64(limit))),
+			"total":        totalRecords,
+			"facets":       facets,
+		},
+	})
+}
@@ -4,35 +4,27 @@ import (
 	"Matahariled/helpers"
 	"Matahariled/initialize"
 	"Matahariled/models"
-	"fmt"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 )
 
-func init() {
-	validate = validator.New()
-}
-
 func GetCategoriesLabel(c *fiber.Ctx) error {
 	// Ambil semua kategori dari database
 	var categories []models.Category
 	if err := initialize.DB.Find(&categories).Error; err != nil {
 		// Jika terjadi kesalahan saat mengambil kategori, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    500,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, 500, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	translateCategories(c, categories)
 
 	// Buat respons dengan format yang diinginkan
 	var categoryOptions []map[string]interface{}
 	for _, category := range categories {
 		option := map[string]interface{}{
-			"value": category.CategoryId,
-			"label": category.Category,
+			"value":     category.CategoryId,
+			"label":     category.Category,
+			"parent_id": category.ParentId,
 		}
 		categoryOptions = append(categoryOptions, option)
 	}
@@ -51,11 +43,7 @@ func GetCountCategory(c *fiber.Ctx) error {
 	var count int64
 	if err := initialize.DB.Model(&models.Category{}).Count(&count).Error; err != nil {
 		// Jika terjadi kesalahan saat menghitung kategori, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -83,39 +71,42 @@ func CreateCategory(c *fiber.Ctx) error {
 
 	// Validasi data kategori
 	if err := validate.Struct(&reqBody); err != nil {
-		errors := make(map[string][]string)
-		for _, err := range err.(validator.ValidationErrors) {
-			field := err.Field()
-			var tagName string
-			switch field {
-			case "Category":
-				tagName = "category"
-			default:
-				tagName = field
-			} // Mendapatkan nama tag JSON yang sesuai
-			message := tagName + " Mohon diisi" // Pesan kesalahan yang disesuaikan
-			errors[tagName] = append(errors[field], message)
-		}
-		response := helpers.ResponseError{
-			Code:   400,
-			Status: "Bad Request",
-			Error:  errors,
-		}
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
+	// Slug dibuat dari nama kategori jika tidak diisi
+	slug, err := resolveSlug(models.SlugEntityCategory, reqBody.Slug, reqBody.Category, 0)
+	if err != nil {
+		return slugErrorResponse(c, err)
+	}
+
+	// Kategori baru diletakkan paling akhir di bawah induknya
+	tree, err := loadCategoryTree()
+	if err != nil {
+		return categoryParentErrorResponse(c, err)
+	}
+	var parentKey int64
+	if reqBody.ParentId != nil && *reqBody.ParentId != 0 {
+		if err := validateCategoryParent(tree, 0, *reqBody.ParentId); err != nil {
+			return categoryParentErrorResponse(c, err)
+		}
+		parentKey = *reqBody.ParentId
+	} else {
+		reqBody.ParentId = nil
+	}
+
 	category := models.Category{
 		Category: reqBody.Category,
+		Slug:     slug,
+		ParentId: reqBody.ParentId,
+		Position: len(tree.children[parentKey]),
 	}
 	if len(reqBody.TagIDs) > 0 {
 		for _, tagID := range reqBody.TagIDs {
 			var tag models.Tag
 			if err := initialize.DB.First(&tag, tagID).Error; err != nil {
-				response := helpers.ResponseMassage{
-					Code:    fiber.StatusBadRequest,
-					Status:  "Bad Request",
-					Message: fmt.Sprintf("Tag with ID %d not found", tagID),
-				}
+				response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgTagIDNotFound, tagID)
 				return c.Status(fiber.StatusBadRequest).JSON(response)
 			}
 			category.Tags = append(category.Tags, tag)
@@ -125,20 +116,12 @@ func CreateCategory(c *fiber.Ctx) error {
 	// Simpan kategori ke database
 	if err := initialize.DB.Create(&category).Error; err != nil {
 		// Jika terjadi kesalahan saat menyimpan kategori, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    500,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, 500, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Kembalikan respons sukses dengan data kategori yang baru dibuat ke klien
-	response := helpers.ResponseMassage{
-		Code:    200,
-		Status:  "Created",
-		Message: "Category Berhasil dibuat",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusCreated, helpers.MsgCategoryCreated)
 	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
@@ -147,34 +130,13 @@ func UpdateCategory(c *fiber.Ctx) error {
 	var updatedCategory models.Category
 	if err := c.BodyParser(&updatedCategory); err != nil {
 		// Jika terjadi kesalahan dalam memparsing body, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    400,
-			Status:  "Bad Request",
-			Message: "Kesalahan Format Pengiriman",
-		}
+		response := helpers.NewResponseMassage(c, 400, helpers.MsgInvalidRequestBody)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Validasi data kategori yang diperbarui
 	if err := validate.Struct(&updatedCategory); err != nil {
-		errors := make(map[string][]string)
-		for _, err := range err.(validator.ValidationErrors) {
-			field := err.Field()
-			var tagName string
-			switch field {
-			case "Category":
-				tagName = "category"
-			default:
-				tagName = field
-			} // Mendapatkan nama tag JSON yang sesuai
-			message := tagName + " Mohon diisi" // Pesan kesalahan yang disesuaikan
-			errors[tagName] = append(errors[field], message)
-		}
-		response := helpers.ResponseError{
-			Code:   400,
-			Status: "Bad Request",
-			Error:  errors,
-		}
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
@@ -182,30 +144,45 @@ func UpdateCategory(c *fiber.Ctx) error {
 	var category models.Category
 	if err := initialize.DB.First(&category, updatedCategory.CategoryId).Error; err != nil {
 		// Jika kategori tidak ditemukan, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    404,
-			Status:  "Not Found",
-			Message: "Data tidak tersedia",
-		}
+		response := helpers.NewResponseMassage(c, 404, helpers.MsgDataNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
+	// Slug hanya berubah jika diisi
+	oldSlug := category.Slug
+	if updatedCategory.Slug != "" || category.Slug == "" {
+		slug, err := resolveSlug(models.SlugEntityCategory, updatedCategory.Slug, updatedCategory.Category, category.CategoryId)
+		if err != nil {
+			return slugErrorResponse(c, err)
+		}
+		updatedCategory.Slug = slug
+	}
+
+	// Induk yang dikirim harus valid, pemindahan ke kategori utama dilakukan lewat endpoint move
+	if updatedCategory.ParentId != nil {
+		tree, err := loadCategoryTree()
+		if err == nil {
+			err = validateCategoryParent(tree, category.CategoryId, *updatedCategory.ParentId)
+		}
+		if err != nil {
+			return categoryParentErrorResponse(c, err)
+		}
+	}
+
 	// Perbarui kategori di database
-	if err := initialize.DB.Model(&category).Updates(&updatedCategory).Error; err != nil {
+	if err := initialize.DB.Model(&category).Omit("Attributes", "Children").Updates(&updatedCategory).Error; err != nil {
 		// Jika terjadi kesalahan saat memperbarui kategori, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    500,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, 500, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	if updatedCategory.Slug != "" {
+		if err := recordSlugChange(initialize.DB, models.SlugEntityCategory, category.CategoryId, oldSlug, updatedCategory.Slug); err != nil {
+			response := helpers.NewResponseMassage(c, 500, helpers.MsgInternalError)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+	}
 
 	// Kembalikan respons sukses dengan data kategori yang diperbarui ke klien
-	response := helpers.ResponseMassage{
-		Code:    200,
-		Status:  "OK",
-		Message: "Category berhasil diupdate",
-	}
+	response := helpers.NewResponseMassage(c, 200, helpers.MsgCategoryUpdated)
 	return c.JSON(response)
 }
@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCategoryParentNotFound = helpers.NewError(helpers.MsgCategoryParentNotFound)
+	ErrCategoryCycle          = helpers.NewError(helpers.MsgCategoryCycle)
+)
+
+// categoryTree memuat seluruh kategori sekaligus; jumlah kategori kecil sehingga pohon cukup dibangun di memori
+type categoryTree struct {
+	categories map[int64]models.Category
+	// children dengan kunci 0 berisi kategori utama
+	children map[int64][]int64
+}
+
+func loadCategoryTree() (*categoryTree, error) {
+	var categories []models.Category
+	if err := initialize.DB.Order("position ASC, category ASC").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	tree := &categoryTree{
+		categories: make(map[int64]models.Category, len(categories)),
+		children:   make(map[int64][]int64),
+	}
+	for _, category := range categories {
+		tree.categories[category.CategoryId] = category
+	}
+	for _, category := range categories {
+		var parentId int64
+		// Induk yang sudah tidak ada dianggap kategori utama
+		if category.ParentId != nil {
+			if _, ok := tree.categories[*category.ParentId]; ok {
+				parentId = *category.ParentId
+			}
+		}
+		tree.children[parentId] = append(tree.children[parentId], category.CategoryId)
+	}
+	return tree, nil
+}
+
+// descendants mengembalikan ID kategori beserta seluruh turunannya
+func (t *categoryTree) descendants(categoryId int64) []int64 {
+	ids := []int64{categoryId}
+	visited := map[int64]bool{categoryId: true}
+	for i := 0; i < len(ids); i++ {
+		for _, childId := range t.children[ids[i]] {
+			if !visited[childId] {
+				visited[childId] = true
+				ids = append(ids, childId)
+			}
+		}
+	}
+	return ids
+}
+
+// ancestors mengembalikan jalur dari kategori utama sampai kategori itu sendiri
+func (t *categoryTree) ancestors(categoryId int64) []models.Category {
+	var path []models.Category
+	visited := make(map[int64]bool)
+	for id := categoryId; !visited[id]; {
+		category, ok := t.categories[id]
+		if !ok {
+			break
+		}
+		visited[id] = true
+		path = append([]models.Category{category}, path...)
+		if category.ParentId == nil {
+			break
+		}
+		id = *category.ParentId
+	}
+	return path
+}
+
+// categoryFilterIDs mengembalikan kategori yang dipilih beserta turunannya untuk filter produk
+func categoryFilterIDs(categoryID string) ([]int64, error) {
+	categoryId, err := strconv.ParseInt(categoryID, 10, 64)
+	if err != nil {
+		return nil, helpers.NewError(helpers.MsgInvalidCategoryIDValue, categoryID)
+	}
+	tree, err := loadCategoryTree()
+	if err != nil {
+		return nil, err
+	}
+	return tree.descendants(categoryId), nil
+}
+
+// categoryBreadcrumb menyusun jalur kategori untuk respons produk sesuai bahasa permintaan
+func categoryBreadcrumb(c *fiber.Ctx, categoryId int64) []models.CategoryBreadcrumb {
+	tree, err := loadCategoryTree()
+	if err != nil {
+		return nil
+	}
+	path := tree.ancestors(categoryId)
+	translateCategories(c, path)
+	var breadcrumb []models.CategoryBreadcrumb
+	for _, category := range path {
+		breadcrumb = append(breadcrumb, models.CategoryBreadcrumb{
+			CategoryId: category.CategoryId,
+			Category:   category.Category,
+			Slug:       category.Slug,
+		})
+	}
+	return breadcrumb
+}
+
+// validateCategoryParent memastikan induk ada dan bukan kategori itu sendiri atau turunannya.
+// categoryId 0 dipakai saat membuat kategori baru.
+func validateCategoryParent(tree *categoryTree, categoryId int64, parentId int64) error {
+	if _, ok := tree.categories[parentId]; !ok {
+		return ErrCategoryParentNotFound
+	}
+	if categoryId == 0 {
+		return nil
+	}
+	for _, id := range tree.descendants(categoryId) {
+		if id == parentId {
+			return ErrCategoryCycle
+		}
+	}
+	return nil
+}
+
+// categoryParentErrorResponse mengubah kesalahan validateCategoryParent menjadi respons validasi
+func categoryParentErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, ErrCategoryParentNotFound) || errors.Is(err, ErrCategoryCycle) {
+		response := helpers.NewResponseError(c, map[string][]string{"parent_id": {helpers.ErrorMessage(c, err)}})
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
+	return c.Status(fiber.StatusInternalServerError).JSON(response)
+}
+
+func GetCategoryTree(c *fiber.Ctx) error {
+	tree, err := loadCategoryTree()
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Nama kategori mengikuti bahasa permintaan
+	categories := make([]models.Category, 0, len(tree.categories))
+	for _, category := range tree.categories {
+		categories = append(categories, category)
+	}
+	translateCategories(c, categories)
+	for _, category := range categories {
+		tree.categories[category.CategoryId] = category
+	}
+
+	// Jumlah produk langsung per kategori, pengunjung hanya menghitung produk yang sudah terbit
+	var counts []struct {
+		CategoryId int64
+		Count      int64
+	}
+	if err := initialize.DB.Model(&models.Product{}).Scopes(visibleScope(c, "products")).Select("category_id, COUNT(*) AS count").Group("category_id").Scan(&counts).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	countByCategory := make(map[int64]int64)
+	for _, row := range counts {
+		countByCategory[row.CategoryId] = row.Count
+	}
+
+	// Bangun simpul dari bawah agar total produk turunan ikut terhitung
+	visited := make(map[int64]bool)
+	var build func(categoryId int64) *models.CategoryNode
+	build = func(categoryId int64) *models.CategoryNode {
+		visited[categoryId] = true
+		category := tree.categories[categoryId]
+		node := &models.CategoryNode{
+			CategoryId:        category.CategoryId,
+			Category:          category.Category,
+			Slug:              category.Slug,
+			ParentId:          category.ParentId,
+			Position:          category.Position,
+			ProductCount:      countByCategory[categoryId],
+			TotalProductCount: countByCategory[categoryId],
+			Children:          []*models.CategoryNode{},
+		}
+		for _, childId := range tree.children[categoryId] {
+			if visited[childId] {
+				continue
+			}
+			child := build(childId)
+			node.TotalProductCount += child.TotalProductCount
+			node.Children = append(node.Children, child)
+		}
+		return node
+	}
+	roots := []*models.CategoryNode{}
+	for _, categoryId := range tree.children[0] {
+		roots = append(roots, build(categoryId))
+	}
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   roots,
+	}
+	return c.JSON(response)
+}
+
+func MoveCategory(c *fiber.Ctx) error {
+	categoryId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidCategoryID)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	var reqBody models.CategoryMoveRequest
+	if err := c.BodyParser(&reqBody); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	tree, err := loadCategoryTree()
+	if err != nil {
+		return categoryParentErrorResponse(c, err)
+	}
+	if _, ok := tree.categories[categoryId]; !ok {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgDataNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	var parentKey int64
+	if reqBody.ParentId != nil && *reqBody.ParentId != 0 {
+		if err := validateCategoryParent(tree, categoryId, *reqBody.ParentId); err != nil {
+			return categoryParentErrorResponse(c, err)
+		}
+		parentKey = *reqBody.ParentId
+	} else {
+		reqBody.ParentId = nil
+	}
+
+	// Susun ulang urutan saudara, tanpa posisi kategori diletakkan paling akhir
+	var siblings []int64
+	for _, id := range tree.children[parentKey] {
+		if id != categoryId {
+			siblings = append(siblings, id)
+		}
+	}
+	position := len(siblings)
+	if reqBody.Position != nil && *reqBody.Position >= 0 && *reqBody.Position < position {
+		position = *reqBody.Position
+	}
+	siblings = append(siblings[:position], append([]int64{categoryId}, siblings[position:]...)...)
+
+	err = initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Category{}).Where("category_id = ?", categoryId).Update("parent_id", reqBody.ParentId).Error; err != nil {
+			return err
+		}
+		for i, id := range siblings {
+			if err := tx.Model(&models.Category{}).Where("category_id = ?", id).Update("position", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgCategoryMoved)
+	return c.JSON(response)
+}
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	minCompareProducts = 2
+	maxCompareProducts = 4
+)
+
+// compareIDs membaca ?ids=1,2,3 dengan urutan tetap dan tanpa duplikat
+func compareIDs(raw string) ([]int64, error) {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, helpers.NewError(helpers.MsgInvalidProductIDValue, part)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < minCompareProducts || len(ids) > maxCompareProducts {
+		return nil, helpers.NewError(helpers.MsgCompareIDsRequired, minCompareProducts, maxCompareProducts)
+	}
+	return ids, nil
+}
+
+// compareValue mengubah nilai atribut ke bentuk yang dapat dibandingkan dengan satuan dasar
+func compareValue(value models.ProductAttributeValue) (interface{}, string) {
+	attr := value.Attribute
+	switch attr.Type {
+	case models.AttributeTypeNumber:
+		if value.NumberValue == nil {
+			return nil, ""
+		}
+		return helpers.NormalizeUnit(*value.NumberValue, attr.Unit)
+	case models.AttributeTypeRange:
+		if value.NumberValue == nil || value.MaxValue == nil {
+			return nil, ""
+		}
+		low, unit := helpers.NormalizeUnit(*value.NumberValue, attr.Unit)
+		high, _ := helpers.NormalizeUnit(*value.MaxValue, attr.Unit)
+		return map[string]float64{"min": low, "max": high}, unit
+	case models.AttributeTypeEnum:
+		return value.TextValue, ""
+	case models.AttributeTypeBoolean:
+		if value.BoolValue == nil {
+			return nil, ""
+		}
+		return *value.BoolValue, ""
+	}
+	return nil, ""
+}
+
+// compareAttributes menyejajarkan atribut semua produk berdasarkan nama dan menandai baris yang nilainya berbeda
+func compareAttributes(products []models.Product) []models.CompareAttribute {
+	var rows []models.CompareAttribute
+	index := make(map[string]int)
+	for _, product := range products {
+		values := product.Attributes
+		sort.SliceStable(values, func(i, j int) bool {
+			return values[i].Attribute.Position < values[j].Attribute.Position
+		})
+		for _, value := range values {
+			name := value.Attribute.Name
+			if _, ok := index[name]; ok {
+				continue
+			}
+			index[name] = len(rows)
+			row := models.CompareAttribute{
+				Name:   name,
+				Label:  value.Attribute.Label,
+				Type:   value.Attribute.Type,
+				Values: make([]models.CompareValue, len(products)),
+			}
+			for i, p := range products {
+				row.Values[i].ProductId = p.ProductId
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	for i, product := range products {
+		for _, value := range product.Attributes {
+			cell := &rows[index[value.Attribute.Name]].Values[i]
+			cell.Value, cell.Unit = compareValue(value)
+		}
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		units := make(map[string]bool)
+		keys := make(map[string]bool)
+		for _, cell := range row.Values {
+			if cell.Value != nil {
+				units[cell.Unit] = true
+			}
+			// Produk yang tidak memiliki atribut ikut dihitung sebagai perbedaan
+			keys[fmt.Sprintf("%v|%s", cell.Value, cell.Unit)] = true
+		}
+		// Satuan baris hanya diisi jika semua nilai memakai satuan dasar yang sama
+		if len(units) == 1 {
+			for unit := range units {
+				row.Unit = unit
+			}
+		}
+		row.Different = len(keys) > 1
+	}
+	return rows
+}
+
+// CompareProducts menampilkan beberapa produk berdampingan dengan spesifikasi yang disejajarkan per atribut
+func CompareProducts(c *fiber.Ctx) error {
+	ids, err := compareIDs(c.Query("ids"))
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	var found []models.Product
+	if err := initialize.DB.Preload("Category").Preload("File").Preload("Attributes.Attribute").
+		Scopes(visibleScope(c, "products")).Where("product_id IN ?", ids).Find(&found).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Urutan produk mengikuti urutan ids pada permintaan
+	byID := make(map[int64]models.Product, len(found))
+	for _, product := range found {
+		byID[product.ProductId] = product
+	}
+	products := make([]models.Product, 0, len(ids))
+	for _, id := range ids {
+		product, ok := byID[id]
+		if !ok {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgProductIDNotFound, id)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		products = append(products, product)
+	}
+	translateProducts(c, products)
+
+	compare := models.CompareResponse{
+		Products:   make([]models.CompareProduct, 0, len(products)),
+		Attributes: compareAttributes(products),
+	}
+	for _, product := range products {
+		compare.Products = append(compare.Products, models.CompareProduct{
+			ProductId:  product.ProductId,
+			Title:      product.Title,
+			Slug:       product.Slug,
+			CategoryId: product.CategoryId,
+			Category:   product.Category.Category,
+			PathFile:   product.File.Path,
+		})
+	}
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   compare,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
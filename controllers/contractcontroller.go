@@ -8,19 +8,15 @@ import (
 	"math"
 	"strconv"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 func GetAllContracts(c *fiber.Ctx) error {
 	// Ambil semua data kontrak dari database dengan preloading untuk memuat relasi User dan Products
 	var contracts []models.Contract
 	if err := initialize.DB.Preload("User").Preload("Products").Find(&contracts).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to fetch contracts",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgContractFetchFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -64,11 +60,7 @@ func GetCountContract(c *fiber.Ctx) error {
 	var count int64
 	if err := initialize.DB.Model(&models.Contract{}).Count(&count).Error; err != nil {
 		// Jika terjadi kesalahan saat menghitung kontrak, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -85,37 +77,18 @@ func CreateContract(c *fiber.Ctx) error {
 	// Parse body request ke dalam struct ContractCreateRequest
 	var requestBody models.ContractRequest
 	if err := c.BodyParser(&requestBody); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid request body",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Validasi request body
 	if err := validate.Struct(&requestBody); err != nil {
-		errors := make(map[string][]string)
-		for _, err := range err.(validator.ValidationErrors) {
-			field := err.Field()
-			tag := err.Tag()
-			message := fmt.Sprintf("%s is %s", field, tag)
-			errors[field] = append(errors[field], message)
-		}
-		response := helpers.ResponseError{
-			Code:   fiber.StatusBadRequest,
-			Status: "Bad Request",
-			Error:  errors,
-		}
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	if len(requestBody.ProductIDs) == 0 {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Product id setidaknya ada 1",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgProductIDsRequired)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
@@ -131,11 +104,7 @@ func CreateContract(c *fiber.Ctx) error {
 	if requestBody.UserID != 0 {
 		var user models.User
 		if err := initialize.DB.First(&user, requestBody.UserID).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusBadRequest,
-				Status:  "Bad Request",
-				Message: fmt.Sprintf("User with ID %d not found", requestBody.UserID),
-			}
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgUserIDNotFound, requestBody.UserID)
 			return c.Status(fiber.StatusBadRequest).JSON(response)
 		}
 		contract.UserID = requestBody.UserID
@@ -145,11 +114,7 @@ func CreateContract(c *fiber.Ctx) error {
 	for _, productID := range requestBody.ProductIDs {
 		var product models.Product
 		if err := initialize.DB.First(&product, productID).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusBadRequest,
-				Status:  "Bad Request",
-				Message: fmt.Sprintf("Product with ID %d not found", productID),
-			}
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgProductIDNotFound, productID)
 			return c.Status(fiber.StatusBadRequest).JSON(response)
 		}
 		contract.Products = append(contract.Products, product)
@@ -157,20 +122,12 @@ func CreateContract(c *fiber.Ctx) error {
 
 	// Menyimpan kontrak ke dalam database
 	if err := initialize.DB.Create(&contract).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Mengirimkan respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "Contract created successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgContractCreated)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
@@ -178,22 +135,14 @@ func GetContractByID(c *fiber.Ctx) error {
 	// Ambil ID kontrak dari parameter URL
 	contractID, err := strconv.ParseInt(c.Query("id"), 10, 64)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid contract ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidContractID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Cari kontrak berdasarkan ID di database
 	var contract models.Contract
 	if err := initialize.DB.Preload("Products").First(&contract, contractID).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusNotFound,
-			Status:  "Not Found",
-			Message: "Contract not found",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgContractNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
@@ -216,6 +165,28 @@ func GetContractByID(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
+// contractExportKeys adalah kolom ekspor datatable kontrak
+var contractExportKeys = []string{"contract_id", "title", "description", "start_date", "end_date", "user_id", "username", "product"}
+
+func contractExportRows(locale string, query *gorm.DB) ([][]interface{}, error) {
+	var contracts []models.Contract
+	if err := query.Find(&contracts).Error; err != nil {
+		return nil, err
+	}
+	rows := make([][]interface{}, 0, len(contracts))
+	for _, contract := range contracts {
+		products := make([]string, 0, len(contract.Products))
+		for _, product := range contract.Products {
+			products = append(products, product.Title)
+		}
+		rows = append(rows, []interface{}{
+			contract.ContractId, contract.Title, contract.Description, contract.StartDate, contract.EndDate,
+			contract.UserID, contract.User.FullName, products,
+		})
+	}
+	return rows, nil
+}
+
 func GetContractsDataTable(c *fiber.Ctx) error {
 	// Ambil nilai parameter limit, sort, sort_by, dan search dari query string
 	limit, _ := strconv.Atoi(c.Query("limit"))
@@ -249,6 +220,11 @@ func GetContractsDataTable(c *fiber.Ctx) error {
 		query = query.Order(fmt.Sprintf("%s %s", sortBy, sort))
 	}
 
+	// Ekspor memakai filter dan urutan yang sama tanpa paginasi
+	if format := c.Query("format"); format != "" {
+		return exportTable(c, format, "contracts", contractExportKeys, query.Order("contract_id"), contractExportRows)
+	}
+
 	var totalRecords int64
 	if err := initialize.DB.Model(&models.Contract{}).Count(&totalRecords).Error; err != nil {
 		response := helpers.GeneralResponse{
@@ -264,11 +240,7 @@ func GetContractsDataTable(c *fiber.Ctx) error {
 	// Lakukan pengambilan data
 	if err := query.Find(&contracts).Error; err != nil {
 		// Jika terjadi kesalahan saat mengambil data kontrak, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -333,50 +305,27 @@ func UpdateContract(c *fiber.Ctx) error {
 	// Ambil id kontrak dari parameter URL
 	contractID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid contract ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidContractID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Parse body request ke dalam struct ContractRequest
 	var requestBody models.ContractRequest
 	if err := c.BodyParser(&requestBody); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid request body",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Validasi request body
 	if err := validate.Struct(&requestBody); err != nil {
-		errors := make(map[string][]string)
-		for _, err := range err.(validator.ValidationErrors) {
-			field := err.Field()
-			tag := err.Tag()
-			message := fmt.Sprintf("%s is %s", field, tag)
-			errors[field] = append(errors[field], message)
-		}
-		response := helpers.ResponseError{
-			Code:   fiber.StatusBadRequest,
-			Status: "Bad Request",
-			Error:  errors,
-		}
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Memeriksa apakah kontrak dengan ID yang diberikan ada di database
 	var existingContract models.Contract
 	if err := initialize.DB.Preload("Products").First(&existingContract, contractID).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusNotFound,
-			Status:  "Not Found",
-			Message: "Contract not found",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgContractNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
@@ -390,11 +339,7 @@ func UpdateContract(c *fiber.Ctx) error {
 	if requestBody.UserID != 0 {
 		var user models.User
 		if err := initialize.DB.First(&user, requestBody.UserID).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusBadRequest,
-				Status:  "Bad Request",
-				Message: fmt.Sprintf("User with ID %d not found", requestBody.UserID),
-			}
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgUserIDNotFound, requestBody.UserID)
 			return c.Status(fiber.StatusBadRequest).JSON(response)
 		}
 		existingContract.UserID = requestBody.UserID
@@ -402,11 +347,7 @@ func UpdateContract(c *fiber.Ctx) error {
 
 	// Menghapus semua relasi produk yang terkait dengan kontrak
 	if err := initialize.DB.Model(&existingContract).Association("Products").Clear(); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to update contract",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgContractUpdateFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -414,11 +355,7 @@ func UpdateContract(c *fiber.Ctx) error {
 	for _, productID := range requestBody.ProductIDs {
 		var product models.Product
 		if err := initialize.DB.First(&product, productID).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusBadRequest,
-				Status:  "Bad Request",
-				Message: fmt.Sprintf("Product with ID %d not found", productID),
-			}
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgProductIDNotFound, productID)
 			return c.Status(fiber.StatusBadRequest).JSON(response)
 		}
 		existingContract.Products = append(existingContract.Products, product)
@@ -426,20 +363,12 @@ func UpdateContract(c *fiber.Ctx) error {
 
 	// Menyimpan perubahan kontrak ke dalam database
 	if err := initialize.DB.Save(&existingContract).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to update contract",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgContractUpdateFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Mengirimkan respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "Contract updated successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgContractUpdated)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
@@ -447,39 +376,19 @@ func DeleteContract(c *fiber.Ctx) error {
 	// Ambil parameter ID kontrak dari URL
 	contractID := c.Params("id")
 
-	// Mulai transaksi
-	tx := initialize.DB.Begin()
-
-	// Hapus hubungan antara kontrak dan produk di tabel ContractProduct
-	if err := tx.Where("contract_contract_id = ?", contractID).Delete(&models.ContractProduct{}).Error; err != nil {
-		tx.Rollback()
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to delete contract's products",
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	var contract models.Contract
+	if err := initialize.DB.Where("contract_id = ?", contractID).First(&contract).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgContractNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
-	// Hapus kontrak
-	if err := tx.Where("contract_id = ?", contractID).Delete(&models.Contract{}).Error; err != nil {
-		tx.Rollback()
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to delete contract",
-		}
+	// Kontrak dipindahkan ke trash, hubungan dengan produk tetap disimpan agar bisa dipulihkan
+	if err := initialize.DB.Delete(&contract).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgContractDeleteFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
-	// Commit transaksi
-	tx.Commit()
-
 	// Mengirimkan respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "Contract deleted successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgContractTrashed)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
@@ -13,11 +13,7 @@ func GetDashboard(c *fiber.Ctx) error {
 	var productCount int64
 	if err := initialize.DB.Model(&models.Product{}).Count(&productCount).Error; err != nil {
 		// Jika terjadi kesalahan saat menghitung produk, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -25,11 +21,7 @@ func GetDashboard(c *fiber.Ctx) error {
 	var userCount int64
 	if err := initialize.DB.Model(&models.User{}).Count(&userCount).Error; err != nil {
 		// Jika terjadi kesalahan saat menghitung pengguna, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -37,11 +29,7 @@ func GetDashboard(c *fiber.Ctx) error {
 	var contractCount int64
 	if err := initialize.DB.Model(&models.Contract{}).Count(&contractCount).Error; err != nil {
 		// Jika terjadi kesalahan saat menghitung kontrak, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -49,11 +37,7 @@ func GetDashboard(c *fiber.Ctx) error {
 	var historyCount int64
 	if err := initialize.DB.Model(&models.History{}).Count(&historyCount).Error; err != nil {
 		// Jika terjadi kesalahan saat menghitung riwayat, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -61,11 +45,7 @@ func GetDashboard(c *fiber.Ctx) error {
 	var categoryCount int64
 	if err := initialize.DB.Model(&models.Category{}).Count(&categoryCount).Error; err != nil {
 		// Jika terjadi kesalahan saat menghitung kategori, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
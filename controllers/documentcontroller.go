@@ -0,0 +1,349 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// documentMaxSize adalah ukuran maksimal satu dokumen produk
+const documentMaxSize = 25 << 20
+
+// documentMimeTypes adalah jenis file yang boleh diunggah sebagai dokumen produk
+var documentMimeTypes = map[string]bool{
+	"application/pdf":    true,
+	"application/zip":    true,
+	"application/msword": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+	"application/vnd.ms-excel": true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": true,
+	"image/jpeg":    true,
+	"image/png":     true,
+	"image/vnd.dwg": true,
+	"image/vnd.dxf": true,
+	"text/plain":    true,
+}
+
+// documentExtension membatasi ekstensi file yang disimpan agar nama di disk tetap aman
+var documentExtension = regexp.MustCompile(`^\.[a-z0-9]{1,10}$`)
+
+// documentDir adalah folder dokumen produk di luar ./public, dapat diganti lewat DOCUMENT_DIR
+func documentDir() string {
+	if dir := os.Getenv("DOCUMENT_DIR"); dir != "" {
+		return dir
+	}
+	return "./storage/documents"
+}
+
+// removeDocumentFile menghapus file dokumen dari disk, file yang sudah tidak ada diabaikan
+func removeDocumentFile(storageName string) error {
+	if storageName == "" {
+		return nil
+	}
+	if err := os.Remove(filepath.Join(documentDir(), storageName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// storeDocumentFile menyimpan unggahan ke folder dokumen lalu mengisi metadata file pada dokumen.
+// Jenis file ditentukan dari isinya, bukan dari nama atau Content-Type yang dikirim klien.
+func storeDocumentFile(c *fiber.Ctx, file *multipart.FileHeader, document *models.ProductDocument) error {
+	if file.Size > documentMaxSize {
+		return helpers.NewError(helpers.MsgDocumentTooLarge, documentMaxSize>>20)
+	}
+	dir := documentDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	name := uuid.New().String()
+	tmpPath := filepath.Join(dir, name+".upload")
+	if err := c.SaveFile(file, tmpPath); err != nil {
+		return err
+	}
+	f, err := os.Open(tmpPath)
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	info, err := helpers.ProbeMedia(f, file.Filename)
+	f.Close()
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if !documentMimeTypes[info.MimeType] {
+		os.Remove(tmpPath)
+		return helpers.NewError(helpers.MsgDocumentTypeNotAllowed, info.MimeType)
+	}
+
+	extension := info.Format
+	if !documentExtension.MatchString(extension) {
+		extension = ""
+	}
+	if err := os.Rename(tmpPath, filepath.Join(dir, name+extension)); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	document.StorageName = name + extension
+	document.OriginalName = file.Filename
+	document.MimeType = info.MimeType
+	document.Size = info.Size
+	document.Checksum = info.Checksum
+	return nil
+}
+
+// canSeePrivateDocuments mengizinkan admin dan pelanggan yang memiliki kontrak aktif berisi produk tersebut
+func canSeePrivateDocuments(c *fiber.Ctx, productId int64) (bool, error) {
+	if isAdminRequest(c) {
+		return true, nil
+	}
+	userID, ok := c.Locals("userID").(float64)
+	if !ok {
+		return false, nil
+	}
+	var count int64
+	err := initialize.DB.Table("ContractProduct").
+		Joins("JOIN contracts ON contracts.contract_id = ContractProduct.contract_contract_id AND contracts.deleted_at IS NULL").
+		Where("contracts.user_id = ? AND ContractProduct.product_product_id = ?", int64(userID), productId).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// documentProduct mencari produk dari parameter :id dengan aturan visibilitas yang sama seperti halaman produk
+func documentProduct(c *fiber.Ctx) (models.Product, error) {
+	var product models.Product
+	productId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return product, gorm.ErrRecordNotFound
+	}
+	err = initialize.DB.Select("product_id").Scopes(visibleScope(c, "products")).
+		Where("product_id = ?", productId).First(&product).Error
+	return product, err
+}
+
+// productNotFound mengubah kesalahan pencarian produk menjadi respons 404 atau 500
+func productNotFound(c *fiber.Ctx, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgProductNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+	response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductFetchFailed)
+	return c.Status(fiber.StatusInternalServerError).JSON(response)
+}
+
+// documentNotFound mengubah kesalahan pencarian dokumen menjadi respons 404 atau 500
+func documentNotFound(c *fiber.Ctx, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgDocumentNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+	response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgDocumentFetchFailed)
+	return c.Status(fiber.StatusInternalServerError).JSON(response)
+}
+
+// visibleDocuments membatasi dokumen produk sesuai hak akses; dokumen privat yang tidak boleh dilihat dianggap tidak ada
+func visibleDocuments(c *fiber.Ctx, productId int64) (*gorm.DB, error) {
+	private, err := canSeePrivateDocuments(c, productId)
+	if err != nil {
+		return nil, err
+	}
+	query := initialize.DB.Where("product_id = ?", productId)
+	if !private {
+		query = query.Where("visibility = ?", models.DocumentVisibilityPublic)
+	}
+	return query, nil
+}
+
+func documentDownloadURL(document models.ProductDocument) string {
+	return fmt.Sprintf("/api/product/%d/documents/%d/download", document.ProductId, document.ProductDocumentId)
+}
+
+// GetProductDocuments menampilkan dokumen produk yang boleh dilihat, dapat difilter dengan ?type= dan ?locale=.
+// Filter locale juga menyertakan dokumen yang berlaku untuk semua bahasa.
+func GetProductDocuments(c *fiber.Ctx) error {
+	product, err := documentProduct(c)
+	if err != nil {
+		return productNotFound(c, err)
+	}
+	query, err := visibleDocuments(c, product.ProductId)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgDocumentFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if documentType := c.Query("type"); documentType != "" {
+		query = query.Where("type = ?", documentType)
+	}
+	if locale := c.Query("locale"); locale != "" {
+		query = query.Where("locale IN ?", []string{locale, ""})
+	}
+
+	var documents []models.ProductDocument
+	if err := query.Order("type ASC, locale ASC, title ASC").Find(&documents).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgDocumentFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	for i := range documents {
+		documents[i].DownloadURL = documentDownloadURL(documents[i])
+	}
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   documents,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// DownloadProductDocument mengirim file dokumen sebagai lampiran setelah visibilitasnya diperiksa
+func DownloadProductDocument(c *fiber.Ctx) error {
+	product, err := documentProduct(c)
+	if err != nil {
+		return productNotFound(c, err)
+	}
+	query, err := visibleDocuments(c, product.ProductId)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgDocumentFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	var document models.ProductDocument
+	if err := query.Where("product_document_id = ?", c.Params("documentId")).First(&document).Error; err != nil {
+		return documentNotFound(c, err)
+	}
+
+	path := filepath.Join(documentDir(), document.StorageName)
+	if _, err := os.Stat(path); err != nil {
+		log.Printf("product document %d: %v", document.ProductDocumentId, err)
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgDocumentNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	filename := unsafeFilenameChars.ReplaceAllString(document.OriginalName, "-")
+	if filename == "" {
+		filename = filepath.Base(document.StorageName)
+	}
+	if err := c.Download(path, filename); err != nil {
+		return err
+	}
+	// Jenis file diambil dari hasil deteksi saat unggah dan browser tidak boleh menebak ulang
+	c.Set(fiber.HeaderContentType, document.MimeType)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	if document.Visibility == models.DocumentVisibilityPrivate {
+		c.Set(fiber.HeaderCacheControl, "private, no-store")
+	}
+	return nil
+}
+
+// saveProductDocument menyimpan metadata dokumen dan file baru jika ada. File lama baru dihapus setelah data tersimpan.
+func saveProductDocument(c *fiber.Ctx, document models.ProductDocument) error {
+	var request models.ProductDocumentRequest
+	if err := c.BodyParser(&request); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := validate.Struct(&request); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	status := fiber.StatusOK
+	if document.ProductDocumentId == 0 {
+		status = fiber.StatusCreated
+	}
+	file, err := c.FormFile("file")
+	if err != nil && status == fiber.StatusCreated {
+		response := helpers.NewResponseError(c, map[string][]string{"file": {helpers.Message(c, helpers.MsgDocumentFileRequired)}})
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	previousFile := ""
+	if file != nil {
+		previousFile = document.StorageName
+		if err := storeDocumentFile(c, file, &document); err != nil {
+			var appError *helpers.AppError
+			if errors.As(err, &appError) {
+				response := helpers.NewResponseError(c, map[string][]string{"file": {helpers.ErrorMessage(c, err)}})
+				return c.Status(fiber.StatusBadRequest).JSON(response)
+			}
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileSaveFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		if userID, ok := c.Locals("userID").(float64); ok {
+			uploader := int64(userID)
+			document.UploadedBy = &uploader
+		}
+	}
+
+	document.Type = request.Type
+	document.Title = request.Title
+	document.Locale = request.Locale
+	document.Version = request.Version
+	document.Visibility = request.Visibility
+	if err := initialize.DB.Save(&document).Error; err != nil {
+		if file != nil {
+			removeDocumentFile(document.StorageName)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgDocumentSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if err := removeDocumentFile(previousFile); err != nil {
+		log.Printf("product document %d: %v", document.ProductDocumentId, err)
+	}
+
+	document.DownloadURL = documentDownloadURL(document)
+	response := helpers.GeneralResponse{
+		Code:   status,
+		Status: "OK",
+		Data:   document,
+	}
+	return c.Status(status).JSON(response)
+}
+
+func CreateProductDocument(c *fiber.Ctx) error {
+	product, err := documentProduct(c)
+	if err != nil {
+		return productNotFound(c, err)
+	}
+	return saveProductDocument(c, models.ProductDocument{ProductId: product.ProductId})
+}
+
+func UpdateProductDocument(c *fiber.Ctx) error {
+	var document models.ProductDocument
+	if err := initialize.DB.Where("product_id = ? AND product_document_id = ?", c.Params("id"), c.Params("documentId")).
+		First(&document).Error; err != nil {
+		return documentNotFound(c, err)
+	}
+	return saveProductDocument(c, document)
+}
+
+func DeleteProductDocument(c *fiber.Ctx) error {
+	var document models.ProductDocument
+	if err := initialize.DB.Where("product_id = ? AND product_document_id = ?", c.Params("id"), c.Params("documentId")).
+		First(&document).Error; err != nil {
+		return documentNotFound(c, err)
+	}
+	if err := initialize.DB.Delete(&document).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgDocumentDeleteFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if err := removeDocumentFile(document.StorageName); err != nil {
+		log.Printf("product document %d: %v", document.ProductDocumentId, err)
+	}
+
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgDocumentDeleted)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
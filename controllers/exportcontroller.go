@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/models"
+	"bufio"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// exportBatchSize adalah jumlah baris yang dimuat per query saat ekspor
+const exportBatchSize = 500
+
+// exportLoader memuat satu batch hasil query lalu mengubahnya menjadi baris sesuai urutan kolom ekspor
+type exportLoader func(locale string, query *gorm.DB) ([][]interface{}, error)
+
+// exportTable mengirim hasil datatable sebagai file CSV, XLSX atau JSON.
+// Query sudah berisi filter dan urutan datatable; paginasi diganti batch agar data besar dialirkan bertahap.
+func exportTable(c *fiber.Ctx, format string, table string, keys []string, query *gorm.DB, load exportLoader) error {
+	contentType, ok := helpers.ExportContentType(format)
+	if !ok {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgExportUnsupportedFormat, format)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	locale := helpers.Locale(c)
+	headers := helpers.ColumnHeaders(locale, table, keys)
+	query = query.Session(&gorm.Session{})
+
+	// Batch pertama dimuat sebelum respons dikirim sehingga kesalahan database masih bisa dijawab dengan 500
+	first, err := load(locale, query.Limit(exportBatchSize).Offset(0))
+	if err != nil {
+		log.Printf("export %s: %v", table, err)
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgExportFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", table, time.Now().Format("20060102-150405"), format)
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	c.Set(fiber.HeaderContentLanguage, locale)
+
+	// Fungsi di bawah berjalan setelah handler selesai, jadi tidak boleh memakai c
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		writer, err := helpers.NewTableWriter(format, w, table, keys, headers)
+		if err != nil {
+			log.Printf("export %s: %v", table, err)
+			return
+		}
+		rows := first
+		for offset := 0; ; {
+			for _, row := range rows {
+				if err := writer.WriteRow(row); err != nil {
+					log.Printf("export %s: %v", table, err)
+					return
+				}
+			}
+			// Gagal flush berarti klien sudah memutus koneksi
+			if err := w.Flush(); err != nil {
+				return
+			}
+			if len(rows) < exportBatchSize {
+				break
+			}
+			offset += exportBatchSize
+			if rows, err = load(locale, query.Limit(exportBatchSize).Offset(offset)); err != nil {
+				// File terpotong; status respons sudah terkirim sehingga kesalahan hanya dicatat
+				log.Printf("export %s: %v", table, err)
+				return
+			}
+		}
+		if err := writer.Close(); err != nil {
+			log.Printf("export %s: %v", table, err)
+			return
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// exportTagNames mengambil nama tag untuk kolom ekspor
+func exportTagNames(tags []models.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Tag)
+	}
+	return names
+}
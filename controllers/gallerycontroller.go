@@ -27,11 +27,7 @@ func GetGalleryById(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusOK).JSON(response)
 		}
 		// Jika terjadi kesalahan lain saat mengambil galeri, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to fetch gallery",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgGalleryFetchFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
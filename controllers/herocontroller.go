@@ -4,25 +4,17 @@ import (
 	"Matahariled/helpers"
 	"Matahariled/initialize"
 	"Matahariled/models"
-	"fmt"
-	"path/filepath"
-	"strconv"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 )
 
 func GetHero(c *fiber.Ctx) error {
 	var heroes []models.Hero
 
-	// Mengambil data hero dengan limit 5
-	if err := initialize.DB.Select("path", "product_id").Limit(5).Find(&heroes).Error; err != nil {
+	// Mengambil data hero dengan limit 5, hero dari produk di trash tidak ditampilkan
+	if err := initialize.DB.Select("path", "product_id").Where("product_id IN (?)", initialize.DB.Model(&models.Product{}).Scopes(publishedScope("products")).Select("product_id")).Limit(5).Find(&heroes).Error; err != nil {
 		// Jika terjadi kesalahan saat mengambil data, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to retrieve heroes",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgHeroFetchFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -51,54 +43,40 @@ func CreateHero(c *fiber.Ctx) error {
 		ProductId int64 `form:"product_id"`
 	}
 	if err := c.BodyParser(&requestBody); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Failed to parse request body"})
+		return c.Status(fiber.StatusBadRequest).JSON(helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody))
 	}
 
 	// Validasi apakah ID produk valid
 	var product models.Product
 	if err := initialize.DB.First(&product, requestBody.ProductId).Error; err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Product not found"})
+		return c.Status(fiber.StatusNotFound).JSON(helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgProductNotFound))
 	}
 
 	// Periksa apakah file diunggah
 	file, err := c.FormFile("file")
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "File is required",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgFileRequired)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	// Simpan file yang diunggah ke folder public
-	filename := uuid.New().String() + filepath.Ext(file.Filename)
-	filePath := fmt.Sprintf("/public/%s", filename)
-	if err := c.SaveFile(file, fmt.Sprintf("./public/%s", filename)); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save file",
-		}
+	// Simpan file yang diunggah ke folder public beserta metadatanya
+	heroFile, err := saveUpload(c, file)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Buat entitas Hero untuk disimpan dalam database
 	hero := models.Hero{
-		Path:      filePath,
-		Hero_name: filename,
-		Size:      strconv.FormatInt(file.Size, 10),
-		Format:    filepath.Ext(file.Filename),
+		Path:      heroFile.Path,
+		Hero_name: heroFile.File_name,
+		FileId:    heroFile.FileId,
 		ProductId: requestBody.ProductId,
 	}
 
 	// Simpan data hero ke dalam database
 	if err := initialize.DB.Create(&hero).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save hero data",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgHeroSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -8,26 +8,22 @@ import (
 	"fmt"
 	"math"
 	"os"
-	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 func GetAllHistories(c *fiber.Ctx) error {
 	// Ambil semua data history dari database
 	var histories []models.History
-	if err := initialize.DB.Preload("Product").Preload("Product.Category").Preload("File").Find(&histories).Error; err != nil {
+	if err := initialize.DB.Preload("Product").Preload("Product.Category").Preload("File").Scopes(visibleScope(c, "histories")).Find(&histories).Error; err != nil {
 		// Jika terjadi kesalahan saat mengambil history, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to fetch histories",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgHistoryFetchFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	translateHistories(c, histories)
 
 	// Membuat slice untuk menyimpan respons history
 	historyResponses := make([]models.HistoryResponse, len(histories))
@@ -37,12 +33,16 @@ func GetAllHistories(c *fiber.Ctx) error {
 		historyResponses[i] = models.HistoryResponse{
 			HistoryId:    history.HistoryId,
 			Title:        history.Title,
+			Slug:         history.Slug,
 			Description:  history.Description,
 			StartDate:    history.StartDate,
 			EndDate:      history.EndDate,
 			ProductName:  history.Product.Title,
 			CategoryName: history.Product.Category.Category,
 			PathFile:     history.File.Path,
+			Status:       history.Status,
+			PublishAt:    history.PublishAt,
+			PublishedAt:  history.PublishedAt,
 			CreatedAt:    history.CreatedAt,
 			UpdatedAt:    history.UpdatedAt,
 		}
@@ -58,41 +58,49 @@ func GetAllHistories(c *fiber.Ctx) error {
 }
 func GetHistoryById(c *fiber.Ctx) error {
 	// Ambil ID history dari parameter URL
-	historyId := c.Params("id")
+	return sendHistory(c, c.Params("id"))
+}
 
+// sendHistory mengirim detail history, dipakai oleh pencarian berdasarkan ID maupun slug
+func sendHistory(c *fiber.Ctx, historyId interface{}) error {
 	// Buat variabel untuk menyimpan data history
 	var history models.History
 
 	// Cari history berdasarkan ID
-	if err := initialize.DB.Preload("Product").Preload("Product.Category").Preload("File").Where("history_id = ?", historyId).First(&history).Error; err != nil {
+	if err := initialize.DB.Preload("Product").Preload("Product.Category").Preload("File").Preload("Videos").Preload("Tags").Scopes(visibleScope(c, "histories")).Where("history_id = ?", historyId).First(&history).Error; err != nil {
 		// Jika history tidak ditemukan, kirim respons not found
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusNotFound,
-				Status:  "Not Found",
-				Message: "History not found",
-			}
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgHistoryNotFound)
 			return c.Status(fiber.StatusNotFound).JSON(response)
 		}
 		// Jika terjadi kesalahan lain saat mengambil history, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to fetch history",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgHistoryFetchFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
+	// Teks mengikuti bahasa permintaan
+	histories := []models.History{history}
+	if !editingRequest(c) {
+		translateHistories(c, histories)
+	}
+	history = histories[0]
+
 	// Membuat respons untuk history yang ditemukan
 	historyResponse := models.HistoryResponse{
 		HistoryId:    history.HistoryId,
 		Title:        history.Title,
+		Slug:         history.Slug,
 		Description:  history.Description,
 		StartDate:    history.StartDate,
 		EndDate:      history.EndDate,
 		ProductName:  history.Product.Title,
 		CategoryName: history.Product.Category.Category,
 		PathFile:     history.File.Path,
+		Videos:       history.Videos,
+		Tags:         history.Tags,
+		Status:       history.Status,
+		PublishAt:    history.PublishAt,
+		PublishedAt:  history.PublishedAt,
 		CreatedAt:    history.CreatedAt,
 		UpdatedAt:    history.UpdatedAt,
 	}
@@ -109,13 +117,9 @@ func GetHistoryById(c *fiber.Ctx) error {
 func GetCountHistory(c *fiber.Ctx) error {
 	// Hitung jumlah total riwayat dari database
 	var count int64
-	if err := initialize.DB.Model(&models.History{}).Count(&count).Error; err != nil {
+	if err := initialize.DB.Model(&models.History{}).Scopes(visibleScope(c, "histories")).Count(&count).Error; err != nil {
 		// Jika terjadi kesalahan saat menghitung riwayat, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -143,7 +147,7 @@ func GetDatatableHistoriess(c *fiber.Ctx) error {
 
 	// Lakukan pengambilan data dari database dengan menggunakan parameter limit, sort, dan sort_by
 	var histories []models.History
-	query := initialize.DB.Preload("Product").Preload("Product.Category").Preload("User").Preload("File").Preload("Video").Model(&models.History{})
+	query := initialize.DB.Preload("Product").Preload("Product.Category").Preload("User").Preload("File").Preload("Videos").Preload("Tags").Model(&models.History{}).Scopes(visibleScope(c, "histories"))
 
 	// Jika parameter search tidak kosong, tambahkan filter pencarian
 	if search != "" {
@@ -173,6 +177,9 @@ func GetDatatableHistoriess(c *fiber.Ctx) error {
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	if !editingRequest(c) {
+		translateHistories(c, histories)
+	}
 
 	// Siapkan respons DataTable
 	response := helpers.DataTableResponse{
@@ -194,18 +201,24 @@ func GetDatatableHistoriess(c *fiber.Ctx) error {
 		historyMap := map[string]interface{}{
 			"history_id":    history.HistoryId,
 			"title":         history.Title,
+			"slug":          history.Slug,
 			"description":   history.Description,
 			"product_id":    history.ProductId,
 			"product_name":  history.Product.Title,
 			"start_date":    history.StartDate,
 			"end_date":      history.EndDate,
-			"embed":         history.Video.Embed,
-			"video_title":   history.Video.Title,
+			"embed":         firstVideo(history.Videos).Embed,
+			"video_title":   firstVideo(history.Videos).Title,
+			"videos":        history.Videos,
+			"tags":          history.Tags,
 			"user_id":       history.UserId,
 			"user":          history.User.FullName,
 			"category_name": history.Product.Category.Category,
 			"file_id":       history.FileId,
 			"path_file":     history.File.Path,
+			"status":        history.Status,
+			"publish_at":    history.PublishAt,
+			"published_at":  history.PublishedAt,
 			"created_at":    history.CreatedAt,
 			"updated_at":    history.UpdatedAt,
 		}
@@ -242,7 +255,7 @@ func GetDatatableHistories(c *fiber.Ctx) error {
 
 	// Lakukan pengambilan data dari database dengan menggunakan parameter limit, offset, sort, dan sort_by
 	var histories []models.History
-	query := initialize.DB.Preload("Product").Preload("Product.Category").Preload("User").Preload("File").Preload("Video").Model(&models.History{})
+	query := initialize.DB.Preload("Product").Preload("Product.Category").Preload("User").Preload("File").Preload("Videos").Preload("Tags").Model(&models.History{}).Scopes(visibleScope(c, "histories"))
 
 	// Jika parameter search tidak kosong, tambahkan filter pencarian
 	if search != "" {
@@ -258,6 +271,11 @@ func GetDatatableHistories(c *fiber.Ctx) error {
 		query = query.Where("product_id = ?", productID)
 	}
 
+	// Ekspor memakai filter dan urutan yang sama tanpa paginasi
+	if format := c.Query("format"); format != "" {
+		return exportTable(c, format, "histories", historyExportKeys, query.Order("histories.history_id"), historyExportRows)
+	}
+
 	var totalRecords int64
 	if err := initialize.DB.Model(&models.Product{}).Count(&totalRecords).Error; err != nil {
 		response := helpers.GeneralResponse{
@@ -279,6 +297,9 @@ func GetDatatableHistories(c *fiber.Ctx) error {
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	if !editingRequest(c) {
+		translateHistories(c, histories)
+	}
 
 	// Hitung total jumlah record tanpa paginasi
 
@@ -303,18 +324,24 @@ func GetDatatableHistories(c *fiber.Ctx) error {
 		historyMap := map[string]interface{}{
 			"history_id":    history.HistoryId,
 			"title":         history.Title,
+			"slug":          history.Slug,
 			"description":   history.Description,
 			"product_id":    history.ProductId,
 			"product_name":  history.Product.Title,
 			"start_date":    history.StartDate,
 			"end_date":      history.EndDate,
-			"embed":         history.Video.Embed,
-			"video_title":   history.Video.Title,
+			"embed":         firstVideo(history.Videos).Embed,
+			"video_title":   firstVideo(history.Videos).Title,
+			"videos":        history.Videos,
+			"tags":          history.Tags,
 			"user_id":       history.UserId,
 			"user":          history.User.FullName,
 			"category_name": history.Product.Category.Category,
 			"file_id":       history.FileId,
 			"path_file":     history.File.Path,
+			"status":        history.Status,
+			"publish_at":    history.PublishAt,
+			"published_at":  history.PublishedAt,
 			"created_at":    history.CreatedAt,
 			"updated_at":    history.UpdatedAt,
 		}
@@ -331,6 +358,35 @@ func GetDatatableHistories(c *fiber.Ctx) error {
 	})
 }
 
+// historyExportKeys adalah kolom ekspor datatable riwayat
+var historyExportKeys = []string{"history_id", "title", "slug", "product_name", "category_name", "user", "start_date", "end_date",
+	"description", "tags", "videos", "path_file", "status", "publish_at", "published_at", "created_at", "updated_at"}
+
+func historyExportRows(locale string, query *gorm.DB) ([][]interface{}, error) {
+	var histories []models.History
+	if err := query.Find(&histories).Error; err != nil {
+		return nil, err
+	}
+	translateHistoriesIn(locale, histories)
+	rows := make([][]interface{}, 0, len(histories))
+	for _, history := range histories {
+		videos := make([]string, 0, len(history.Videos))
+		for _, video := range history.Videos {
+			if video.Url != "" {
+				videos = append(videos, video.Url)
+			} else {
+				videos = append(videos, video.Title)
+			}
+		}
+		rows = append(rows, []interface{}{
+			history.HistoryId, history.Title, history.Slug, history.Product.Title, history.Product.Category.Category,
+			history.User.FullName, history.StartDate, history.EndDate, history.Description, exportTagNames(history.Tags),
+			videos, history.File.Path, history.Status, history.PublishAt, history.PublishedAt, history.CreatedAt, history.UpdatedAt,
+		})
+	}
+	return rows, nil
+}
+
 func CreateHistory(c *fiber.Ctx) error {
 	// Ambil data produk dari form
 	title := c.FormValue("title")
@@ -343,123 +399,110 @@ func CreateHistory(c *fiber.Ctx) error {
 	embed := c.FormValue("embed")
 	productId, err := strconv.ParseInt(productIdStr, 10, 64)
 	if err != nil || productId <= 0 {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid Product Id",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidProductID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 	userId, err := strconv.ParseInt(userIdStr, 10, 64)
-	if err != nil || productId <= 0 {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid Product Id",
-		}
+	if err != nil || userId <= 0 {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidUserID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	var product models.Product
 	if err := initialize.DB.First(&product, productId).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Product Tidak Tersedia",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgProductNotFound)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	video := models.Video{
-		Title: video_title,
-		Embed: embed,
+	// Video diambil dari pustaka (video_ids[]), input embed lama tetap didukung dan ditambahkan ke pustaka
+	videos, _, err := videosFromForm(c)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
-	if err := initialize.DB.Create(&video).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to create video",
-		})
+	// Tag langsung pada riwayat pemasangan
+	tags, _, err := tagsFromForm(c)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	// Riwayat baru berstatus draft kecuali status dikirim
+	status, publishAt, _, err := publishFromForm(c, models.StatusDraft, nil)
+	if err != nil {
+		return publishErrorResponse(c, err)
 	}
-
 	if title == "" || description == "" {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Judul atau Deskripsi diperlukan",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgTitleDescriptionRequired)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
+	// Slug dibuat dari judul jika tidak diisi
+	slug, err := resolveSlug(models.SlugEntityHistory, c.FormValue("slug"), title, 0)
+	if err != nil {
+		return slugErrorResponse(c, err)
+	}
+
 	file, err := c.FormFile("file")
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "File is required",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgFileRequired)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	// Generate nama unik untuk file yang diunggah
-	filename := uuid.New().String() + filepath.Ext(file.Filename)
-
-	// Simpan file ke direktori publik
-	if err := c.SaveFile(file, fmt.Sprintf("./public/%s", filename)); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save file",
+	// Video dari embed disiapkan setelah validasi dan disimpan bersama riwayat
+	var embedVideo *models.Video
+	if strings.TrimSpace(embed) != "" {
+		video, err := findOrPrepareVideo(video_title, embed)
+		if err != nil {
+			response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(response)
+		embedVideo = &video
 	}
 
-	// Buat entitas File untuk disimpan dalam database
-	fileModel := models.File{
-		Path:      fmt.Sprintf("/public/%s", filename),
-		File_name: filename,
-		Size:      strconv.FormatInt(file.Size, 10),
-		Format:    filepath.Ext(file.Filename),
-	}
-
-	// Simpan file ke dalam database
-	if err := initialize.DB.Create(&fileModel).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save file data",
-		}
+	// Simpan file ke direktori publik beserta metadatanya
+	fileModel, err := saveUpload(c, file)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Buat entitas Product
 	history := models.History{
 		Title:       title,
+		Slug:        slug,
 		StartDate:   start_date,
 		EndDate:     end_date,
 		Description: description,
 		ProductId:   productId,
 		UserId:      userId,
 		FileId:      fileModel.FileId,
-		VideoId:     video.VideoId,
-	}
-
-	// Simpan produk ke dalam database
-	if err := initialize.DB.Create(&history).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save product",
+		Videos:      videos,
+		Tags:        tags,
+		Status:      status,
+		PublishAt:   publishAt,
+		PublishedAt: publishedAtFor(status, publishAt, nil),
+	}
+
+	// Simpan riwayat beserta video dari embed dalam satu transaksi
+	err = initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if embedVideo != nil {
+			if err := saveVideo(tx, embedVideo); err != nil {
+				return err
+			}
+			history.Videos = append(history.Videos, *embedVideo)
 		}
+		return tx.Create(&history).Error
+	})
+	if err != nil {
+		deleteFileIfUnused(fileModel.FileId)
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	// Callback pencarian di dalam transaksi berjalan sebelum commit sehingga riwayat diantrekan ulang
+	queueSearchChange(searchTypeHistory, history.HistoryId)
 
 	// Kirim respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "History saved successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgHistorySaved)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
@@ -467,22 +510,14 @@ func UpdateHistory(c *fiber.Ctx) error {
 	// Ambil history ID dari parameter URL
 	historyID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil || historyID <= 0 {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid or missing History ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidHistoryID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Periksa apakah riwayat dengan historyID tersebut ada di database
 	var history models.History
 	if err := initialize.DB.First(&history, historyID).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "History Not Found",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgHistoryNotFound)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
@@ -497,60 +532,75 @@ func UpdateHistory(c *fiber.Ctx) error {
 	userIdStr := c.FormValue("user_id")
 	productId, err := strconv.ParseInt(productIdStr, 10, 64)
 	if err != nil || productId <= 0 {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid or missing Product Id",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidProductID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	userId, err := strconv.ParseInt(userIdStr, 10, 64)
 	if err != nil || userId <= 0 {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid or missing Product Id",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidUserID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Validasi apakah title atau description kosong
 	if title == "" || description == "" {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Title and description are required",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgTitleDescriptionRequired)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Periksa apakah product dengan productId tersebut ada di database
 	var product models.Product
 	if err := initialize.DB.First(&product, productId).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Product with given ID not found",
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgProductNotFound)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	// Slug hanya berubah jika diisi, judul baru tidak mengubah tautan yang sudah ada
+	oldSlug := history.Slug
+	slug := history.Slug
+	if requested := c.FormValue("slug"); requested != "" || slug == "" {
+		slug, err = resolveSlug(models.SlugEntityHistory, requested, title, historyID)
+		if err != nil {
+			return slugErrorResponse(c, err)
 		}
+	}
+
+	// Video diambil dari pustaka (video_ids[]), input embed lama tetap didukung dan ditambahkan ke pustaka
+	videos, videosPresent, err := videosFromForm(c)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
+	// Tag hanya diganti jika tag_ids[] dikirim
+	tags, tagsPresent, err := tagsFromForm(c)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	// Status dan jadwal hanya berubah jika status atau publish_at dikirim
+	status, publishAt, publishPresent, err := publishFromForm(c, history.Status, history.PublishAt)
+	if err != nil {
+		return publishErrorResponse(c, err)
+	}
+	// Video dari embed disiapkan di sini dan baru disimpan bersama riwayat
+	var embedVideo *models.Video
+	if strings.TrimSpace(embed) != "" {
+		video, err := findOrPrepareVideo(video_title, embed)
+		if err != nil {
+			response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		embedVideo = &video
+	}
 
 	// Cek apakah ada file baru yang diunggah
 	file, err := c.FormFile("file")
 	if err != nil {
 	} else {
 		// Jika ada file baru, simpan file baru dan hapus file lama
-		// Generate nama unik untuk file yang diunggah
-		filename := uuid.New().String() + filepath.Ext(file.Filename)
-		// eapatkan data file id  dari baris tesb
-		// Simpan file ke direktori publik
-		if err := c.SaveFile(file, fmt.Sprintf("./public/%s", filename)); err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to save file",
-			}
+		newFile, err := saveUpload(c, file)
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileSaveFailed)
 			return c.Status(fiber.StatusInternalServerError).JSON(response)
 		}
 
@@ -559,54 +609,24 @@ func UpdateHistory(c *fiber.Ctx) error {
 			// Memuat data file terkait
 			if err := initialize.DB.Model(&history).Association("File").Find(&history.File); err != nil {
 				// Handle error jika gagal memuat data file
-				response := helpers.ResponseMassage{
-					Code:    fiber.StatusInternalServerError,
-					Status:  "Internal Server Error",
-					Message: "Gagal Memuat Data File",
-				}
+				response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileLoadFailed)
 				return c.Status(fiber.StatusInternalServerError).JSON(response)
 			}
 
 			// Hapus file lama dari sistem file lokal
 			oldFile := history.File
 			if err := os.Remove("./" + oldFile.Path); err != nil {
-				response := helpers.ResponseMassage{
-					Code:    fiber.StatusInternalServerError,
-					Status:  "Internal Server Error",
-					Message: "Gagal Menghapus Data Local",
-				}
+				response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileDeleteFailed)
 				return c.Status(fiber.StatusInternalServerError).JSON(response)
 			}
 
 			// Hapus entitas file lama dari basis data
 			if err := initialize.DB.Delete(&oldFile).Error; err != nil {
-				response := helpers.ResponseMassage{
-					Code:    fiber.StatusInternalServerError,
-					Status:  "Internal Server Error",
-					Message: "Gagal Menghapus Data File",
-				}
+				response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileDeleteFailed)
 				return c.Status(fiber.StatusInternalServerError).JSON(response)
 			}
 		}
 
-		// Buat entitas File baru untuk disimpan dalam database
-		newFile := models.File{
-			Path:      fmt.Sprintf("/public/%s", filename),
-			File_name: filename,
-			Size:      strconv.FormatInt(file.Size, 10),
-			Format:    filepath.Ext(file.Filename),
-		}
-
-		// Simpan file baru ke dalam database
-		if err := initialize.DB.Create(&newFile).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to save new file data",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-
 		// Ganti file lama dengan file baru
 		history.File = newFile
 	}
@@ -615,176 +635,93 @@ func UpdateHistory(c *fiber.Ctx) error {
 	history.StartDate = start_date
 	history.EndDate = end_date
 	history.Title = title
+	history.Slug = slug
 	history.Description = description
 	history.ProductId = productId
 	history.UserId = userId
+	if publishPresent {
+		history.PublishedAt = nextPublishedAt(history.Status, history.PublishedAt, status, publishAt, publishAtChanged(history.PublishAt, publishAt))
+		history.Status = status
+		history.PublishAt = publishAt
+	}
 
-	oldVideoId := history.VideoId
-
-	if history.VideoId != 0 {
-		newVideo := models.Video{
-			Title: video_title,
-			Embed: embed,
+	// Simpan riwayat, slug lama, video dari embed, daftar video dan tag dalam satu transaksi
+	failure := helpers.MsgHistoryUpdateFailed
+	err = initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&history).Error; err != nil {
+			return err
 		}
-		if err := initialize.DB.Create(&newVideo).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to save new video",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
+
+		failure = helpers.MsgSlugRedirectSaveFailed
+		if err := recordSlugChange(tx, models.SlugEntityHistory, history.HistoryId, oldSlug, slug); err != nil {
+			return err
 		}
-		history.VideoId = newVideo.VideoId
-		if err := initialize.DB.Save(&history).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to update history with new video ID",
+
+		// Daftar video hanya diganti jika video_ids[] atau embed dikirim
+		failure = helpers.MsgHistoryVideosUpdateFailed
+		if embedVideo != nil {
+			if err := saveVideo(tx, embedVideo); err != nil {
+				return err
 			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
+			videos = append(videos, *embedVideo)
 		}
-		if err := initialize.DB.Where("video_id = ?", oldVideoId).Delete(&models.Video{}).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to delete old video",
+		if videosPresent || embedVideo != nil {
+			if err := tx.Model(&history).Association("Videos").Replace(videos); err != nil {
+				return err
 			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
 		}
-	} else {
-		newVideo := models.Video{
-			Title: video_title,
-			Embed: embed,
-		}
-		if err := initialize.DB.Create(&newVideo).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to create new video",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-		// Tautkan video baru ke riwayat
-		history.Video = newVideo
-	}
 
-	// Simpan perubahan ke dalam database
-	if err := initialize.DB.Save(&history).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to update history",
+		failure = helpers.MsgHistoryTagsUpdateFailed
+		if tagsPresent {
+			return tx.Model(&history).Association("Tags").Replace(tags)
 		}
+		return nil
+	})
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, failure)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	// Callback pencarian di dalam transaksi berjalan sebelum commit sehingga riwayat diantrekan ulang
+	queueSearchChange(searchTypeHistory, history.HistoryId)
 
 	// Kirim respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "History updated successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgHistoryUpdated)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 func DeleteHistory(c *fiber.Ctx) error {
 	// Ambil history ID dari parameter URL
 	historyID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil || historyID <= 0 {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid or missing History ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidHistoryID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Periksa apakah riwayat dengan historyID tersebut ada di database
 	var history models.History
 	if err := initialize.DB.First(&history, historyID).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "History Not Found",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgHistoryNotFound)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	// Hapus riwayat dari basis data
+	// Riwayat dipindahkan ke trash; video, tag dan file baru dilepas saat purge
 	if err := initialize.DB.Delete(&history).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to delete history",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgHistoryDeleteFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
-	// Hapus video terkait jika ada
-	if history.VideoId != 0 {
-		if err := initialize.DB.Delete(&models.Video{}, history.VideoId).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to delete associated video",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-	}
-
-	// Hapus file terkait dari sistem file lokal jika ada
-	if history.FileId != 0 {
-		// Memuat data file terkait
-		if err := initialize.DB.Model(&history).Association("File").Find(&history.File); err != nil {
-			// Handle error jika gagal memuat data file
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to load file data",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-
-		// Hapus file dari sistem file lokal
-		if err := os.Remove("." + history.File.Path); err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to delete local file",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-
-		// Hapus entitas file dari basis data
-		if err := initialize.DB.Delete(&history.File).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to delete file data",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-	}
-
 	// Kirim respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "History deleted successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgHistoryTrashed)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 func GetAllUserPortfolios(c *fiber.Ctx) error {
 	// Ambil semua data history dari database
 	var histories []models.History
-	if err := initialize.DB.Preload("User").Preload("User.File").Find(&histories).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to fetch portfolios",
-		}
+	if err := initialize.DB.Preload("User").Preload("User.File").Scopes(visibleScope(c, "histories")).Find(&histories).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgHistoryFetchFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	translateHistories(c, histories)
 
 	// Siapkan slice untuk menyimpan data portofolio dan informasi pengguna terkait
 	var userPortfolios []struct {
@@ -813,7 +750,7 @@ func GetAllUserPortfolios(c *fiber.Ctx) error {
 				UserFilePath string `json:"photo"`
 			}{
 				FullName:     history.User.FullName,
-				UserFilePath: history.User.File.Path,
+				UserFilePath: history.User.AvatarPath(),
 			},
 		})
 	}
@@ -839,15 +776,12 @@ func GetHistoryByIdProduct(c *fiber.Ctx) error {
 
 	// Ambil semua data history terkait dengan ID produk dari database
 	var histories []models.History
-	if err := initialize.DB.Preload("Product").Preload("Product.Category").Preload("File").Where("product_id = ?", productId).Find(&histories).Error; err != nil {
+	if err := initialize.DB.Preload("Product").Preload("Product.Category").Preload("File").Scopes(visibleScope(c, "histories")).Where("product_id = ?", productId).Find(&histories).Error; err != nil {
 		// Jika terjadi kesalahan saat mengambil history, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to fetch histories",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgHistoryFetchFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	translateHistories(c, histories)
 
 	// Membuat slice untuk menyimpan respons history
 	historyResponses := make([]models.HistoryResponse, len(histories))
@@ -857,12 +791,16 @@ func GetHistoryByIdProduct(c *fiber.Ctx) error {
 		historyResponses[i] = models.HistoryResponse{
 			HistoryId:    history.HistoryId,
 			Title:        history.Title,
+			Slug:         history.Slug,
 			Description:  history.Description,
 			StartDate:    history.StartDate,
 			EndDate:      history.EndDate,
 			ProductName:  history.Product.Title,
 			CategoryName: history.Product.Category.Category,
 			PathFile:     history.File.Path,
+			Status:       history.Status,
+			PublishAt:    history.PublishAt,
+			PublishedAt:  history.PublishedAt,
 			CreatedAt:    history.CreatedAt,
 			UpdatedAt:    history.UpdatedAt,
 		}
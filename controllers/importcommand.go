@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/models"
+	"archive/zip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// RunImportCommand menjalankan import produk dari command line, misalnya:
+//
+//	go run . import -images gambar.zip -dry-run produk.xlsx
+//
+// Laporan per baris ditulis ke stdout dalam format JSON. Nilai kembali adalah exit code.
+func RunImportCommand(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	imagesPath := flags.String("images", "", "file ZIP berisi gambar yang dirujuk kolom image dan gallery")
+	dryRun := flags.Bool("dry-run", false, "hanya validasi, tidak ada data yang disimpan")
+	locale := flags.String("lang", models.DefaultLocale, "bahasa pesan kesalahan (id atau en)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: import [-images gambar.zip] [-dry-run] [-lang id|en] produk.csv|produk.xlsx")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	if !helpers.SupportedLocale(*locale) {
+		*locale = models.DefaultLocale
+	}
+
+	path := flags.Arg(0)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	var images *zip.Reader
+	if *imagesPath != "" {
+		archive, err := zip.OpenReader(*imagesPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, helpers.MessageIn(*locale, helpers.MsgImportImagesNotZip))
+			return 1
+		}
+		defer archive.Close()
+		images = &archive.Reader
+	}
+
+	report, err := newProductImport(*locale, nil, images).run(filepath.Base(path), data, *dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, helpers.ErrorMessageIn(*locale, err))
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(report)
+	if report.Invalid > 0 {
+		fmt.Fprintln(os.Stderr, helpers.MessageIn(*locale, helpers.MsgImportHasErrors, report.Invalid))
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,658 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// importAttributePrefix menandai kolom atribut spesifikasi, misalnya "attr.pixel_pitch"
+	importAttributePrefix = "attr."
+	// importGallerySeparator memisahkan beberapa gambar galeri dalam satu sel
+	importGallerySeparator = "|"
+	importImageMaxSize     = 20 << 20
+	importImageMaxRedirect = 5
+)
+
+var errImportAddressBlocked = errors.New("import image address is not public")
+
+// importBlockedNetworks adalah rentang khusus yang tidak tercakup IsPrivate/IsLoopback/IsLinkLocal*
+var importBlockedNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+}
+
+// importImageClient hanya menghubungi alamat publik agar URL di file import tidak bisa menjangkau jaringan internal.
+// Alamat diperiksa setelah DNS di-resolve, termasuk untuk setiap redirect.
+var importImageClient = &http.Client{
+	Timeout: 30 * time.Second,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: 10 * time.Second, Control: dialPublicOnly}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= importImageMaxRedirect || !isImportURL(req.URL.String()) {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	},
+}
+
+func mustParseCIDR(value string) *net.IPNet {
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// isPublicIP menolak loopback, jaringan privat, link-local (termasuk metadata cloud 169.254.169.254) dan multicast
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range importBlockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// dialPublicOnly dipanggil dengan alamat IP yang sudah di-resolve sehingga DNS rebinding tidak bisa melewatinya
+func dialPublicOnly(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return errImportAddressBlocked
+	}
+	return nil
+}
+
+// importRangePattern mengenali rentang seperti "800-1200" atau "800..1200" untuk atribut range
+var importRangePattern = regexp.MustCompile(`^(-?[0-9.]+)\s*(?:-|\.\.)\s*(-?[0-9.]+)$`)
+
+// productImport adalah satu proses import beserta sumber gambarnya
+type productImport struct {
+	locale string
+	author *int64
+	images map[string]*zip.File // nama file huruf kecil tanpa folder -> isi ZIP
+}
+
+// productImportRow adalah satu baris file yang sudah dibaca dan divalidasi
+type productImportRow struct {
+	result     models.ImportRowResult
+	product    models.Product
+	attributes []models.ProductAttributeValue
+	image      string
+	gallery    []string
+	// Nama file sementara hasil unduhan, diisi sebelum transaksi dibuka
+	imageUpload    string
+	galleryUploads []string
+}
+
+func (row *productImportRow) addError(column string, message string) {
+	if row.result.Errors == nil {
+		row.result.Errors = make(map[string][]string)
+	}
+	row.result.Errors[column] = append(row.result.Errors[column], message)
+}
+
+func newProductImport(locale string, author *int64, images *zip.Reader) productImport {
+	source := productImport{locale: locale, author: author, images: make(map[string]*zip.File)}
+	if images != nil {
+		for _, file := range images.File {
+			if !file.FileInfo().IsDir() {
+				source.images[strings.ToLower(path.Base(file.Name))] = file
+			}
+		}
+	}
+	return source
+}
+
+func isImportURL(ref string) bool {
+	lower := strings.ToLower(ref)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// checkImage memastikan referensi gambar berupa URL http(s) atau nama file yang ada di ZIP
+func (p productImport) checkImage(ref string) error {
+	if isImportURL(ref) {
+		if parsed, err := url.ParseRequestURI(ref); err != nil || parsed.Host == "" {
+			return helpers.NewError(helpers.MsgImportImageURLInvalid, ref)
+		}
+		return nil
+	}
+	if len(p.images) == 0 {
+		return helpers.NewError(helpers.MsgImportImageURLInvalid, ref)
+	}
+	if _, ok := p.images[strings.ToLower(path.Base(ref))]; !ok {
+		return helpers.NewError(helpers.MsgImportImageNotInArchive, ref)
+	}
+	return nil
+}
+
+// fetchImage mengunduh gambar dari URL atau mengambilnya dari ZIP ke file sementara
+func (p productImport) fetchImage(ref string, dst string) error {
+	var reader io.ReadCloser
+	if isImportURL(ref) {
+		resp, err := importImageClient.Get(ref)
+		if err != nil {
+			return helpers.NewError(helpers.MsgImportImageDownloadFailed, ref)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return helpers.NewError(helpers.MsgImportImageDownloadFailed, ref)
+		}
+		reader = resp.Body
+	} else {
+		file, ok := p.images[strings.ToLower(path.Base(ref))]
+		if !ok {
+			return helpers.NewError(helpers.MsgImportImageNotInArchive, ref)
+		}
+		opened, err := file.Open()
+		if err != nil {
+			return helpers.NewError(helpers.MsgImportImageInvalid, ref)
+		}
+		reader = opened
+	}
+	defer reader.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	written, err := io.Copy(out, io.LimitReader(reader, importImageMaxSize+1))
+	out.Close()
+	if err != nil {
+		return helpers.NewError(helpers.MsgImportImageDownloadFailed, ref)
+	}
+	if written > importImageMaxSize {
+		return helpers.NewError(helpers.MsgImportImageTooLarge, ref, importImageMaxSize>>20)
+	}
+	return nil
+}
+
+// downloadImage mengunduh gambar ke ./public/<tmpName>.upload tanpa membuka transaksi
+func (p productImport) downloadImage(ref string) (string, error) {
+	tmpName := uuid.New().String()
+	tmpPath := fmt.Sprintf("./public/%s.upload", tmpName)
+	if err := p.fetchImage(ref, tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+	return tmpName, nil
+}
+
+// storeImage mencatat gambar yang sudah diunduh lewat alur unggahan biasa di dalam transaksi import
+func (p productImport) storeImage(tx *gorm.DB, ref string, tmpName string) (models.File, error) {
+	file, err := storeUpload(tx, tmpName, path.Base(ref), false, p.author)
+	if err != nil {
+		return models.File{}, helpers.NewError(helpers.MsgImportImageInvalid, ref)
+	}
+	if !strings.HasPrefix(file.MimeType, "image/") {
+		os.Remove("." + file.Path)
+		return models.File{}, helpers.NewError(helpers.MsgImportImageInvalid, ref)
+	}
+	return file, nil
+}
+
+// importPublishAt membaca publish_at berupa teks atau nomor seri tanggal dari XLSX
+func importPublishAt(value string) (*time.Time, error) {
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 {
+		// Tanggal XLSX tersimpan sebagai jumlah hari sejak 30 Desember 1899
+		publishAt := time.Date(1899, 12, 30, 0, 0, 0, 0, time.Local).
+			Add(time.Duration(serial * float64(24*time.Hour))).Round(time.Second)
+		return &publishAt, nil
+	}
+	return parsePublishAt(value)
+}
+
+// importAttributeValue mengubah isi sel rentang menjadi pasangan min dan max
+func importAttributeValue(value string) interface{} {
+	if match := importRangePattern.FindStringSubmatch(value); match != nil {
+		return []interface{}{match[1], match[2]}
+	}
+	return value
+}
+
+// importSlug membuat slug unik dari judul, tidak bentrok dengan database maupun baris lain di file
+func importSlug(title string, taken map[string]bool) (string, error) {
+	base := helpers.Slugify(title)
+	if base == "" {
+		base = models.SlugEntityProduct
+	}
+	slug := base
+	for i := 2; ; i++ {
+		if !taken[slug] {
+			var count int64
+			if err := initialize.DB.Table("products").Where("slug = ?", slug).Count(&count).Error; err != nil {
+				return "", err
+			}
+			if count == 0 {
+				return slug, nil
+			}
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// parseRows membaca dan memvalidasi setiap baris data terhadap kategori, tag, atribut dan gambar
+func (p productImport) parseRows(rows [][]string) ([]productImportRow, error) {
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "title" {
+			name = "name"
+		}
+		if _, ok := columns[name]; !ok && name != "" {
+			columns[name] = i
+		}
+	}
+	for _, required := range []string{"name", "image"} {
+		if _, ok := columns[required]; !ok {
+			return nil, helpers.NewError(helpers.MsgImportColumnRequired, required)
+		}
+	}
+	_, hasCategoryId := columns["category_id"]
+	_, hasCategory := columns["category"]
+	if !hasCategoryId && !hasCategory {
+		return nil, helpers.NewError(helpers.MsgImportColumnRequired, "category_id")
+	}
+
+	// Kategori dapat diisi dengan ID, nama atau slug
+	var categories []models.Category
+	if err := initialize.DB.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	categoryByID := make(map[int64]models.Category, len(categories))
+	categoryByName := make(map[string]models.Category, len(categories)*2)
+	for _, category := range categories {
+		categoryByID[category.CategoryId] = category
+		categoryByName[strings.ToLower(category.Category)] = category
+		if category.Slug != "" {
+			categoryByName[strings.ToLower(category.Slug)] = category
+		}
+	}
+
+	var parsed []productImportRow
+	taken := make(map[string]bool)
+	for index, cells := range rows[1:] {
+		if strings.Join(cells, "") == "" {
+			continue
+		}
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(cells) {
+				return cells[i]
+			}
+			return ""
+		}
+
+		row := productImportRow{result: models.ImportRowResult{Row: index + 2, Title: cell("name")}}
+		product := models.Product{
+			Title:         cell("name"),
+			Description:   cell("description"),
+			Specification: cell("specification"),
+			Status:        models.StatusDraft,
+		}
+		if product.Title == "" {
+			row.addError("name", helpers.MessageIn(p.locale, helpers.MsgFieldRequired, "name"))
+		}
+
+		categoryFound := false
+		if value := cell("category_id"); value != "" {
+			categoryId, err := strconv.ParseInt(value, 10, 64)
+			category, ok := categoryByID[categoryId]
+			switch {
+			case err != nil:
+				row.addError("category_id", helpers.MessageIn(p.locale, helpers.MsgInvalidCategoryIDValue, value))
+			case !ok:
+				row.addError("category_id", helpers.MessageIn(p.locale, helpers.MsgImportCategoryNotFound, value))
+			default:
+				product.CategoryId = category.CategoryId
+				categoryFound = true
+			}
+		} else if value := cell("category"); value != "" {
+			if category, ok := categoryByName[strings.ToLower(value)]; ok {
+				product.CategoryId = category.CategoryId
+				categoryFound = true
+			} else {
+				row.addError("category", helpers.MessageIn(p.locale, helpers.MsgImportCategoryNotFound, value))
+			}
+		} else {
+			row.addError("category_id", helpers.MessageIn(p.locale, helpers.MsgFieldRequired, "category_id"))
+		}
+
+		if value := cell("status"); value != "" {
+			if err := validate.Var(value, "oneof=draft in_review published archived"); err != nil {
+				row.addError("status", helpers.MessageIn(p.locale, helpers.MsgInvalidStatus))
+			} else {
+				product.Status = value
+			}
+		}
+		if value := cell("publish_at"); value != "" {
+			publishAt, err := importPublishAt(value)
+			if err != nil {
+				row.addError("publish_at", helpers.ErrorMessageIn(p.locale, err))
+			}
+			product.PublishAt = publishAt
+		}
+		product.PublishedAt = publishedAtFor(product.Status, product.PublishAt, nil)
+
+		if value := cell("tag_ids"); value != "" {
+			tags, err := parseTagIDs([]string{value})
+			if err != nil {
+				row.addError("tag_ids", helpers.ErrorMessageIn(p.locale, err))
+			}
+			product.Tags = tags
+		}
+
+		// Nilai atribut hanya bisa diperiksa jika kategorinya valid
+		if categoryFound {
+			input := make(map[string]interface{})
+			for name, i := range columns {
+				if strings.HasPrefix(name, importAttributePrefix) && i < len(cells) && cells[i] != "" {
+					input[strings.TrimPrefix(name, importAttributePrefix)] = importAttributeValue(cells[i])
+				}
+			}
+			attributes, attributeErrors, err := validateAttributes(p.locale, product.CategoryId, input)
+			if err != nil {
+				return nil, err
+			}
+			for field, messages := range attributeErrors {
+				column := importAttributePrefix + strings.TrimPrefix(field, "attributes.")
+				for _, message := range messages {
+					row.addError(column, message)
+				}
+			}
+			row.attributes = attributes
+		}
+
+		row.image = cell("image")
+		if row.image == "" {
+			row.addError("image", helpers.MessageIn(p.locale, helpers.MsgFieldRequired, "image"))
+		} else if err := p.checkImage(row.image); err != nil {
+			row.addError("image", helpers.ErrorMessageIn(p.locale, err))
+		}
+		for _, ref := range strings.Split(cell("gallery"), importGallerySeparator) {
+			if ref = strings.TrimSpace(ref); ref == "" {
+				continue
+			}
+			if err := p.checkImage(ref); err != nil {
+				row.addError("gallery", helpers.ErrorMessageIn(p.locale, err))
+			}
+			row.gallery = append(row.gallery, ref)
+		}
+
+		// Slug yang ditulis di file diperiksa lebih dulu agar slug otomatis tidak memakainya
+		if value := cell("slug"); value != "" {
+			slug, err := resolveSlug(models.SlugEntityProduct, value, product.Title, 0)
+			switch {
+			case err != nil:
+				row.addError("slug", helpers.ErrorMessageIn(p.locale, err))
+			case taken[slug]:
+				row.addError("slug", helpers.MessageIn(p.locale, helpers.MsgImportSlugDuplicate, slug))
+			default:
+				taken[slug] = true
+				product.Slug = slug
+			}
+		}
+
+		row.product = product
+		parsed = append(parsed, row)
+	}
+
+	for i := range parsed {
+		row := &parsed[i]
+		if row.product.Slug == "" && row.result.Errors["slug"] == nil {
+			slug, err := importSlug(row.product.Title, taken)
+			if err != nil {
+				return nil, err
+			}
+			taken[slug] = true
+			row.product.Slug = slug
+		}
+		row.result.Slug = row.product.Slug
+	}
+	return parsed, nil
+}
+
+// commit menyimpan semua baris dalam satu transaksi; jika satu baris gagal tidak ada produk yang tersimpan.
+// Semua gambar diunduh lebih dulu agar unduhan yang lambat tidak menahan transaksi dan lock database.
+func (p productImport) commit(rows []productImportRow) error {
+	var downloaded []string
+	defer func() {
+		// File sementara yang sudah dipindahkan storeUpload tidak ada lagi sehingga aman dihapus ulang
+		for _, tmpName := range downloaded {
+			os.Remove(fmt.Sprintf("./public/%s.upload", tmpName))
+		}
+	}()
+	for i := range rows {
+		row := &rows[i]
+		tmpName, err := p.downloadImage(row.image)
+		if err != nil {
+			row.addError("image", helpers.ErrorMessageIn(p.locale, err))
+			return err
+		}
+		downloaded = append(downloaded, tmpName)
+		row.imageUpload = tmpName
+		for _, ref := range row.gallery {
+			tmpName, err := p.downloadImage(ref)
+			if err != nil {
+				row.addError("gallery", helpers.ErrorMessageIn(p.locale, err))
+				return err
+			}
+			downloaded = append(downloaded, tmpName)
+			row.galleryUploads = append(row.galleryUploads, tmpName)
+		}
+	}
+
+	var stored []string
+	err := initialize.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range rows {
+			row := &rows[i]
+			mainFile, err := p.storeImage(tx, row.image, row.imageUpload)
+			if err != nil {
+				row.addError("image", helpers.ErrorMessageIn(p.locale, err))
+				return err
+			}
+			stored = append(stored, mainFile.Path)
+
+			row.product.FileId = mainFile.FileId
+			if err := tx.Create(&row.product).Error; err != nil {
+				return err
+			}
+			if err := saveProductAttributes(tx, row.product.ProductId, row.attributes); err != nil {
+				return err
+			}
+			for j, ref := range row.gallery {
+				galleryFile, err := p.storeImage(tx, ref, row.galleryUploads[j])
+				if err != nil {
+					row.addError("gallery", helpers.ErrorMessageIn(p.locale, err))
+					return err
+				}
+				stored = append(stored, galleryFile.Path)
+				gallery := models.Gallery{
+					Path:         galleryFile.Path,
+					Gallery_name: path.Base(ref),
+					FileId:       galleryFile.FileId,
+					ProductId:    row.product.ProductId,
+				}
+				if err := tx.Create(&gallery).Error; err != nil {
+					return err
+				}
+			}
+			row.result.ProductId = row.product.ProductId
+		}
+		return nil
+	})
+	if err != nil {
+		// File yang sudah disalin ke direktori publik ikut dibatalkan
+		for _, filePath := range stored {
+			os.Remove("." + filePath)
+		}
+		for i := range rows {
+			rows[i].result.ProductId = 0
+		}
+		return err
+	}
+
+	for _, row := range rows {
+		// Callback pencarian berjalan sebelum commit sehingga produk diantrekan ulang setelah tersimpan
+		queueSearchChange(searchTypeProduct, row.product.ProductId)
+		if _, err := saveProductRevision(row.product.ProductId, "Imported", p.author); err != nil {
+			log.Printf("import: revision for product %d: %v", row.product.ProductId, err)
+		}
+	}
+	return nil
+}
+
+// run membaca file CSV/XLSX, memvalidasi semua baris lalu menyimpannya jika bukan dry run dan tidak ada baris yang salah.
+// Error hanya dikembalikan untuk masalah pada file secara keseluruhan; kesalahan per baris ada di laporan.
+func (p productImport) run(filename string, data []byte, dryRun bool) (models.ImportReport, error) {
+	report := models.ImportReport{DryRun: dryRun, Rows: []models.ImportRowResult{}}
+	rows, err := helpers.ReadSpreadsheet(filename, data)
+	if err != nil {
+		var appError *helpers.AppError
+		if errors.As(err, &appError) {
+			return report, err
+		}
+		return report, helpers.NewError(helpers.MsgImportReadFailed)
+	}
+	if len(rows) < 2 {
+		return report, helpers.NewError(helpers.MsgImportEmpty)
+	}
+
+	parsed, err := p.parseRows(rows)
+	if err != nil {
+		return report, err
+	}
+	if len(parsed) == 0 {
+		return report, helpers.NewError(helpers.MsgImportEmpty)
+	}
+
+	summarize := func() {
+		report.Total, report.Valid, report.Invalid = len(parsed), 0, 0
+		report.Rows = report.Rows[:0]
+		for _, row := range parsed {
+			if len(row.result.Errors) > 0 {
+				report.Invalid++
+			} else {
+				report.Valid++
+			}
+			report.Rows = append(report.Rows, row.result)
+		}
+	}
+	summarize()
+	if dryRun || report.Invalid > 0 {
+		return report, nil
+	}
+
+	if err := p.commit(parsed); err != nil {
+		var appError *helpers.AppError
+		if !errors.As(err, &appError) {
+			return report, err
+		}
+		// Gambar yang gagal diunduh atau bukan gambar dilaporkan pada barisnya
+		summarize()
+		return report, nil
+	}
+	report.Created = len(parsed)
+	summarize()
+	return report, nil
+}
+
+// ImportProducts mengimpor produk dari file CSV/XLSX. Gambar berupa URL atau nama file di ZIP "images".
+// Dengan dry_run=true semua baris hanya divalidasi tanpa disimpan.
+func ImportProducts(c *fiber.Ctx) error {
+	upload, err := c.FormFile("file")
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgFileRequired)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	file, err := upload.Open()
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgImportReadFailed)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	data, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgImportReadFailed)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	var images *zip.Reader
+	if imagesUpload, err := c.FormFile("images"); err == nil {
+		imagesFile, err := imagesUpload.Open()
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgImportImagesNotZip)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		defer imagesFile.Close()
+		images, err = zip.NewReader(imagesFile, imagesUpload.Size)
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgImportImagesNotZip)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+	}
+
+	var author *int64
+	if userID, ok := c.Locals("userID").(float64); ok {
+		id := int64(userID)
+		author = &id
+	}
+	dryRun, _ := strconv.ParseBool(c.FormValue("dry_run"))
+
+	report, err := newProductImport(helpers.Locale(c), author, images).run(upload.Filename, data, dryRun)
+	if err != nil {
+		var appError *helpers.AppError
+		if errors.As(err, &appError) {
+			response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		log.Printf("import: %v", err)
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgImportFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	if report.Invalid > 0 {
+		response := helpers.GeneralResponse{
+			Code:   fiber.StatusBadRequest,
+			Status: "Bad Request",
+			Data:   report,
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   report,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
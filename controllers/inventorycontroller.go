@@ -0,0 +1,542 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// stockAssignmentColumns adalah kolom alokasi unit untuk GetAssignedStock
+var stockAssignmentColumns = map[string]string{
+	"contract": "contract_id",
+	"history":  "history_id",
+}
+
+// stockErrorResponse mengubah kesalahan perpindahan unit menjadi respons; status yang tidak sesuai aturan menjadi 409
+func stockErrorResponse(c *fiber.Ctx, err error) error {
+	var appError *helpers.AppError
+	if errors.As(err, &appError) && appError.Code == helpers.MsgStockMoveInvalid {
+		response := helpers.NewErrorMassage(c, fiber.StatusConflict, err)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+	return inputErrorResponse(c, err, helpers.MsgStockSaveFailed)
+}
+
+// isDuplicateKey mengenali pelanggaran unique index MySQL (error 1062)
+func isDuplicateKey(err error) bool {
+	var mysqlError *mysql.MySQLError
+	return errors.As(err, &mysqlError) && mysqlError.Number == 1062
+}
+
+// ensureStockReferences memastikan gudang, kontrak dan riwayat yang dirujuk ada
+func ensureStockReferences(db *gorm.DB, warehouseId *int64, contractId *int64, historyId *int64) error {
+	if warehouseId != nil {
+		if err := db.Select("warehouse_id").First(&models.Warehouse{}, *warehouseId).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return helpers.NewError(helpers.MsgWarehouseIDNotFound, *warehouseId)
+			}
+			return err
+		}
+	}
+	if contractId != nil {
+		if err := db.Select("contract_id").First(&models.Contract{}, *contractId).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return helpers.NewError(helpers.MsgContractIDNotFound, *contractId)
+			}
+			return err
+		}
+	}
+	if historyId != nil {
+		if err := db.Select("history_id").First(&models.History{}, *historyId).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return helpers.NewError(helpers.MsgHistoryIDNotFound, *historyId)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// applyStockMovement mengubah unit sesuai alasan perpindahan dan mengembalikan catatan perpindahannya.
+// Alokasi dilepas saat unit kembali ke gudang atau dibuang, tetapi tetap tercatat di perpindahan.
+func applyStockMovement(item *models.StockItem, request models.StockMovementRequest) (models.StockMovement, error) {
+	rule := models.StockMovementRules[request.Reason]
+	allowed := len(rule.From) == 0
+	for _, status := range rule.From {
+		if status == item.Status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return models.StockMovement{}, helpers.NewError(helpers.MsgStockMoveInvalid, item.Status, request.Reason)
+	}
+
+	movement := models.StockMovement{
+		StockItemId:     item.StockItemId,
+		Reason:          request.Reason,
+		FromStatus:      item.Status,
+		FromWarehouseId: item.WarehouseId,
+		FromLocation:    item.Location,
+		Note:            request.Note,
+	}
+	previousContract, previousHistory := item.ContractId, item.HistoryId
+	status := rule.To
+	if status == "" {
+		status = item.Status
+	}
+
+	switch request.Reason {
+	case models.StockReasonTransfer:
+		if request.WarehouseId == nil && request.Location == nil {
+			return movement, helpers.NewError(helpers.MsgStockLocationRequired)
+		}
+	case models.StockReasonReserve:
+		if request.ContractId == nil {
+			return movement, helpers.NewError(helpers.MsgStockContractRequired, request.Reason)
+		}
+		item.ContractId = request.ContractId
+	case models.StockReasonRelease:
+		item.ContractId = nil
+	case models.StockReasonInstall:
+		if request.ContractId == nil && request.HistoryId == nil && item.ContractId == nil {
+			return movement, helpers.NewError(helpers.MsgStockAssignmentRequired)
+		}
+		if request.ContractId != nil {
+			item.ContractId = request.ContractId
+		}
+		if request.HistoryId != nil {
+			item.HistoryId = request.HistoryId
+		}
+		// Unit terpasang sudah keluar dari gudang; location boleh diisi lokasi pemasangan
+		item.WarehouseId = nil
+		item.Location = ""
+	case models.StockReasonUninstall:
+		if request.WarehouseId == nil {
+			return movement, helpers.NewError(helpers.MsgStockWarehouseRequired, request.Reason)
+		}
+		item.ContractId, item.HistoryId = nil, nil
+	case models.StockReasonScrap:
+		item.ContractId, item.HistoryId = nil, nil
+	case models.StockReasonAdjustment:
+		if request.Status == "" || strings.TrimSpace(request.Note) == "" {
+			return movement, helpers.NewError(helpers.MsgStockAdjustmentInvalid)
+		}
+		status = request.Status
+		if request.ContractId != nil {
+			item.ContractId = request.ContractId
+		}
+		if request.HistoryId != nil {
+			item.HistoryId = request.HistoryId
+		}
+		// Efek samping sama dengan alasan biasa yang menghasilkan status tersebut
+		switch status {
+		case models.StockStatusInStock, models.StockStatusReserved:
+			if request.WarehouseId == nil && item.WarehouseId == nil {
+				return movement, helpers.NewError(helpers.MsgStockWarehouseRequired, request.Reason)
+			}
+			if status == models.StockStatusInStock {
+				item.ContractId, item.HistoryId = nil, nil
+			} else if item.ContractId == nil {
+				return movement, helpers.NewError(helpers.MsgStockContractRequired, request.Reason)
+			}
+		case models.StockStatusInstalled:
+			if item.ContractId == nil && item.HistoryId == nil {
+				return movement, helpers.NewError(helpers.MsgStockAssignmentRequired)
+			}
+			item.WarehouseId = nil
+			item.Location = ""
+		case models.StockStatusScrapped:
+			item.ContractId, item.HistoryId = nil, nil
+		}
+	}
+	if request.WarehouseId != nil && status != models.StockStatusInstalled {
+		item.WarehouseId = request.WarehouseId
+	}
+	if request.Location != nil {
+		item.Location = strings.TrimSpace(*request.Location)
+	}
+	item.Status = status
+
+	movement.ToStatus = item.Status
+	movement.ToWarehouseId = item.WarehouseId
+	movement.ToLocation = item.Location
+	movement.ContractId, movement.HistoryId = item.ContractId, item.HistoryId
+	if movement.ContractId == nil {
+		movement.ContractId = previousContract
+	}
+	if movement.HistoryId == nil {
+		movement.HistoryId = previousHistory
+	}
+	return movement, nil
+}
+
+func GetWarehouses(c *fiber.Ctx) error {
+	var warehouses []models.Warehouse
+	if err := initialize.DB.Order("code ASC").Find(&warehouses).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgWarehouseFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   warehouses,
+	})
+}
+
+// saveWarehouse dipakai CreateWarehouse dan UpdateWarehouse; kode gudang disimpan dalam huruf besar dan harus unik
+func saveWarehouse(c *fiber.Ctx, warehouse models.Warehouse, success string) error {
+	var request models.WarehouseRequest
+	if err := c.BodyParser(&request); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := validate.Struct(&request); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	status := fiber.StatusOK
+	if warehouse.WarehouseId == 0 {
+		status = fiber.StatusCreated
+	}
+	warehouse.Code = strings.ToUpper(strings.TrimSpace(request.Code))
+	warehouse.Name = request.Name
+	warehouse.Address = request.Address
+
+	var count int64
+	if err := initialize.DB.Model(&models.Warehouse{}).Where("code = ? AND warehouse_id <> ?", warehouse.Code, warehouse.WarehouseId).
+		Count(&count).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgWarehouseSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if count > 0 {
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgWarehouseExists, warehouse.Code)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+	if err := initialize.DB.Save(&warehouse).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgWarehouseSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.NewResponseMassage(c, status, success)
+	return c.Status(status).JSON(response)
+}
+
+func CreateWarehouse(c *fiber.Ctx) error {
+	return saveWarehouse(c, models.Warehouse{}, helpers.MsgWarehouseCreated)
+}
+
+func UpdateWarehouse(c *fiber.Ctx) error {
+	var warehouse models.Warehouse
+	if err := initialize.DB.Where("warehouse_id = ?", c.Params("id")).First(&warehouse).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgWarehouseNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+	return saveWarehouse(c, warehouse, helpers.MsgWarehouseUpdated)
+}
+
+func DeleteWarehouse(c *fiber.Ctx) error {
+	var warehouse models.Warehouse
+	if err := initialize.DB.Where("warehouse_id = ?", c.Params("id")).First(&warehouse).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgWarehouseNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	// Gudang yang masih menyimpan unit tidak dapat dihapus, unit harus dipindahkan dulu
+	var count int64
+	if err := initialize.DB.Model(&models.StockItem{}).Where("warehouse_id = ?", warehouse.WarehouseId).Count(&count).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgWarehouseDeleteFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if count > 0 {
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgWarehouseInUse, count)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+	if err := initialize.DB.Delete(&warehouse).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgWarehouseDeleteFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgWarehouseDeleted)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetStockItems menampilkan unit dengan filter product_id, status, warehouse_id, contract_id, history_id dan search nomor seri
+func GetStockItems(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	page, _ := strconv.Atoi(c.Query("page"))
+	if limit <= 0 {
+		limit = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	query := initialize.DB.Model(&models.StockItem{})
+	for _, column := range []string{"product_id", "warehouse_id", "contract_id", "history_id"} {
+		if id := c.QueryInt(column); id != 0 {
+			query = query.Where(column+" = ?", id)
+		}
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status IN ?", strings.Split(status, ","))
+	}
+	if search := c.Query("search"); search != "" {
+		query = query.Where("serial_number LIKE ?", "%"+search+"%")
+	}
+
+	var totalRecords int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgStockFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	var items []models.StockItem
+	if err := query.Preload("Product").Preload("Warehouse").Order("serial_number ASC").Limit(limit).Offset(offset).Find(&items).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgStockFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.DataTableResponse{
+		CurrentPage: page,
+		From:        offset + 1,
+		LastPage:    int((totalRecords + int64(limit) - 1) / int64(limit)),
+		To:          offset + len(items),
+		Total:       int(totalRecords),
+		Data:        make([]interface{}, len(items)),
+	}
+	for i, item := range items {
+		response.Data[i] = item
+	}
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   response,
+	})
+}
+
+// stockItemDetail mengirim unit beserta seluruh riwayat perpindahannya, terbaru lebih dulu
+func stockItemDetail(c *fiber.Ctx, query *gorm.DB) error {
+	var item models.StockItem
+	if err := query.Preload("Product").Preload("Warehouse").First(&item).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgStockNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgStockFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	var movements []models.StockMovement
+	if err := initialize.DB.Preload("User").Where("stock_item_id = ?", item.StockItemId).
+		Order("created_at DESC, stock_movement_id DESC").Find(&movements).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgStockFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   fiber.Map{"item": item, "movements": movements},
+	})
+}
+
+func GetStockItem(c *fiber.Ctx) error {
+	return stockItemDetail(c, initialize.DB.Where("stock_item_id = ?", c.Params("id")))
+}
+
+func GetStockItemBySerial(c *fiber.Ctx) error {
+	return stockItemDetail(c, initialize.DB.Where("serial_number = ?", c.Params("serial")))
+}
+
+// ReceiveStock mendaftarkan unit baru ke gudang; semua nomor seri disimpan atau tidak sama sekali
+func ReceiveStock(c *fiber.Ctx) error {
+	var request models.StockReceiveRequest
+	if err := c.BodyParser(&request); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := validate.Struct(&request); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	if err := ensureProductsExist([]int64{request.ProductId}); err != nil {
+		return inputErrorResponse(c, err, helpers.MsgStockSaveFailed)
+	}
+	if err := ensureStockReferences(initialize.DB, &request.WarehouseId, nil, nil); err != nil {
+		return inputErrorResponse(c, err, helpers.MsgStockSaveFailed)
+	}
+
+	// Nomor seri dibandingkan tanpa membedakan huruf besar kecil, sama seperti collation kolomnya
+	serials := make([]string, 0, len(request.SerialNumbers))
+	seen := make(map[string]bool)
+	for _, serial := range request.SerialNumbers {
+		serial = strings.TrimSpace(serial)
+		if serial == "" {
+			response := helpers.NewResponseError(c, map[string][]string{"serial_numbers": {helpers.Message(c, helpers.MsgFieldRequired, "serial_numbers")}})
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		if seen[strings.ToUpper(serial)] {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgStockSerialDuplicate, serial)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		seen[strings.ToUpper(serial)] = true
+		serials = append(serials, serial)
+	}
+	var existing []string
+	if err := initialize.DB.Model(&models.StockItem{}).Where("serial_number IN ?", serials).Limit(1).Pluck("serial_number", &existing).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgStockSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if len(existing) > 0 {
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgStockSerialExists, existing[0])
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+
+	author := requestAuthor(c)
+	location := strings.TrimSpace(request.Location)
+	items := make([]models.StockItem, len(serials))
+	for i, serial := range serials {
+		items[i] = models.StockItem{
+			ProductId:    request.ProductId,
+			SerialNumber: serial,
+			Status:       models.StockStatusInStock,
+			WarehouseId:  &request.WarehouseId,
+			Location:     location,
+		}
+	}
+	err := initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Product", "Warehouse").Create(&items).Error; err != nil {
+			return err
+		}
+		movements := make([]models.StockMovement, len(items))
+		for i, item := range items {
+			movements[i] = models.StockMovement{
+				StockItemId:   item.StockItemId,
+				Reason:        models.StockReasonReceived,
+				ToStatus:      item.Status,
+				ToWarehouseId: item.WarehouseId,
+				ToLocation:    item.Location,
+				Note:          request.Note,
+				UserId:        author,
+			}
+		}
+		return tx.Omit("StockItem", "User").Create(&movements).Error
+	})
+	if isDuplicateKey(err) {
+		// Nomor seri yang sama tersimpan oleh permintaan lain di antara pemeriksaan dan insert
+		serial := serials[0]
+		existing = nil
+		if initialize.DB.Model(&models.StockItem{}).Where("serial_number IN ?", serials).Limit(1).Pluck("serial_number", &existing).Error == nil && len(existing) > 0 {
+			serial = existing[0]
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgStockSerialExists, serial)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgStockSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   items,
+	})
+}
+
+// MoveStockItem memproses satu unit sesuai models.StockMovementRules dan mencatat perpindahannya
+func MoveStockItem(c *fiber.Ctx) error {
+	var request models.StockMovementRequest
+	if err := c.BodyParser(&request); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := validate.Struct(&request); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := ensureStockReferences(initialize.DB, request.WarehouseId, request.ContractId, request.HistoryId); err != nil {
+		return inputErrorResponse(c, err, helpers.MsgStockSaveFailed)
+	}
+
+	author := requestAuthor(c)
+	var item models.StockItem
+	err := initialize.DB.Transaction(func(tx *gorm.DB) error {
+		// Baris unit dikunci agar dua perpindahan bersamaan tidak memakai status yang sama
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("stock_item_id = ?", c.Params("id")).First(&item).Error; err != nil {
+			return err
+		}
+		movement, err := applyStockMovement(&item, request)
+		if err != nil {
+			return err
+		}
+		movement.UserId = author
+		if err := tx.Omit("Product", "Warehouse").Save(&item).Error; err != nil {
+			return err
+		}
+		return tx.Omit("StockItem", "User").Create(&movement).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgStockNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		return stockErrorResponse(c, err)
+	}
+
+	return stockItemDetail(c, initialize.DB.Where("stock_item_id = ?", item.StockItemId))
+}
+
+// GetStockSummary menghitung jumlah unit per produk dan status, dapat difilter dengan product_id dan warehouse_id
+func GetStockSummary(c *fiber.Ctx) error {
+	query := initialize.DB.Table("stock_items").
+		Select("stock_items.product_id, products.title AS product, stock_items.status, COUNT(*) AS total").
+		Joins("JOIN products ON products.product_id = stock_items.product_id")
+	if productId := c.QueryInt("product_id"); productId != 0 {
+		query = query.Where("stock_items.product_id = ?", productId)
+	}
+	if warehouseId := c.QueryInt("warehouse_id"); warehouseId != 0 {
+		query = query.Where("stock_items.warehouse_id = ?", warehouseId)
+	}
+
+	var summary []models.StockSummary
+	if err := query.Group("stock_items.product_id, products.title, stock_items.status").
+		Order("products.title ASC, stock_items.status ASC").Scan(&summary).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgStockFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   summary,
+	})
+}
+
+// GetAssignedStock menampilkan semua unit yang dialokasikan atau dipasang pada kontrak atau riwayat pemasangan
+func GetAssignedStock(resourceName string) fiber.Handler {
+	column := stockAssignmentColumns[resourceName]
+	return func(c *fiber.Ctx) error {
+		var items []models.StockItem
+		if err := initialize.DB.Preload("Product").Preload("Warehouse").Where(column+" = ?", c.Params("id")).
+			Order("product_id ASC, serial_number ASC").Find(&items).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgStockFetchFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		return c.JSON(helpers.GeneralResponse{
+			Code:   fiber.StatusOK,
+			Status: "OK",
+			Data:   items,
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/models"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func stringPtr(v string) *string { return &v }
+
+func TestApplyStockMovement(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    models.StockItem
+		request models.StockMovementRequest
+		wantErr string
+		want    models.StockItem
+	}{
+		{
+			name:    "reserve from stock",
+			item:    models.StockItem{Status: models.StockStatusInStock, WarehouseId: int64Ptr(1)},
+			request: models.StockMovementRequest{Reason: models.StockReasonReserve, ContractId: int64Ptr(5)},
+			want:    models.StockItem{Status: models.StockStatusReserved, WarehouseId: int64Ptr(1), ContractId: int64Ptr(5)},
+		},
+		{
+			name:    "reserve needs contract",
+			item:    models.StockItem{Status: models.StockStatusInStock, WarehouseId: int64Ptr(1)},
+			request: models.StockMovementRequest{Reason: models.StockReasonReserve},
+			wantErr: helpers.MsgStockContractRequired,
+		},
+		{
+			name:    "install clears warehouse",
+			item:    models.StockItem{Status: models.StockStatusReserved, WarehouseId: int64Ptr(1), Location: "A-1", ContractId: int64Ptr(5)},
+			request: models.StockMovementRequest{Reason: models.StockReasonInstall, HistoryId: int64Ptr(9)},
+			want:    models.StockItem{Status: models.StockStatusInstalled, ContractId: int64Ptr(5), HistoryId: int64Ptr(9)},
+		},
+		{
+			name:    "install needs assignment",
+			item:    models.StockItem{Status: models.StockStatusInStock, WarehouseId: int64Ptr(1)},
+			request: models.StockMovementRequest{Reason: models.StockReasonInstall},
+			wantErr: helpers.MsgStockAssignmentRequired,
+		},
+		{
+			name:    "uninstall needs warehouse",
+			item:    models.StockItem{Status: models.StockStatusInstalled, ContractId: int64Ptr(5)},
+			request: models.StockMovementRequest{Reason: models.StockReasonUninstall},
+			wantErr: helpers.MsgStockWarehouseRequired,
+		},
+		{
+			name:    "uninstall returns to stock",
+			item:    models.StockItem{Status: models.StockStatusInstalled, ContractId: int64Ptr(5), HistoryId: int64Ptr(9)},
+			request: models.StockMovementRequest{Reason: models.StockReasonUninstall, WarehouseId: int64Ptr(2), Location: stringPtr(" B-2 ")},
+			want:    models.StockItem{Status: models.StockStatusInStock, WarehouseId: int64Ptr(2), Location: "B-2"},
+		},
+		{
+			name:    "scrapped units cannot move",
+			item:    models.StockItem{Status: models.StockStatusScrapped},
+			request: models.StockMovementRequest{Reason: models.StockReasonRepair},
+			wantErr: helpers.MsgStockMoveInvalid,
+		},
+		{
+			name:    "transfer needs a destination",
+			item:    models.StockItem{Status: models.StockStatusInStock, WarehouseId: int64Ptr(1)},
+			request: models.StockMovementRequest{Reason: models.StockReasonTransfer},
+			wantErr: helpers.MsgStockLocationRequired,
+		},
+		{
+			name:    "adjustment needs a note",
+			item:    models.StockItem{Status: models.StockStatusInStock, WarehouseId: int64Ptr(1)},
+			request: models.StockMovementRequest{Reason: models.StockReasonAdjustment, Status: models.StockStatusScrapped, Note: " "},
+			wantErr: helpers.MsgStockAdjustmentInvalid,
+		},
+		{
+			name:    "adjustment to stock needs warehouse",
+			item:    models.StockItem{Status: models.StockStatusInstalled, ContractId: int64Ptr(5)},
+			request: models.StockMovementRequest{Reason: models.StockReasonAdjustment, Status: models.StockStatusInStock, Note: "audit"},
+			wantErr: helpers.MsgStockWarehouseRequired,
+		},
+		{
+			name:    "adjustment to stock clears assignment",
+			item:    models.StockItem{Status: models.StockStatusInstalled, ContractId: int64Ptr(5), HistoryId: int64Ptr(9)},
+			request: models.StockMovementRequest{Reason: models.StockReasonAdjustment, Status: models.StockStatusInStock, WarehouseId: int64Ptr(1), Note: "audit"},
+			want:    models.StockItem{Status: models.StockStatusInStock, WarehouseId: int64Ptr(1)},
+		},
+		{
+			name:    "adjustment to reserved needs contract",
+			item:    models.StockItem{Status: models.StockStatusInStock, WarehouseId: int64Ptr(1)},
+			request: models.StockMovementRequest{Reason: models.StockReasonAdjustment, Status: models.StockStatusReserved, Note: "audit"},
+			wantErr: helpers.MsgStockContractRequired,
+		},
+		{
+			name:    "adjustment to installed ignores warehouse",
+			item:    models.StockItem{Status: models.StockStatusInStock, WarehouseId: int64Ptr(1), Location: "A-1"},
+			request: models.StockMovementRequest{Reason: models.StockReasonAdjustment, Status: models.StockStatusInstalled, WarehouseId: int64Ptr(2), HistoryId: int64Ptr(9), Note: "audit"},
+			want:    models.StockItem{Status: models.StockStatusInstalled, HistoryId: int64Ptr(9)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			movement, err := applyStockMovement(&item, tt.request)
+			if tt.wantErr != "" {
+				var appErr *helpers.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantErr {
+					t.Fatalf("err = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(item, tt.want) {
+				t.Errorf("item = %+v, want %+v", item, tt.want)
+			}
+			if movement.FromStatus != tt.item.Status || movement.ToStatus != tt.want.Status {
+				t.Errorf("movement status = %s -> %s", movement.FromStatus, movement.ToStatus)
+			}
+		})
+	}
+}
+
+func TestApplyStockMovementKeepsReleasedAssignment(t *testing.T) {
+	item := models.StockItem{Status: models.StockStatusReserved, WarehouseId: int64Ptr(1), ContractId: int64Ptr(5)}
+	movement, err := applyStockMovement(&item, models.StockMovementRequest{Reason: models.StockReasonRelease})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.ContractId != nil {
+		t.Errorf("item contract = %v, want nil", *item.ContractId)
+	}
+	if movement.ContractId == nil || *movement.ContractId != 5 {
+		t.Errorf("movement contract = %v, want 5", movement.ContractId)
+	}
+}
@@ -0,0 +1,327 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// saveUpload menyimpan file unggahan ke direktori public dan mencatat metadatanya ke tabel files
+func saveUpload(c *fiber.Ctx, file *multipart.FileHeader) (models.File, error) {
+	// Simpan dulu dengan nama sementara, ekstensi final ditentukan dari isi file
+	tmpName := uuid.New().String()
+	tmpPath := fmt.Sprintf("./public/%s.upload", tmpName)
+	if err := c.SaveFile(file, tmpPath); err != nil {
+		return models.File{}, err
+	}
+
+	// Tanggal dan lokasi pengambilan hanya disimpan jika diminta secara eksplisit
+	extract, _ := strconv.ParseBool(c.FormValue("extract_metadata"))
+
+	// Catat pengunggah jika request melewati middleware autentikasi
+	var uploadedBy *int64
+	if userID, ok := c.Locals("userID").(float64); ok {
+		uploader := int64(userID)
+		uploadedBy = &uploader
+	}
+	return storeUpload(initialize.DB, tmpName, file.Filename, extract, uploadedBy)
+}
+
+// storeUpload memproses file sementara ./public/<tmpName>.upload menjadi file publik dan mencatatnya di tabel files
+func storeUpload(db *gorm.DB, tmpName string, originalName string, extract bool, uploadedBy *int64) (models.File, error) {
+	tmpPath := fmt.Sprintf("./public/%s.upload", tmpName)
+
+	// Hapus metadata EXIF (lokasi GPS, perangkat) sebelum file bisa diakses publik
+	exif, err := helpers.SanitizeImageFile(tmpPath)
+	if err != nil {
+		os.Remove(tmpPath)
+		return models.File{}, err
+	}
+
+	info, err := helpers.ProbeMediaFile(tmpPath, originalName)
+	if err != nil {
+		os.Remove(tmpPath)
+		return models.File{}, err
+	}
+
+	filename := tmpName + info.Format
+	if err := os.Rename(tmpPath, fmt.Sprintf("./public/%s", filename)); err != nil {
+		os.Remove(tmpPath)
+		return models.File{}, err
+	}
+
+	fileModel := models.File{
+		Path:         fmt.Sprintf("/public/%s", filename),
+		File_name:    filename,
+		OriginalName: originalName,
+		Size:         info.Size,
+		Format:       info.Format,
+		MimeType:     info.MimeType,
+		Checksum:     info.Checksum,
+		Width:        info.Width,
+		Height:       info.Height,
+		Duration:     info.Duration,
+	}
+
+	if extract {
+		fileModel.CapturedAt = exif.CapturedAt
+		fileModel.Latitude = exif.Latitude
+		fileModel.Longitude = exif.Longitude
+	}
+	fileModel.UploadedBy = uploadedBy
+
+	if err := db.Create(&fileModel).Error; err != nil {
+		os.Remove("." + fileModel.Path)
+		return models.File{}, err
+	}
+	return fileModel, nil
+}
+
+func GetMediaById(c *fiber.Ctx) error {
+	// Ambil ID media dari parameter URL
+	mediaId := c.Params("id")
+
+	var file models.File
+	if err := initialize.DB.Where("file_id = ?", mediaId).First(&file).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgMediaNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgMediaFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	var data interface{} = file
+	if isAdminRequest(c) {
+		data = models.FileLocation{File: file, Latitude: file.Latitude, Longitude: file.Longitude}
+	}
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   data,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// mediaKitEntry adalah satu baris pada manifest.json di dalam arsip media kit
+type mediaKitEntry struct {
+	Name         string `json:"name"`
+	Kind         string `json:"kind"`
+	OriginalName string `json:"original_name,omitempty"`
+	MimeType     string `json:"mime_type,omitempty"`
+	Size         int64  `json:"size"`
+	Checksum     string `json:"sha256,omitempty"`
+	Missing      bool   `json:"missing,omitempty"`
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._ -]+`)
+
+// zipEntryName membersihkan nama file dan menambahkan akhiran jika nama sudah dipakai
+func zipEntryName(folder string, name string, used map[string]int) string {
+	name = strings.TrimSpace(unsafeFilenameChars.ReplaceAllString(filepath.Base(name), "_"))
+	if name == "" || name == "." {
+		name = "file"
+	}
+	full := folder + "/" + name
+	used[full]++
+	if used[full] > 1 {
+		ext := filepath.Ext(name)
+		full = fmt.Sprintf("%s/%s (%d)%s", folder, strings.TrimSuffix(name, ext), used[full], ext)
+	}
+	return full
+}
+
+// productSpecSheet membuat lembar spesifikasi teks sederhana untuk dikirim ke calon pelanggan
+func productSpecSheet(product models.Product) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", product.Title)
+	fmt.Fprintf(&b, "%s\n\n", strings.Repeat("=", len(product.Title)))
+	if product.Category.Category != "" {
+		fmt.Fprintf(&b, "Kategori: %s\n\n", product.Category.Category)
+	}
+	fmt.Fprintf(&b, "Deskripsi\n---------\n%s\n\n", product.Description)
+	fmt.Fprintf(&b, "Spesifikasi\n-----------\n%s\n\n", product.Specification)
+	fmt.Fprintf(&b, "Diperbarui: %s\n", product.UpdatedAt.Format("2006-01-02"))
+	return b.String()
+}
+
+func DownloadProductMediaKit(c *fiber.Ctx) error {
+	// Ambil ID produk dari parameter URL
+	productId := c.Params("id")
+
+	var product models.Product
+	if err := initialize.DB.Preload("Category").Preload("File").Preload("Gallery.File").Scopes(visibleScope(c, "products")).Where("product_id = ?", productId).First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgProductNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	var heroes []models.Hero
+	if err := initialize.DB.Preload("File").Where("product_id = ?", product.ProductId).Find(&heroes).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgHeroFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Kumpulkan daftar file yang akan dimasukkan ke arsip
+	type kitFile struct {
+		folder string
+		kind   string
+		path   string
+		file   models.File
+	}
+	var files []kitFile
+	if product.FileId != 0 {
+		files = append(files, kitFile{"images", "main", product.File.Path, product.File})
+	}
+	for _, gallery := range product.Gallery {
+		file := gallery.File
+		if file.OriginalName == "" {
+			file.OriginalName = gallery.Gallery_name
+		}
+		files = append(files, kitFile{"gallery", "gallery", gallery.Path, file})
+	}
+	for _, hero := range heroes {
+		files = append(files, kitFile{"hero", "hero", hero.Path, hero.File})
+	}
+
+	archiveName := strings.Trim(unsafeFilenameChars.ReplaceAllString(strings.ToLower(product.Title), "-"), "-. ")
+	if archiveName == "" {
+		archiveName = "product-" + strconv.FormatInt(product.ProductId, 10)
+	}
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s-media.zip"`, archiveName))
+
+	// Arsip ditulis langsung ke koneksi, file dibaca satu per satu dari storage
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		zw := zip.NewWriter(w)
+		used := make(map[string]int)
+		var manifest []mediaKitEntry
+		modified := time.Now()
+
+		for _, item := range files {
+			original := item.file.OriginalName
+			if original == "" {
+				original = filepath.Base(item.path)
+			}
+			name := zipEntryName(item.folder, original, used)
+			entry := mediaKitEntry{
+				Name:         name,
+				Kind:         item.kind,
+				OriginalName: item.file.OriginalName,
+				MimeType:     item.file.MimeType,
+				Checksum:     item.file.Checksum,
+			}
+
+			src, err := os.Open("." + item.path)
+			if err != nil {
+				log.Printf("media kit product %d: %v", product.ProductId, err)
+				entry.Missing = true
+				manifest = append(manifest, entry)
+				continue
+			}
+			// Gambar sudah terkompresi, cukup disimpan tanpa deflate
+			dst, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: modified})
+			if err == nil {
+				entry.Size, err = io.Copy(dst, src)
+			}
+			src.Close()
+			if err != nil {
+				log.Printf("media kit product %d: %v", product.ProductId, err)
+				return
+			}
+			manifest = append(manifest, entry)
+			w.Flush()
+		}
+
+		specSheet := productSpecSheet(product)
+		if dst, err := zw.CreateHeader(&zip.FileHeader{Name: "spec-sheet.txt", Method: zip.Deflate, Modified: modified}); err == nil {
+			io.WriteString(dst, specSheet)
+			manifest = append(manifest, mediaKitEntry{Name: "spec-sheet.txt", Kind: "spec_sheet", MimeType: "text/plain", Size: int64(len(specSheet))})
+		}
+
+		if dst, err := zw.CreateHeader(&zip.FileHeader{Name: "manifest.json", Method: zip.Deflate, Modified: modified}); err == nil {
+			encoder := json.NewEncoder(dst)
+			encoder.SetIndent("", "  ")
+			encoder.Encode(map[string]interface{}{
+				"product_id":   product.ProductId,
+				"title":        product.Title,
+				"category":     product.Category.Category,
+				"generated_at": modified,
+				"files":        manifest,
+			})
+		}
+
+		if err := zw.Close(); err != nil {
+			log.Printf("media kit product %d: %v", product.ProductId, err)
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// sharedAssetPaths adalah file bawaan aplikasi yang tidak boleh dihapus walaupun tercatat di tabel files
+var sharedAssetPaths = map[string]bool{
+	"/public/image_notfound.jpg": true,
+}
+
+// deleteFileIfUnused menghapus file dari storage dan database hanya jika tidak lagi direferensikan data lain,
+// termasuk data di trash yang masih bisa dipulihkan
+func deleteFileIfUnused(fileId int64) error {
+	var file models.File
+	if err := initialize.DB.Where("file_id = ?", fileId).First(&file).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if sharedAssetPaths[file.Path] {
+		return nil
+	}
+
+	references := []interface{}{&models.User{}, &models.Product{}, &models.History{}, &models.Gallery{}, &models.Hero{}}
+	for _, model := range references {
+		var count int64
+		if err := initialize.DB.Unscoped().Model(model).Where("file_id = ?", fileId).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+	}
+	// Revisi produk menyimpan file sebagai JSON agar bisa dipulihkan, sehingga file tersebut belum boleh dihapus
+	var revisionCount int64
+	if err := initialize.DB.Model(&models.ProductRevision{}).
+		Where("JSON_EXTRACT(file, '$.file_id') = ? OR JSON_CONTAINS(gallery, JSON_OBJECT('file_id', ?))", fileId, fileId).
+		Count(&revisionCount).Error; err != nil {
+		return err
+	}
+	if revisionCount > 0 {
+		return nil
+	}
+
+	if err := os.Remove("." + file.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return initialize.DB.Delete(&file).Error
+}
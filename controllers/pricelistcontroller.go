@@ -0,0 +1,360 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// dateLayout adalah format tanggal pada daftar harga dan penawaran
+const dateLayout = "2006-01-02"
+
+// parseDate membaca tanggal YYYY-MM-DD, string kosong berarti tanpa tanggal
+func parseDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return nil, helpers.NewError(helpers.MsgInvalidDate, value)
+	}
+	return &parsed, nil
+}
+
+// startOfDay membuang jam agar masa berlaku dibandingkan per tanggal
+func startOfDay(at time.Time) time.Time {
+	year, month, day := at.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// priceListFromRequest memeriksa request lalu menyusun daftar harga beserta itemnya
+func priceListFromRequest(request models.PriceListRequest) (models.PriceList, error) {
+	validFrom, err := parseDate(request.ValidFrom)
+	if err != nil {
+		return models.PriceList{}, err
+	}
+	validUntil, err := parseDate(request.ValidUntil)
+	if err != nil {
+		return models.PriceList{}, err
+	}
+	if validFrom != nil && validUntil != nil && validUntil.Before(*validFrom) {
+		return models.PriceList{}, helpers.NewError(helpers.MsgPriceListPeriodInvalid)
+	}
+
+	list := models.PriceList{
+		Name:       request.Name,
+		Tier:       strings.TrimSpace(request.Tier),
+		Currency:   strings.ToUpper(request.Currency),
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+		Items:      make([]models.PriceListItem, 0, len(request.Items)),
+	}
+	if list.Currency == "" {
+		list.Currency = "IDR"
+	}
+
+	productIds := make([]int64, 0, len(request.Items))
+	seen := make(map[int64]bool)
+	for _, item := range request.Items {
+		if seen[item.ProductId] {
+			return models.PriceList{}, helpers.NewError(helpers.MsgPriceListDuplicateProduct, item.ProductId)
+		}
+		seen[item.ProductId] = true
+		productIds = append(productIds, item.ProductId)
+		list.Items = append(list.Items, models.PriceListItem{ProductId: item.ProductId, Price: helpers.RoundMoney(item.Price)})
+	}
+	if err := ensureProductsExist(productIds); err != nil {
+		return models.PriceList{}, err
+	}
+	return list, nil
+}
+
+// ensureProductsExist mengembalikan kesalahan untuk produk pertama yang tidak ada
+func ensureProductsExist(productIds []int64) error {
+	if len(productIds) == 0 {
+		return nil
+	}
+	var existing []int64
+	if err := initialize.DB.Model(&models.Product{}).Where("product_id IN ?", productIds).Pluck("product_id", &existing).Error; err != nil {
+		return err
+	}
+	found := make(map[int64]bool, len(existing))
+	for _, id := range existing {
+		found[id] = true
+	}
+	for _, id := range productIds {
+		if !found[id] {
+			return helpers.NewError(helpers.MsgProductIDNotFound, id)
+		}
+	}
+	return nil
+}
+
+// inputErrorResponse mengubah AppError dari pemeriksaan input menjadi respons 400, kesalahan lain menjadi 500 dengan pesan failure
+func inputErrorResponse(c *fiber.Ctx, err error, failure string) error {
+	var appError *helpers.AppError
+	if errors.As(err, &appError) {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, failure)
+	return c.Status(fiber.StatusInternalServerError).JSON(response)
+}
+
+// resolvePrices mencari harga yang berlaku pada tanggal tertentu untuk setiap produk.
+// Daftar harga khusus tier didahulukan dari daftar umum, lalu yang mulai berlaku paling akhir.
+func resolvePrices(db *gorm.DB, productIds []int64, tier string, at time.Time) (map[int64]models.ResolvedPrice, error) {
+	prices := make(map[int64]models.ResolvedPrice, len(productIds))
+	if len(productIds) == 0 {
+		return prices, nil
+	}
+	day := startOfDay(at)
+	tiers := []string{""}
+	if tier != "" {
+		tiers = append(tiers, tier)
+	}
+
+	var candidates []models.ResolvedPrice
+	if err := db.Table("price_list_items").
+		Select("price_list_items.product_id, price_lists.price_list_id, price_lists.name AS price_list, price_lists.tier, price_lists.currency, price_list_items.price").
+		Joins("JOIN price_lists ON price_lists.price_list_id = price_list_items.price_list_id").
+		Where("price_list_items.product_id IN ?", productIds).
+		Where("price_lists.tier IN ?", tiers).
+		Where("price_lists.valid_from IS NULL OR price_lists.valid_from <= ?", day).
+		Where("price_lists.valid_until IS NULL OR price_lists.valid_until >= ?", day).
+		Order(gorm.Expr("price_lists.tier = ? DESC", tier)).
+		Order("price_lists.valid_from IS NULL, price_lists.valid_from DESC, price_lists.price_list_id DESC").
+		Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		if _, ok := prices[candidate.ProductId]; !ok {
+			prices[candidate.ProductId] = candidate
+		}
+	}
+	return prices, nil
+}
+
+func GetPriceLists(c *fiber.Ctx) error {
+	query := initialize.DB.Model(&models.PriceList{})
+	if tier, ok := c.Queries()["tier"]; ok {
+		query = query.Where("tier = ?", tier)
+	}
+	// active=1 hanya menampilkan daftar harga yang berlaku hari ini
+	if c.QueryBool("active") {
+		today := startOfDay(time.Now())
+		query = query.Where("valid_from IS NULL OR valid_from <= ?", today).Where("valid_until IS NULL OR valid_until >= ?", today)
+	}
+
+	var lists []models.PriceList
+	if err := query.Order("tier ASC, valid_from DESC, price_list_id DESC").Find(&lists).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgPriceListFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   lists,
+	})
+}
+
+func GetPriceList(c *fiber.Ctx) error {
+	var list models.PriceList
+	if err := initialize.DB.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("product_id ASC")
+	}).Preload("Items.Product").Where("price_list_id = ?", c.Params("id")).First(&list).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgPriceListNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgPriceListFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Nama produk ditampilkan dalam bahasa permintaan
+	products := make([]models.Product, len(list.Items))
+	for i, item := range list.Items {
+		products[i] = item.Product
+	}
+	translateProducts(c, products)
+	for i := range list.Items {
+		list.Items[i].Title = products[i].Title
+	}
+
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   list,
+	})
+}
+
+func CreatePriceList(c *fiber.Ctx) error {
+	var request models.PriceListRequest
+	if err := c.BodyParser(&request); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := validate.Struct(&request); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	list, err := priceListFromRequest(request)
+	if err != nil {
+		return inputErrorResponse(c, err, helpers.MsgPriceListSaveFailed)
+	}
+	if err := initialize.DB.Create(&list).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgPriceListSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.NewResponseMassage(c, fiber.StatusCreated, helpers.MsgPriceListCreated)
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+func UpdatePriceList(c *fiber.Ctx) error {
+	var existing models.PriceList
+	if err := initialize.DB.Where("price_list_id = ?", c.Params("id")).First(&existing).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgPriceListNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgPriceListFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	var request models.PriceListRequest
+	if err := c.BodyParser(&request); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := validate.Struct(&request); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	list, err := priceListFromRequest(request)
+	if err != nil {
+		return inputErrorResponse(c, err, helpers.MsgPriceListSaveFailed)
+	}
+	list.PriceListId = existing.PriceListId
+	list.CreatedAt = existing.CreatedAt
+	for i := range list.Items {
+		list.Items[i].PriceListId = existing.PriceListId
+	}
+
+	// Item lama diganti seluruhnya dalam satu transaksi
+	err = initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("price_list_id = ?", existing.PriceListId).Delete(&models.PriceListItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("Items").Save(&list).Error; err != nil {
+			return err
+		}
+		if len(list.Items) == 0 {
+			return nil
+		}
+		return tx.Omit("Product").Create(&list.Items).Error
+	})
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgPriceListSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgPriceListUpdated)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func DeletePriceList(c *fiber.Ctx) error {
+	var list models.PriceList
+	if err := initialize.DB.Where("price_list_id = ?", c.Params("id")).First(&list).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgPriceListNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	// Penawaran menyimpan harga satuannya sendiri sehingga tidak terpengaruh penghapusan daftar harga
+	err := initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("price_list_id = ?", list.PriceListId).Delete(&models.PriceListItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&list).Error
+	})
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgPriceListDeleteFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgPriceListDeleted)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// ResolvePrices mengembalikan harga yang berlaku untuk ?product_ids=1,2 berdasarkan tier pelanggan (user_id) atau tier, pada tanggal date (bawaan hari ini)
+func ResolvePrices(c *fiber.Ctx) error {
+	var productIds []int64
+	seen := make(map[int64]bool)
+	for _, part := range strings.Split(c.Query("product_ids"), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || id <= 0 {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidProductIDValue, part)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		if !seen[id] {
+			seen[id] = true
+			productIds = append(productIds, id)
+		}
+	}
+	if len(productIds) == 0 {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgProductIDsRequired)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	at := time.Now()
+	if date, err := parseDate(c.Query("date")); err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	} else if date != nil {
+		at = *date
+	}
+
+	tier := c.Query("tier")
+	if userId := c.QueryInt("user_id"); userId != 0 {
+		var user models.User
+		if err := initialize.DB.Select("user_id", "price_tier").First(&user, userId).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgUserIDNotFound, userId)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		tier = user.PriceTier
+	}
+
+	prices, err := resolvePrices(initialize.DB, productIds, tier, at)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgPriceListFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	// Produk tanpa harga yang berlaku tidak ikut dalam hasil
+	data := make([]models.ResolvedPrice, 0, len(prices))
+	for _, id := range productIds {
+		if price, ok := prices[id]; ok {
+			data = append(data, price)
+		}
+	}
+
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   data,
+	})
+}
@@ -7,32 +7,52 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"os"
-	"path/filepath"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 func GetAllProducts(c *fiber.Ctx) error {
 	// Ambil semua produk dari database
 	var products []models.Product
-	if err := initialize.DB.Preload("Category").Preload("File").Find(&products).Error; err != nil {
-		// Jika terjadi kesalahan saat mengambil produk, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    500,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
+	query := initialize.DB.Preload("Category").Preload("File").Preload("Tags").Preload("Attributes.Attribute").Model(&models.Product{})
+
+	// Pengunjung hanya melihat produk yang sudah terbit
+	query = query.Scopes(visibleScope(c, "products"))
+
+	// Filter berdasarkan kategori dan atribut spesifikasi
+	categoryID := c.Query("category_id")
+	if categoryID != "" {
+		// Produk dari subkategori ikut ditampilkan
+		categoryIDs, err := categoryFilterIDs(categoryID)
+		if err != nil {
+			response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
 		}
+		query = query.Where("category_id IN ?", categoryIDs)
+	}
+	query, err := applyAttributeFilters(c, query, categoryID)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if sortBy := c.Query("sort_by"); sortBy != "" {
+		query, _ = applyAttributeSort(query, sortBy, c.Query("sort"), categoryID)
+	}
+
+	if err := query.Find(&products).Error; err != nil {
+		// Jika terjadi kesalahan saat mengambil produk, kirim respons kesalahan ke klien
+		response := helpers.NewResponseMassage(c, 500, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	translateProducts(c, products)
 	var customProducts []models.ProductResponse
 	for _, product := range products {
 		customProduct := models.ProductResponse{
 			ProductId:     product.ProductId,
 			Title:         product.Title,
+			Slug:          product.Slug,
 			Description:   product.Description,
 			Specification: product.Specification,
 			CreatedAt:     product.CreatedAt,
@@ -41,6 +61,11 @@ func GetAllProducts(c *fiber.Ctx) error {
 			CategoryId:    product.CategoryId,
 			PathFile:      product.File.Path,
 			Category:      product.Category.Category,
+			Tags:          product.Tags,
+			Attributes:    productAttributeMap(product.Attributes),
+			Status:        product.Status,
+			PublishAt:     product.PublishAt,
+			PublishedAt:   product.PublishedAt,
 		}
 		customProducts = append(customProducts, customProduct)
 	}
@@ -57,15 +82,12 @@ func GetAllProducts(c *fiber.Ctx) error {
 func GetProductsLabel(c *fiber.Ctx) error {
 	// Ambil semua produk dari database
 	var products []models.Product
-	if err := initialize.DB.Find(&products).Error; err != nil {
+	if err := initialize.DB.Scopes(visibleScope(c, "products")).Find(&products).Error; err != nil {
 		// Jika terjadi kesalahan saat mengambil produk, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	translateProducts(c, products)
 
 	// Buat respons dengan format yang diinginkan
 	var productOptions []map[string]interface{}
@@ -88,13 +110,9 @@ func GetProductsLabel(c *fiber.Ctx) error {
 func GetCountProduct(c *fiber.Ctx) error {
 	// Hitung jumlah total produk dari database
 	var count int64
-	if err := initialize.DB.Model(&models.Product{}).Count(&count).Error; err != nil {
+	if err := initialize.DB.Model(&models.Product{}).Scopes(visibleScope(c, "products")).Count(&count).Error; err != nil {
 		// Jika terjadi kesalahan saat menghitung produk, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -129,24 +147,32 @@ func GetDatatableProducts(c *fiber.Ctx) error {
 
 	// Lakukan pengambilan data dari database dengan menggunakan parameter limit, offset, sort, dan sort_by
 	var products []models.Product
-	query := initialize.DB.Preload("File").Preload("Category").Model(&models.Product{})
+	query := initialize.DB.Model(&models.Product{}).Scopes(visibleScope(c, "products"))
 
 	// Jika parameter search tidak kosong, tambahkan filter pencarian
 	if search != "" {
 		query = query.Where("title LIKE ?", "%"+search+"%")
 	}
 
-	// Jika parameter sort dan sort_by disediakan, lakukan pengurutan berdasarkan kolom yang dimaksud
-	if sort != "" && sortBy != "" {
-		query = query.Order(fmt.Sprintf("%s %s", sortBy, sort))
+	// Produk dari subkategori ikut ditampilkan
+	if categoryID != "" {
+		categoryIDs, err := categoryFilterIDs(categoryID)
+		if err != nil {
+			response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		query = query.Where("category_id IN ?", categoryIDs)
 	}
 
-	if categoryID != "" {
-		query = query.Where("category_id = ?", categoryID)
+	// Filter atribut spesifikasi, misalnya attr[pixel_pitch][max]=3.9 atau attr[ip_rating]=IP65,IP67
+	query, err := applyAttributeFilters(c, query, categoryID)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	var totalRecords int64
-	if err := initialize.DB.Model(&models.Product{}).Count(&totalRecords).Error; err != nil {
+	if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
 		response := helpers.GeneralResponse{
 			Code:   500,
 			Status: "Internal Server Error",
@@ -154,8 +180,23 @@ func GetDatatableProducts(c *fiber.Ctx) error {
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	// Jika parameter sort dan sort_by disediakan, lakukan pengurutan berdasarkan kolom atau atribut yang dimaksud
+	if sort != "" && sortBy != "" {
+		var sorted bool
+		query, sorted = applyAttributeSort(query, sortBy, sort, categoryID)
+		if !sorted {
+			query = query.Order(fmt.Sprintf("%s %s", sortBy, sort))
+		}
+	}
+
+	// Ekspor memakai filter dan urutan yang sama tanpa paginasi
+	if format := c.Query("format"); format != "" {
+		query = query.Order("products.product_id").Preload("File").Preload("Category").Preload("Tags").Preload("Attributes.Attribute")
+		return exportTable(c, format, "products", productExportKeys, query, productExportRows)
+	}
+
 	// Limit jumlah data yang diambil sesuai dengan nilai parameter limit dan offset
-	query = query.Limit(limit).Offset(offset)
+	query = query.Preload("File").Preload("Category").Preload("Tags").Preload("Attributes.Attribute").Limit(limit).Offset(offset)
 
 	// Lakukan pengambilan data
 	if err := query.Find(&products).Error; err != nil {
@@ -167,6 +208,9 @@ func GetDatatableProducts(c *fiber.Ctx) error {
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	if !editingRequest(c) {
+		translateProducts(c, products)
+	}
 	totalPages := int(math.Ceil(float64(totalRecords) / float64(limit)))
 
 	response := helpers.DataTableResponse{
@@ -187,6 +231,7 @@ func GetDatatableProducts(c *fiber.Ctx) error {
 		productMap := map[string]interface{}{
 			"product_id":    product.ProductId,
 			"name":          product.Title,
+			"slug":          product.Slug,
 			"description":   product.Description,
 			"specification": product.Specification,
 			"created_at":    product.CreatedAt,
@@ -195,6 +240,11 @@ func GetDatatableProducts(c *fiber.Ctx) error {
 			"category_id":   product.CategoryId,
 			"path_file":     product.File.Path,
 			"category":      product.Category.Category,
+			"tags":          product.Tags,
+			"attributes":    productAttributeMap(product.Attributes),
+			"status":        product.Status,
+			"publish_at":    product.PublishAt,
+			"published_at":  product.PublishedAt,
 		}
 
 		// Tambahkan map produk ke dalam slice Data pada respons
@@ -209,37 +259,61 @@ func GetDatatableProducts(c *fiber.Ctx) error {
 	})
 }
 
+// productExportKeys adalah kolom ekspor datatable produk
+var productExportKeys = []string{"product_id", "name", "slug", "category", "status", "tags", "attributes",
+	"description", "specification", "path_file", "publish_at", "published_at", "created_at", "updated_at"}
+
+func productExportRows(locale string, query *gorm.DB) ([][]interface{}, error) {
+	var products []models.Product
+	if err := query.Find(&products).Error; err != nil {
+		return nil, err
+	}
+	translateProductsIn(locale, products)
+	rows := make([][]interface{}, 0, len(products))
+	for _, product := range products {
+		rows = append(rows, []interface{}{
+			product.ProductId, product.Title, product.Slug, product.Category.Category, product.Status,
+			exportTagNames(product.Tags), productAttributeText(product.Attributes), product.Description,
+			product.Specification, product.File.Path, product.PublishAt, product.PublishedAt, product.CreatedAt, product.UpdatedAt,
+		})
+	}
+	return rows, nil
+}
+
 func GetProductById(c *fiber.Ctx) error {
 	// Ambil ID produk dari parameter URL
-	productId := c.Params("id")
+	return sendProduct(c, c.Params("id"))
+}
 
+// sendProduct mengirim detail produk, dipakai oleh pencarian berdasarkan ID maupun slug
+func sendProduct(c *fiber.Ctx, productId interface{}) error {
 	// Buat variabel untuk menyimpan data produk
 	var product models.Product
 
 	// Cari produk berdasarkan ID
-	if err := initialize.DB.Preload("Category").Preload("File").Where("product_id = ?", productId).First(&product).Error; err != nil {
+	if err := initialize.DB.Preload("Category").Preload("File").Preload("Videos").Preload("Tags").Preload("Attributes.Attribute").Scopes(visibleScope(c, "products")).Where("product_id = ?", productId).First(&product).Error; err != nil {
 		// Jika produk tidak ditemukan, kirim respons not found
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusNotFound,
-				Status:  "Not Found",
-				Message: "Product not found",
-			}
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgProductNotFound)
 			return c.Status(fiber.StatusNotFound).JSON(response)
 		}
 		// Jika terjadi kesalahan lain saat mengambil produk, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to fetch product",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductFetchFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
+	// Teks mengikuti bahasa permintaan
+	products := []models.Product{product}
+	if !editingRequest(c) {
+		translateProducts(c, products)
+	}
+	product = products[0]
+
 	// Membuat respons untuk produk yang ditemukan
 	productResponse := models.ProductResponse{
 		ProductId:     product.ProductId,
 		Title:         product.Title,
+		Slug:          product.Slug,
 		Specification: product.Specification,
 		Description:   product.Description,
 		CreatedAt:     product.CreatedAt,
@@ -248,6 +322,13 @@ func GetProductById(c *fiber.Ctx) error {
 		CategoryId:    product.CategoryId,
 		PathFile:      product.File.Path,
 		Category:      product.Category.Category,
+		Breadcrumb:    categoryBreadcrumb(c, product.CategoryId),
+		Videos:        product.Videos,
+		Tags:          product.Tags,
+		Attributes:    productAttributeMap(product.Attributes),
+		Status:        product.Status,
+		PublishAt:     product.PublishAt,
+		PublishedAt:   product.PublishedAt,
 	}
 
 	// Mengirimkan respons sukses dengan data produk yang ditemukan
@@ -266,103 +347,101 @@ func CreateProduct(c *fiber.Ctx) error {
 	specification := c.FormValue("specification")
 	categoryId, err := strconv.ParseInt(c.FormValue("category_id"), 10, 64)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid category ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidCategoryID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	// Simpan file yang diunggah ke folder public
-	file, err := c.FormFile("file")
+	// Slug dibuat dari judul jika tidak diisi
+	slug, err := resolveSlug(models.SlugEntityProduct, c.FormValue("slug"), title, 0)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "File is required",
-		}
+		return slugErrorResponse(c, err)
+	}
+
+	// Tag langsung pada produk, terpisah dari tag kategorinya
+	tags, _, err := tagsFromForm(c)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	// Generate nama unik untuk file yang diunggah
-	filename := uuid.New().String() + filepath.Ext(file.Filename)
+	// Produk baru berstatus draft kecuali status dikirim
+	status, publishAt, _, err := publishFromForm(c, models.StatusDraft, nil)
+	if err != nil {
+		return publishErrorResponse(c, err)
+	}
 
-	// Simpan file ke direktori publik
-	if err := c.SaveFile(file, fmt.Sprintf("./public/%s", filename)); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save file",
-		}
+	// Nilai atribut spesifikasi sesuai skema kategori
+	attributes, _, attributeErrors, err := attributesFromForm(c, categoryId, true)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductAttributesSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	if attributeErrors != nil {
+		response := helpers.NewResponseError(c, attributeErrors)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
 
-	// Buat entitas File untuk disimpan dalam database
-	fileModel := models.File{
-		Path:      fmt.Sprintf("/public/%s", filename),
-		File_name: filename,
-		Size:      strconv.FormatInt(file.Size, 10),
-		Format:    filepath.Ext(file.Filename),
+	// Simpan file yang diunggah ke folder public
+	file, err := c.FormFile("file")
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgFileRequired)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	// Simpan file ke dalam database
-	if err := initialize.DB.Create(&fileModel).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save file data",
-		}
+	// Simpan file ke direktori publik beserta metadatanya
+	fileModel, err := saveUpload(c, file)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Buat entitas Product
 	product := models.Product{
 		Title:         title,
+		Slug:          slug,
 		Specification: specification,
 		Description:   description,
 		CategoryId:    categoryId,
 		FileId:        fileModel.FileId,
+		Tags:          tags,
+		Status:        status,
+		PublishAt:     publishAt,
+		PublishedAt:   publishedAtFor(status, publishAt, nil),
 	}
 
 	// Simpan produk ke dalam database
 	if err := initialize.DB.Create(&product).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save product",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
-	// Kirim respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "Product saved successfully",
+	if err := saveProductAttributes(initialize.DB, product.ProductId, attributes); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductAttributesSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+
+	// Setiap penyimpanan produk dicatat sebagai revisi
+	if _, err := recordProductRevision(c, product.ProductId, "Created"); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductRevisionSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Kirim respons sukses
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgProductSaved)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 func UpdateProduct(c *fiber.Ctx) error {
 	productID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil || productID <= 0 {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid or missing product ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidProductID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Periksa apakah riwayat dengan productID tersebut ada di database
 	var product models.Product
 	if err := initialize.DB.First(&product, productID).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Product Not Found",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgProductNotFound)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
@@ -373,124 +452,149 @@ func UpdateProduct(c *fiber.Ctx) error {
 	categoryIdStr := c.FormValue("category_id")
 	categoryId, err := strconv.ParseInt(categoryIdStr, 10, 64)
 	if err != nil || categoryId <= 0 {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid or missing Product Id",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidCategoryID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Validasi apakah title atau description kosong
 	if title == "" || description == "" || specification == "" {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Title and description are required",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgTitleDescriptionRequired)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	// Periksa apakah product dengan productId tersebut ada di database
-
-	// Cek apakah ada file baru yang diunggah
-	file, err := c.FormFile("file")
+	// Video dari pustaka hanya diganti jika video_ids[] dikirim
+	videos, videosPresent, err := videosFromForm(c)
 	if err != nil {
-	} else {
-		// Jika ada file baru, simpan file baru dan hapus file lama
-		// Generate nama unik untuk file yang diunggah
-		filename := uuid.New().String() + filepath.Ext(file.Filename)
-		// eapatkan data file id  dari baris tesb
-		// Simpan file ke direktori publik
-		if err := c.SaveFile(file, fmt.Sprintf("./public/%s", filename)); err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to save file",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
 
-		// Hapus file lama dari direktori publik
-		if product.FileId != 0 {
-			// Memuat data file terkait
-			if err := initialize.DB.Model(&product).Association("File").Find(&product.File); err != nil {
-				// Handle error jika gagal memuat data file
-				response := helpers.ResponseMassage{
-					Code:    fiber.StatusInternalServerError,
-					Status:  "Internal Server Error",
-					Message: "Gagal Memuat Data File",
-				}
-				return c.Status(fiber.StatusInternalServerError).JSON(response)
-			}
+	// Tag hanya diganti jika tag_ids[] dikirim
+	tags, tagsPresent, err := tagsFromForm(c)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
 
-			// Hapus file lama dari sistem file lokal
-			oldFile := product.File
-			if err := os.Remove("./" + oldFile.Path); err != nil {
-				response := helpers.ResponseMassage{
-					Code:    fiber.StatusInternalServerError,
-					Status:  "Internal Server Error",
-					Message: "Gagal Menghapus Data Local",
-				}
-				return c.Status(fiber.StatusInternalServerError).JSON(response)
-			}
+	// Status dan jadwal hanya berubah jika status atau publish_at dikirim
+	status, publishAt, publishPresent, err := publishFromForm(c, product.Status, product.PublishAt)
+	if err != nil {
+		return publishErrorResponse(c, err)
+	}
 
-			// Hapus entitas file lama dari basis data
-			if err := initialize.DB.Delete(&oldFile).Error; err != nil {
-				response := helpers.ResponseMassage{
-					Code:    fiber.StatusInternalServerError,
-					Status:  "Internal Server Error",
-					Message: "Gagal Menghapus Data File",
-				}
-				return c.Status(fiber.StatusInternalServerError).JSON(response)
-			}
+	// Slug hanya berubah jika diisi, judul baru tidak mengubah tautan yang sudah ada
+	oldSlug := product.Slug
+	slug := product.Slug
+	if requested := c.FormValue("slug"); requested != "" || slug == "" {
+		slug, err = resolveSlug(models.SlugEntityProduct, requested, title, productID)
+		if err != nil {
+			return slugErrorResponse(c, err)
 		}
+	}
 
-		// Buat entitas File baru untuk disimpan dalam database
-		newFile := models.File{
-			Path:      fmt.Sprintf("/public/%s", filename),
-			File_name: filename,
-			Size:      strconv.FormatInt(file.Size, 10),
-			Format:    filepath.Ext(file.Filename),
-		}
+	// Nilai atribut hanya diganti jika field attributes dikirim. Saat kategori berubah tanpa field attributes,
+	// nilai tersimpan dipindahkan ke skema kategori baru dan atribut wajibnya tetap diperiksa
+	attributes, attributesPresent, attributeErrors, err := attributesFromForm(c, categoryId, false)
+	if err == nil && !attributesPresent && categoryId != product.CategoryId {
+		attributes, attributeErrors, err = movedAttributes(helpers.Locale(c), productID, categoryId)
+		attributesPresent = true
+	}
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductAttributesSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if attributeErrors != nil {
+		response := helpers.NewResponseError(c, attributeErrors)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
 
-		// Simpan file baru ke dalam database
-		if err := initialize.DB.Create(&newFile).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to save new file data",
-			}
+	// Cek apakah ada file baru yang diunggah
+	var previousFileId int64
+	file, err := c.FormFile("file")
+	if err != nil {
+	} else {
+		// Jika ada file baru, simpan file baru dan hapus file lama
+		newFile, err := saveUpload(c, file)
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileSaveFailed)
 			return c.Status(fiber.StatusInternalServerError).JSON(response)
 		}
 
+		// File lama baru dilepas setelah produk tersimpan, karena bisa masih direferensikan revisi
+		previousFileId = product.FileId
+
 		// Ganti file lama dengan file baru
 		product.File = newFile
+		product.FileId = newFile.FileId
 	}
 
 	// Update data riwayat dengan data baru
 	product.Title = title
+	product.Slug = slug
 	product.Specification = specification
 	product.Description = description
 	product.ProductId = productID
 	product.CategoryId = categoryId
+	if publishPresent {
+		product.PublishedAt = nextPublishedAt(product.Status, product.PublishedAt, status, publishAt, publishAtChanged(product.PublishAt, publishAt))
+		product.Status = status
+		product.PublishAt = publishAt
+	}
 
 	// Simpan perubahan ke dalam database
 	if err := initialize.DB.Save(&product).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to update history",
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductUpdateFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	if err := recordSlugChange(initialize.DB, models.SlugEntityProduct, product.ProductId, oldSlug, slug); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgSlugRedirectSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	if previousFileId != 0 && previousFileId != product.FileId {
+		if err := deleteFileIfUnused(previousFileId); err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileDeleteFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
 		}
+	}
+
+	if attributesPresent {
+		err = saveProductAttributes(initialize.DB, product.ProductId, attributes)
+	} else {
+		// Buang nilai yang atributnya tidak lagi ada di skema kategori produk
+		err = initialize.DB.Where("product_id = ? AND attribute_id NOT IN (?)", product.ProductId,
+			initialize.DB.Model(&models.CategoryAttribute{}).Select("attribute_id").Where("category_id = ?", categoryId)).
+			Delete(&models.ProductAttributeValue{}).Error
+	}
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductAttributesSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
-	// Kirim respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "History updated successfully",
+	if videosPresent {
+		if err := initialize.DB.Model(&product).Association("Videos").Replace(videos); err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductVideosUpdateFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
 	}
+
+	if tagsPresent {
+		if err := initialize.DB.Model(&product).Association("Tags").Replace(tags); err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductTagsUpdateFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		queueSearchChange(searchTypeProduct, product.ProductId)
+	}
+
+	// Setiap penyimpanan produk dicatat sebagai revisi
+	if _, err := recordProductRevision(c, product.ProductId, "Updated"); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductRevisionSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Kirim respons sukses
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgProductUpdated)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
@@ -498,60 +602,30 @@ func DeleteProduct(c *fiber.Ctx) error {
 	// Ambil ID produk dari parameter URL
 	productId, err := strconv.ParseInt(c.Query("id"), 10, 64)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid product ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidProductID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Ambil data produk yang akan dihapus dari database
 	var product models.Product
 	if err := initialize.DB.Preload("File").First(&product, productId).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusNotFound,
-			Status:  "Not Found",
-			Message: "Product not found",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgProductNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
 	// Hapus produk dari database
 	if err := initialize.DB.Delete(&product).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to delete product",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductDeleteFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
-	// Jika produk memiliki file terkait, hapus file tersebut
-	if err := os.Remove("." + product.File.Path); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Gagal Menghapus Data Dilocal",
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(response)
-	}
-
-	// Hapus entitas file terkait dari basis data
-	if err := initialize.DB.Delete(&product.File).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Kesalahan Server",
-		}
+	// File hanya dihapus jika tidak lagi dipakai data lain, termasuk produk di trash dan revisinya
+	if err := deleteFileIfUnused(product.FileId); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileDeleteFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 	// Kirim respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "Product Berhasil dihapus",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgProductDeleted)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
@@ -562,72 +636,84 @@ func CreateProductT(c *fiber.Ctx) error {
 	specification := c.FormValue("specification")
 	categoryId, err := strconv.ParseInt(c.FormValue("category_id"), 10, 64)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid category ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidCategoryID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	// Simpan file utama yang diunggah ke folder public
-	file, err := c.FormFile("file")
+	// Slug dibuat dari judul jika tidak diisi
+	slug, err := resolveSlug(models.SlugEntityProduct, c.FormValue("slug"), title, 0)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Main file is required",
-		}
+		return slugErrorResponse(c, err)
+	}
+
+	// Video yang dipilih dari pustaka
+	videos, _, err := videosFromForm(c)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	// Generate nama unik untuk file utama yang diunggah
-	mainFilename := uuid.New().String() + filepath.Ext(file.Filename)
+	// Tag langsung pada produk, terpisah dari tag kategorinya
+	tags, _, err := tagsFromForm(c)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
 
-	// Simpan file utama ke direktori publik
-	if err := c.SaveFile(file, fmt.Sprintf("./public/%s", mainFilename)); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save main file",
-		}
+	// Produk baru berstatus draft kecuali status dikirim
+	status, publishAt, _, err := publishFromForm(c, models.StatusDraft, nil)
+	if err != nil {
+		return publishErrorResponse(c, err)
+	}
+
+	// Nilai atribut spesifikasi sesuai skema kategori
+	attributes, _, attributeErrors, err := attributesFromForm(c, categoryId, true)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductAttributesSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	if attributeErrors != nil {
+		response := helpers.NewResponseError(c, attributeErrors)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
 
-	// Buat entitas File untuk file utama yang akan disimpan dalam database
-	mainFileModel := models.File{
-		Path:      fmt.Sprintf("/public/%s", mainFilename),
-		File_name: mainFilename,
-		Size:      strconv.FormatInt(file.Size, 10),
-		Format:    filepath.Ext(file.Filename),
+	// Simpan file utama yang diunggah ke folder public
+	file, err := c.FormFile("file")
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgFileRequired)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	// Simpan file utama ke dalam database
-	if err := initialize.DB.Create(&mainFileModel).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save main file data",
-		}
+	// Simpan file utama ke direktori publik beserta metadatanya
+	mainFileModel, err := saveUpload(c, file)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Buat entitas Product
 	product := models.Product{
 		Title:         title,
+		Slug:          slug,
 		Specification: specification,
 		Description:   description,
 		CategoryId:    categoryId,
 		FileId:        mainFileModel.FileId,
+		Videos:        videos,
+		Tags:          tags,
+		Status:        status,
+		PublishAt:     publishAt,
+		PublishedAt:   publishedAtFor(status, publishAt, nil),
 	}
 
 	// Simpan produk ke dalam database
 	if err := initialize.DB.Create(&product).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save product",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	if err := saveProductAttributes(initialize.DB, product.ProductId, attributes); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductAttributesSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -639,25 +725,18 @@ func CreateProductT(c *fiber.Ctx) error {
 		files := form.File["gallery[]"]
 		var galleries []models.Gallery
 		for _, file := range files {
-			// Generate nama unik untuk file yang diunggah
-			filename := uuid.New().String() + filepath.Ext(file.Filename)
-
-			// Simpan file ke direktori publik
-			if err := c.SaveFile(file, fmt.Sprintf("./public/%s", filename)); err != nil {
-				response := helpers.ResponseMassage{
-					Code:    fiber.StatusInternalServerError,
-					Status:  "Internal Server Error",
-					Message: "Failed to save gallery files",
-				}
+			// Simpan file ke direktori publik beserta metadatanya
+			galleryFile, err := saveUpload(c, file)
+			if err != nil {
+				response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgGallerySaveFailed)
 				return c.Status(fiber.StatusInternalServerError).JSON(response)
 			}
 
 			// Buat entitas Gallery untuk disimpan dalam database
 			gallery := models.Gallery{
-				Path:         fmt.Sprintf("/public/%s", filename),
+				Path:         galleryFile.Path,
 				Gallery_name: file.Filename,
-				Size:         strconv.FormatInt(file.Size, 10),
-				Format:       filepath.Ext(file.Filename),
+				FileId:       galleryFile.FileId,
 				ProductId:    product.ProductId, // Gunakan ID produk yang baru dibuat
 			}
 
@@ -666,21 +745,19 @@ func CreateProductT(c *fiber.Ctx) error {
 
 		// Simpan galeri ke dalam database
 		if err := initialize.DB.Create(&galleries).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to save gallery data",
-			}
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgGallerySaveFailed)
 			return c.Status(fiber.StatusInternalServerError).JSON(response)
 		}
 	}
 
-	// Kirim respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "Product and gallery files saved successfully",
+	// Setiap penyimpanan produk dicatat sebagai revisi
+	if _, err := recordProductRevision(c, product.ProductId, "Created"); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductRevisionSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+
+	// Kirim respons sukses
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgProductSaved)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
@@ -688,101 +765,24 @@ func DeleteProductT(c *fiber.Ctx) error {
 	// Ambil ID produk dari parameter URL
 	productId, err := strconv.ParseInt(c.Query("id"), 10, 64)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid product ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidProductID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Ambil data produk yang akan dihapus dari database
 	var product models.Product
-	if err := initialize.DB.Preload("Gallery").Preload("File").First(&product, productId).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusNotFound,
-			Status:  "Not Found",
-			Message: "Product not found",
-		}
+	if err := initialize.DB.First(&product, productId).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgProductNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
-	// Ambil data hero yang terkait dengan produk
-	var hero models.Hero
-	if err := initialize.DB.Where("product_id = ?", productId).First(&hero).Error; err == nil {
-		// Jika hero ditemukan, hapus file hero dari disk
-		if err := os.Remove("." + hero.Path); err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to delete hero image",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-		// Hapus data hero dari database
-		if err := initialize.DB.Delete(&hero).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to delete hero data",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-	}
-
-	// Hapus galeri-galeri terkait dengan produk
-	for _, gallery := range product.Gallery {
-		if err := os.Remove("." + gallery.Path); err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to delete gallery",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-		if err := initialize.DB.Delete(&gallery).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to delete gallery",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-	}
-
-	// Hapus file produk dari disk
-	if err := os.Remove("." + product.File.Path); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to delete file",
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(response)
-	}
-	if err := initialize.DB.Delete(&product.File).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to delete file",
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(response)
-	}
-
-	// Hapus produk dari database
+	// Produk dipindahkan ke trash; galeri, hero dan file baru dihapus saat purge
 	if err := initialize.DB.Delete(&product).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to delete product",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductDeleteFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Kirim respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "Product, its galleries, and related hero deleted successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgProductTrashed)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
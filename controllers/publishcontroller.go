@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidPublishAt dikembalikan jika publish_at tidak dapat dibaca
+var ErrInvalidPublishAt = helpers.NewError(helpers.MsgInvalidPublishAt)
+
+// publishResource menjelaskan tabel yang memakai alur kerja publikasi
+type publishResource struct {
+	table      string
+	idColumn   string
+	searchType string
+}
+
+var publishResources = map[string]publishResource{
+	"product": {"products", "product_id", searchTypeProduct},
+	"history": {"histories", "history_id", searchTypeHistory},
+}
+
+// publishAtLayouts adalah format publish_at yang diterima dari form
+var publishAtLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// isAdminRequest bernilai true jika token yang dikirim milik Admin atau SuperAdmin
+func isAdminRequest(c *fiber.Ctx) bool {
+	role, _ := c.Locals("role").(string)
+	return role == "Admin" || role == "SuperAdmin"
+}
+
+// publishedScope membatasi query ke data yang sudah terbit
+func publishedScope(table string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(table+".status = ? AND "+table+".published_at IS NOT NULL", models.StatusPublished)
+	}
+}
+
+// visibleScope menampilkan semua status untuk admin (dapat difilter dengan ?status=),
+// sedangkan pengunjung lain hanya melihat data yang sudah terbit
+func visibleScope(c *fiber.Ctx, table string) func(db *gorm.DB) *gorm.DB {
+	if !isAdminRequest(c) {
+		return publishedScope(table)
+	}
+	// Status dibaca sekarang karena scope bisa dijalankan setelah handler selesai, misalnya saat ekspor
+	status := c.Query("status")
+	return func(db *gorm.DB) *gorm.DB {
+		if status != "" {
+			return db.Where(table+".status = ?", status)
+		}
+		return db
+	}
+}
+
+// publishedAtFor menghitung published_at dari status dan jadwal.
+// Jadwal di masa depan membuat published_at kosong sampai worker menerbitkannya.
+func publishedAtFor(status string, publishAt *time.Time, previous *time.Time) *time.Time {
+	if status != models.StatusPublished {
+		return nil
+	}
+	now := time.Now()
+	if publishAt != nil && publishAt.After(now) {
+		return nil
+	}
+	if previous != nil {
+		return previous
+	}
+	if publishAt != nil {
+		publishedAt := *publishAt
+		return &publishedAt
+	}
+	return &now
+}
+
+// nextPublishedAt mempertahankan waktu terbit lama selama data tetap terbit dan jadwalnya tidak diubah
+func nextPublishedAt(currentStatus string, currentPublishedAt *time.Time, status string, publishAt *time.Time, rescheduled bool) *time.Time {
+	previous := currentPublishedAt
+	if currentStatus != models.StatusPublished || rescheduled {
+		previous = nil
+	}
+	return publishedAtFor(status, publishAt, previous)
+}
+
+// publishAtChanged bernilai true jika jadwal publikasi diubah atau dihapus
+func publishAtChanged(current *time.Time, next *time.Time) bool {
+	if current == nil || next == nil {
+		return current != next
+	}
+	return !current.Equal(*next)
+}
+
+// parsePublishAt membaca publish_at dari form, string kosong berarti tanpa jadwal
+func parsePublishAt(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range publishAtLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, ErrInvalidPublishAt
+}
+
+// publishFromForm membaca field status dan publish_at; present bernilai false jika keduanya tidak dikirim
+func publishFromForm(c *fiber.Ctx, status string, publishAt *time.Time) (string, *time.Time, bool, error) {
+	present := false
+	if value := c.FormValue("status"); value != "" {
+		if err := validate.Var(value, "oneof=draft in_review published archived"); err != nil {
+			return status, publishAt, true, helpers.NewError(helpers.MsgInvalidStatus)
+		}
+		status = value
+		present = true
+	}
+	if form, err := c.MultipartForm(); err == nil {
+		if values, ok := form.Value["publish_at"]; ok {
+			parsed, err := parsePublishAt(values[0])
+			if err != nil {
+				return status, publishAt, true, err
+			}
+			publishAt = parsed
+			present = true
+		}
+	} else if value := c.FormValue("publish_at"); value != "" {
+		parsed, err := parsePublishAt(value)
+		if err != nil {
+			return status, publishAt, true, err
+		}
+		publishAt = parsed
+		present = true
+	}
+	return status, publishAt, present, nil
+}
+
+// publishErrorResponse mengubah kesalahan publishFromForm menjadi respons validasi
+func publishErrorResponse(c *fiber.Ctx, err error) error {
+	field := "status"
+	if errors.Is(err, ErrInvalidPublishAt) {
+		field = "publish_at"
+	}
+	response := helpers.NewResponseError(c, map[string][]string{field: {helpers.ErrorMessage(c, err)}})
+	return c.Status(fiber.StatusBadRequest).JSON(response)
+}
+
+// publishScheduled menerbitkan data berstatus published yang jadwalnya sudah lewat
+func publishScheduled() {
+	now := time.Now()
+	for name, resource := range publishResources {
+		var ids []int64
+		if err := initialize.DB.Table(resource.table).
+			Where("status = ? AND published_at IS NULL AND publish_at IS NOT NULL AND publish_at <= ? AND deleted_at IS NULL", models.StatusPublished, now).
+			Pluck(resource.idColumn, &ids).Error; err != nil {
+			log.Printf("publish %s: %v", name, err)
+			continue
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		if err := initialize.DB.Table(resource.table).Where(resource.idColumn+" IN ?", ids).
+			Update("published_at", gorm.Expr("publish_at")).Error; err != nil {
+			log.Printf("publish %s: %v", name, err)
+			continue
+		}
+		// Update lewat Table tidak melewati callback pencarian
+		for _, id := range ids {
+			queueSearchChange(resource.searchType, id)
+		}
+		log.Printf("publish %s: published %d scheduled items", name, len(ids))
+	}
+}
+
+// StartPublishScheduler menjalankan publikasi terjadwal saat aplikasi mulai lalu setiap menit
+func StartPublishScheduler() {
+	go func() {
+		publishScheduled()
+		for range time.Tick(time.Minute) {
+			publishScheduled()
+		}
+	}()
+}
+
+// UpdatePublishStatus mengubah status alur kerja dan jadwal publikasi resource
+func UpdatePublishStatus(resourceName string) fiber.Handler {
+	resource := publishResources[resourceName]
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidID)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+
+		var reqBody models.PublishRequest
+		if err := c.BodyParser(&reqBody); err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		if err := validate.Struct(&reqBody); err != nil {
+			response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+
+		var current struct {
+			Status      string
+			PublishedAt *time.Time
+		}
+		result := initialize.DB.Table(resource.table).Select("status, published_at").
+			Where(resource.idColumn+" = ? AND deleted_at IS NULL", id).Limit(1).Scan(&current)
+		if result.Error != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgStatusUpdateFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		if result.RowsAffected == 0 {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgDataNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+
+		publishedAt := nextPublishedAt(current.Status, current.PublishedAt, reqBody.Status, reqBody.PublishAt, reqBody.PublishAt != nil)
+		if err := initialize.DB.Table(resource.table).Where(resource.idColumn+" = ?", id).Updates(map[string]interface{}{
+			"status":       reqBody.Status,
+			"publish_at":   reqBody.PublishAt,
+			"published_at": publishedAt,
+			"updated_at":   time.Now(),
+		}).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgStatusUpdateFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		queueSearchChange(resource.searchType, id)
+
+		response := helpers.GeneralResponse{
+			Code:   fiber.StatusOK,
+			Status: "OK",
+			Data: fiber.Map{
+				"status":       reqBody.Status,
+				"publish_at":   reqBody.PublishAt,
+				"published_at": publishedAt,
+			},
+		}
+		return c.JSON(response)
+	}
+}
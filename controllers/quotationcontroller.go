@@ -0,0 +1,593 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultTaxRate adalah tarif PPN dalam persen untuk penawaran tanpa tax_rate, dapat diganti lewat PPN_RATE
+func defaultTaxRate() float64 {
+	if rate, err := strconv.ParseFloat(os.Getenv("PPN_RATE"), 64); err == nil && rate >= 0 {
+		return rate
+	}
+	return 11
+}
+
+// requestAuthor mengembalikan ID pengguna yang sedang login, nil jika request tidak membawa token
+func requestAuthor(c *fiber.Ctx) *int64 {
+	if userID, ok := c.Locals("userID").(float64); ok {
+		id := int64(userID)
+		return &id
+	}
+	return nil
+}
+
+// quotationLines menyusun baris penawaran. Deskripsi kosong memakai nama produk dalam bahasa permintaan,
+// harga kosong diambil dari daftar harga yang berlaku untuk tier pelanggan.
+func quotationLines(c *fiber.Ctx, requests []models.QuotationLineRequest, tier string, currency string) ([]models.QuotationLine, string, error) {
+	productIds := make([]int64, 0, len(requests))
+	var unpriced []int64
+	for _, request := range requests {
+		productIds = append(productIds, request.ProductId)
+		if request.UnitPrice == nil {
+			unpriced = append(unpriced, request.ProductId)
+		}
+	}
+	if err := ensureProductsExist(productIds); err != nil {
+		return nil, "", err
+	}
+
+	var products []models.Product
+	if err := initialize.DB.Where("product_id IN ?", productIds).Find(&products).Error; err != nil {
+		return nil, "", err
+	}
+	translateProducts(c, products)
+	titles := make(map[int64]string, len(products))
+	for _, product := range products {
+		titles[product.ProductId] = product.Title
+	}
+
+	prices, err := resolvePrices(initialize.DB, unpriced, tier, time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+	return priceQuotationLines(requests, titles, prices, currency)
+}
+
+// priceQuotationLines mengisi harga setiap baris dalam satu mata uang. Mata uang kosong mengikuti daftar harga
+// baris pertama yang harganya diambil dari daftar harga, atau IDR; harga dari daftar bermata uang lain ditolak.
+func priceQuotationLines(requests []models.QuotationLineRequest, titles map[int64]string, prices map[int64]models.ResolvedPrice, currency string) ([]models.QuotationLine, string, error) {
+	currency = strings.ToUpper(currency)
+	if currency == "" {
+		for _, request := range requests {
+			if price, ok := prices[request.ProductId]; ok && request.UnitPrice == nil {
+				currency = price.Currency
+				break
+			}
+		}
+	}
+	if currency == "" {
+		currency = "IDR"
+	}
+
+	lines := make([]models.QuotationLine, 0, len(requests))
+	for i, request := range requests {
+		line := models.QuotationLine{
+			Position:        i + 1,
+			ProductId:       request.ProductId,
+			Description:     request.Description,
+			Quantity:        request.Quantity,
+			DiscountPercent: request.DiscountPercent,
+		}
+		if line.Description == "" {
+			line.Description = titles[request.ProductId]
+		}
+		if request.UnitPrice != nil {
+			line.UnitPrice = helpers.RoundMoney(*request.UnitPrice)
+		} else {
+			price, ok := prices[request.ProductId]
+			if !ok {
+				return nil, "", helpers.NewError(helpers.MsgPriceNotFound, request.ProductId)
+			}
+			if price.Currency != currency {
+				return nil, "", helpers.NewError(helpers.MsgQuotationCurrencyMismatch, request.ProductId, price.Currency, currency)
+			}
+			line.UnitPrice = price.Price
+		}
+		lines = append(lines, line)
+	}
+	return lines, currency, nil
+}
+
+// calculateQuotation menghitung ulang nilai baris dan total penawaran.
+// Diskon baris dihitung dari persentase, diskon penawaran mengurangi subtotal menjadi DPP, lalu PPN dihitung dari DPP.
+func calculateQuotation(quotation *models.Quotation) error {
+	subtotal := 0.0
+	for i := range quotation.Lines {
+		line := &quotation.Lines[i]
+		gross := helpers.RoundMoney(float64(line.Quantity) * line.UnitPrice)
+		line.DiscountAmount = helpers.RoundMoney(gross * line.DiscountPercent / 100)
+		line.Total = helpers.RoundMoney(gross - line.DiscountAmount)
+		subtotal += line.Total
+	}
+	quotation.Subtotal = helpers.RoundMoney(subtotal)
+	quotation.Discount = helpers.RoundMoney(quotation.Discount)
+	if quotation.Discount > quotation.Subtotal {
+		return helpers.NewError(helpers.MsgQuotationDiscountTooLarge)
+	}
+	quotation.TaxBase = helpers.RoundMoney(quotation.Subtotal - quotation.Discount)
+	quotation.Tax = helpers.RoundMoney(quotation.TaxBase * quotation.TaxRate / 100)
+	quotation.Total = helpers.RoundMoney(quotation.TaxBase + quotation.Tax)
+	return nil
+}
+
+// quotationFromRequest mengisi penawaran dari request dan menghitung totalnya; nomor, status dan versi tidak diubah
+func quotationFromRequest(c *fiber.Ctx, quotation *models.Quotation, request models.QuotationRequest) error {
+	var customer models.User
+	if err := initialize.DB.First(&customer, request.UserId).Error; err != nil {
+		return helpers.NewError(helpers.MsgUserIDNotFound, request.UserId)
+	}
+	validUntil, err := parseDate(request.ValidUntil)
+	if err != nil {
+		return err
+	}
+	lines, currency, err := quotationLines(c, request.Lines, customer.PriceTier, request.Currency)
+	if err != nil {
+		return err
+	}
+
+	quotation.UserId = customer.UserId
+	quotation.Customer = customer.FullName
+	quotation.Title = request.Title
+	quotation.Notes = request.Notes
+	quotation.Currency = currency
+	quotation.IssuedAt = time.Now()
+	quotation.ValidUntil = validUntil
+	quotation.Discount = request.Discount
+	quotation.TaxRate = defaultTaxRate()
+	if request.TaxRate != nil {
+		quotation.TaxRate = *request.TaxRate
+	}
+	quotation.Lines = lines
+	return calculateQuotation(quotation)
+}
+
+// nextQuotationNumber mengambil nomor penawaran berikutnya, misalnya QUO-2024-0001.
+// Baris urutan tahun berjalan dikunci sehingga dua penawaran tidak mendapat nomor yang sama.
+func nextQuotationNumber(tx *gorm.DB, at time.Time) (string, error) {
+	sequence := models.QuotationSequence{Year: at.Year()}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&sequence).Error; err != nil {
+		return "", err
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sequence, "year = ?", sequence.Year).Error; err != nil {
+		return "", err
+	}
+	sequence.Last++
+	if err := tx.Model(&sequence).Update("last", sequence.Last).Error; err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("QUO-%d-%04d", sequence.Year, sequence.Last), nil
+}
+
+// saveQuotationVersion menyimpan salinan penawaran sebagai versi yang tidak dapat diubah lagi
+func saveQuotationVersion(tx *gorm.DB, quotation models.Quotation, author *int64) error {
+	return tx.Create(&models.QuotationVersion{
+		QuotationId: quotation.QuotationId,
+		Version:     quotation.Version,
+		Snapshot:    quotation,
+		UserId:      author,
+	}).Error
+}
+
+// findQuotation memuat penawaran beserta pelanggan dan barisnya
+func findQuotation(db *gorm.DB, id string) (models.Quotation, error) {
+	var quotation models.Quotation
+	err := db.Preload("User").Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Where("quotation_id = ?", id).First(&quotation).Error
+	return quotation, err
+}
+
+func quotationNotFound(c *fiber.Ctx, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgQuotationNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+	response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgQuotationFetchFailed)
+	return c.Status(fiber.StatusInternalServerError).JSON(response)
+}
+
+func GetQuotations(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	page, _ := strconv.Atoi(c.Query("page"))
+	if limit <= 0 {
+		limit = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	query := initialize.DB.Model(&models.Quotation{})
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if userId := c.QueryInt("user_id"); userId != 0 {
+		query = query.Where("user_id = ?", userId)
+	}
+	if search := c.Query("search"); search != "" {
+		query = query.Where("number LIKE ? OR title LIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+
+	var totalRecords int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgQuotationFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Daftar penawaran tidak memuat baris produk, isi lengkap diambil per penawaran
+	var quotations []models.Quotation
+	if err := query.Preload("User").Order("quotation_id DESC").Limit(limit).Offset(offset).Find(&quotations).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgQuotationFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.DataTableResponse{
+		CurrentPage: page,
+		From:        offset + 1,
+		LastPage:    int((totalRecords + int64(limit) - 1) / int64(limit)),
+		To:          offset + len(quotations),
+		Total:       int(totalRecords),
+		Data:        make([]interface{}, len(quotations)),
+	}
+	for i, quotation := range quotations {
+		response.Data[i] = quotation
+	}
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   response,
+	})
+}
+
+func GetQuotation(c *fiber.Ctx) error {
+	quotation, err := findQuotation(initialize.DB, c.Params("id"))
+	if err != nil {
+		return quotationNotFound(c, err)
+	}
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   quotation,
+	})
+}
+
+func CreateQuotation(c *fiber.Ctx) error {
+	var request models.QuotationRequest
+	if err := c.BodyParser(&request); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := validate.Struct(&request); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	author := requestAuthor(c)
+	quotation := models.Quotation{Status: models.QuotationStatusDraft, Version: 1, CreatedBy: author}
+	if err := quotationFromRequest(c, &quotation, request); err != nil {
+		return inputErrorResponse(c, err, helpers.MsgQuotationSaveFailed)
+	}
+
+	err := initialize.DB.Transaction(func(tx *gorm.DB) error {
+		number, err := nextQuotationNumber(tx, quotation.IssuedAt)
+		if err != nil {
+			return err
+		}
+		quotation.Number = number
+		if err := tx.Omit("User").Create(&quotation).Error; err != nil {
+			return err
+		}
+		return saveQuotationVersion(tx, quotation, author)
+	})
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgQuotationSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   quotation,
+	})
+}
+
+// UpdateQuotation menyimpan isi baru sebagai versi berikutnya. Penawaran yang sudah dikirim kembali menjadi draft
+// karena isinya berbeda dari yang diterima pelanggan; penawaran yang sudah diputuskan tidak dapat diubah.
+func UpdateQuotation(c *fiber.Ctx) error {
+	var request models.QuotationRequest
+	if err := c.BodyParser(&request); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := validate.Struct(&request); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	quotation, err := findQuotation(initialize.DB, c.Params("id"))
+	if err != nil {
+		return quotationNotFound(c, err)
+	}
+	if quotation.Status != models.QuotationStatusDraft && quotation.Status != models.QuotationStatusSent {
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgQuotationLocked, quotation.Status)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+	if err := quotationFromRequest(c, &quotation, request); err != nil {
+		return inputErrorResponse(c, err, helpers.MsgQuotationSaveFailed)
+	}
+
+	author := requestAuthor(c)
+	err = initialize.DB.Transaction(func(tx *gorm.DB) error {
+		// Baris dikunci dan dibaca ulang agar dua penyimpanan bersamaan tidak mendapat nomor versi yang sama
+		var current models.Quotation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("quotation_id", "status", "version").
+			First(&current, quotation.QuotationId).Error; err != nil {
+			return err
+		}
+		if current.Status != models.QuotationStatusDraft && current.Status != models.QuotationStatusSent {
+			return helpers.NewError(helpers.MsgQuotationLocked, current.Status)
+		}
+		quotation.Status = models.QuotationStatusDraft
+		quotation.Version = current.Version + 1
+
+		if err := tx.Where("quotation_id = ?", quotation.QuotationId).Delete(&models.QuotationLine{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("User", "Lines").Save(&quotation).Error; err != nil {
+			return err
+		}
+		for i := range quotation.Lines {
+			quotation.Lines[i].QuotationId = quotation.QuotationId
+		}
+		if err := tx.Create(&quotation.Lines).Error; err != nil {
+			return err
+		}
+		return saveQuotationVersion(tx, quotation, author)
+	})
+	if err != nil {
+		var appError *helpers.AppError
+		if errors.As(err, &appError) {
+			response := helpers.NewErrorMassage(c, fiber.StatusConflict, err)
+			return c.Status(fiber.StatusConflict).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgQuotationSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   quotation,
+	})
+}
+
+func GetQuotationVersions(c *fiber.Ctx) error {
+	var versions []models.QuotationVersion
+	if err := initialize.DB.Where("quotation_id = ?", c.Params("id")).Order("version DESC").Find(&versions).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgQuotationFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if len(versions) == 0 {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgQuotationNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	// Daftar versi hanya berisi ringkasan, isi lengkap diambil per versi
+	data := make([]fiber.Map, len(versions))
+	for i, version := range versions {
+		data[i] = fiber.Map{
+			"version":    version.Version,
+			"status":     version.Snapshot.Status,
+			"title":      version.Snapshot.Title,
+			"total":      version.Snapshot.Total,
+			"user_id":    version.UserId,
+			"created_at": version.CreatedAt,
+		}
+	}
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   data,
+	})
+}
+
+func GetQuotationVersion(c *fiber.Ctx) error {
+	var version models.QuotationVersion
+	if err := initialize.DB.Where("quotation_id = ? AND version = ?", c.Params("id"), c.Params("version")).First(&version).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgQuotationVersionNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgQuotationFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   version,
+	})
+}
+
+// UpdateQuotationStatus mengubah status penawaran sesuai models.QuotationTransitions
+func UpdateQuotationStatus(c *fiber.Ctx) error {
+	var request models.QuotationStatusRequest
+	if err := c.BodyParser(&request); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := validate.Struct(&request); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	quotation, err := findQuotation(initialize.DB, c.Params("id"))
+	if err != nil {
+		return quotationNotFound(c, err)
+	}
+	allowed := false
+	for _, next := range models.QuotationTransitions[quotation.Status] {
+		if next == request.Status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgQuotationStatusInvalid, quotation.Status, request.Status)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+
+	// Status lama ikut diperiksa agar perubahan bersamaan tidak saling menimpa
+	result := initialize.DB.Model(&models.Quotation{}).Where("quotation_id = ? AND status = ?", quotation.QuotationId, quotation.Status).
+		Updates(map[string]interface{}{"status": request.Status, "updated_at": time.Now()})
+	if result.Error != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgQuotationSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if result.RowsAffected == 0 {
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgQuotationStatusInvalid, quotation.Status, request.Status)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+	quotation.Status = request.Status
+
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   quotation,
+	})
+}
+
+// ConvertQuotation membuat kontrak untuk pelanggan dan produk dari penawaran yang sudah diterima
+func ConvertQuotation(c *fiber.Ctx) error {
+	var request models.QuotationConvertRequest
+	if err := c.BodyParser(&request); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := validate.Struct(&request); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	for _, value := range []string{request.StartDate, request.EndDate} {
+		if _, err := parseDate(value); err != nil {
+			response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+	}
+
+	quotation, err := findQuotation(initialize.DB, c.Params("id"))
+	if err != nil {
+		return quotationNotFound(c, err)
+	}
+	if quotation.Status != models.QuotationStatusAccepted {
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgQuotationNotAccepted)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+
+	// Produk yang sama pada beberapa baris hanya dicatat sekali di kontrak
+	var productIds []int64
+	seen := make(map[int64]bool)
+	for _, line := range quotation.Lines {
+		if !seen[line.ProductId] {
+			seen[line.ProductId] = true
+			productIds = append(productIds, line.ProductId)
+		}
+	}
+	var products []models.Product
+	if err := initialize.DB.Where("product_id IN ?", productIds).Find(&products).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgQuotationSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	contract := models.Contract{
+		Title:       request.Title,
+		Description: helpers.Message(c, helpers.MsgQuotationContractDescription, quotation.Number, quotation.Version),
+		StartDate:   request.StartDate,
+		EndDate:     request.EndDate,
+		UserID:      quotation.UserId,
+		Products:    products,
+	}
+	if contract.Title == "" {
+		contract.Title = quotation.Title
+	}
+
+	err = initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User").Create(&contract).Error; err != nil {
+			return err
+		}
+		// Status accepted ikut diperiksa agar satu penawaran tidak dikonversi dua kali
+		result := tx.Model(&models.Quotation{}).Where("quotation_id = ? AND status = ?", quotation.QuotationId, models.QuotationStatusAccepted).
+			Updates(map[string]interface{}{"status": models.QuotationStatusConverted, "contract_id": contract.ContractId, "updated_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return helpers.NewError(helpers.MsgQuotationNotAccepted)
+		}
+		return nil
+	})
+	if err != nil {
+		var appError *helpers.AppError
+		if errors.As(err, &appError) {
+			response := helpers.NewErrorMassage(c, fiber.StatusConflict, err)
+			return c.Status(fiber.StatusConflict).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgQuotationSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	quotation.Status = models.QuotationStatusConverted
+	quotation.ContractId = &contract.ContractId
+
+	return c.Status(fiber.StatusCreated).JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusCreated,
+		Status: "Created",
+		Data:   quotation,
+	})
+}
+
+// GetQuotationPDF mengirim PDF penawaran versi terbaru, atau versi tertentu lewat ?version=
+func GetQuotationPDF(c *fiber.Ctx) error {
+	quotation, err := findQuotation(initialize.DB, c.Params("id"))
+	if err != nil {
+		return quotationNotFound(c, err)
+	}
+	if version := c.QueryInt("version"); version != 0 && version != quotation.Version {
+		var saved models.QuotationVersion
+		if err := initialize.DB.Where("quotation_id = ? AND version = ?", quotation.QuotationId, version).First(&saved).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgQuotationVersionNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		saved.Snapshot.Customer = quotation.Customer
+		quotation = saved.Snapshot
+	}
+
+	// PDF disimpan di cache yang sama dengan brosur, satu file per versi penawaran
+	locale := helpers.Locale(c)
+	branding := helpers.BrandingFromEnv()
+	key := fmt.Sprintf("quotation-%d-v%d", quotation.QuotationId, quotation.Version)
+	filename := brochureFilename(fmt.Sprintf("%s-v%d", quotation.Number, quotation.Version), key)
+	return sendBrochure(c, key, filename, quotation, func(w io.Writer) error {
+		return helpers.RenderQuotation(w, branding, locale, quotation)
+	})
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/models"
+	"errors"
+	"testing"
+)
+
+func TestCalculateQuotation(t *testing.T) {
+	tests := []struct {
+		name      string
+		quotation models.Quotation
+		wantErr   string
+		want      models.Quotation
+		wantLines []float64
+	}{
+		{
+			name: "line discount, quotation discount and tax",
+			quotation: models.Quotation{
+				Discount: 101.34,
+				TaxRate:  11,
+				Lines: []models.QuotationLine{
+					{Quantity: 3, UnitPrice: 1250000.5, DiscountPercent: 10},
+					{Quantity: 1, UnitPrice: 99.99},
+				},
+			},
+			want:      models.Quotation{Subtotal: 3375101.34, Discount: 101.34, TaxBase: 3375000, TaxRate: 11, Tax: 371250, Total: 3746250},
+			wantLines: []float64{3375001.35, 99.99},
+		},
+		{
+			name: "tax is rounded from the tax base",
+			quotation: models.Quotation{
+				TaxRate: 11,
+				Lines:   []models.QuotationLine{{Quantity: 7, UnitPrice: 33.33, DiscountPercent: 2.5}},
+			},
+			want:      models.Quotation{Subtotal: 227.48, TaxBase: 227.48, TaxRate: 11, Tax: 25.02, Total: 252.5},
+			wantLines: []float64{227.48},
+		},
+		{
+			name: "discount larger than subtotal",
+			quotation: models.Quotation{
+				Discount: 500,
+				Lines:    []models.QuotationLine{{Quantity: 1, UnitPrice: 100}},
+			},
+			wantErr: helpers.MsgQuotationDiscountTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotation := tt.quotation
+			err := calculateQuotation(&quotation)
+			if tt.wantErr != "" {
+				var appErr *helpers.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantErr {
+					t.Fatalf("err = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, total := range tt.wantLines {
+				if quotation.Lines[i].Total != total {
+					t.Errorf("line %d total = %v, want %v", i, quotation.Lines[i].Total, total)
+				}
+			}
+			got := [6]float64{quotation.Subtotal, quotation.Discount, quotation.TaxBase, quotation.TaxRate, quotation.Tax, quotation.Total}
+			want := [6]float64{tt.want.Subtotal, tt.want.Discount, tt.want.TaxBase, tt.want.TaxRate, tt.want.Tax, tt.want.Total}
+			if got != want {
+				t.Errorf("subtotal, discount, tax base, tax rate, tax, total = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPriceQuotationLines(t *testing.T) {
+	manual := 250000.0
+	prices := map[int64]models.ResolvedPrice{
+		1: {ProductId: 1, Currency: "IDR", Price: 1500000},
+		2: {ProductId: 2, Currency: "IDR", Price: 900000},
+		3: {ProductId: 3, Currency: "USD", Price: 95},
+	}
+	tests := []struct {
+		name         string
+		lines        []models.QuotationLineRequest
+		currency     string
+		wantCurrency string
+		wantPrices   []float64
+		wantErr      string
+	}{
+		{"price lists in one currency", []models.QuotationLineRequest{{ProductId: 1, Quantity: 1}, {ProductId: 2, Quantity: 2}}, "", "IDR", []float64{1500000, 900000}, ""},
+		{"manual price follows the price list currency", []models.QuotationLineRequest{{ProductId: 4, Quantity: 1, UnitPrice: &manual}, {ProductId: 3, Quantity: 1}}, "", "USD", []float64{250000, 95}, ""},
+		{"manual prices only default to IDR", []models.QuotationLineRequest{{ProductId: 4, Quantity: 1, UnitPrice: &manual}}, "", "IDR", []float64{250000}, ""},
+		{"requested currency", []models.QuotationLineRequest{{ProductId: 3, Quantity: 1}}, "usd", "USD", []float64{95}, ""},
+		{"mixed price list currencies", []models.QuotationLineRequest{{ProductId: 1, Quantity: 1}, {ProductId: 3, Quantity: 1}}, "", "", nil, helpers.MsgQuotationCurrencyMismatch},
+		{"price list differs from requested currency", []models.QuotationLineRequest{{ProductId: 1, Quantity: 1}}, "USD", "", nil, helpers.MsgQuotationCurrencyMismatch},
+		{"no price", []models.QuotationLineRequest{{ProductId: 9, Quantity: 1}}, "", "", nil, helpers.MsgPriceNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, currency, err := priceQuotationLines(tt.lines, map[int64]string{1: "Videotron P10"}, prices, tt.currency)
+			if tt.wantErr != "" {
+				var appErr *helpers.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantErr {
+					t.Fatalf("err = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if currency != tt.wantCurrency {
+				t.Errorf("currency = %s, want %s", currency, tt.wantCurrency)
+			}
+			for i, price := range tt.wantPrices {
+				if lines[i].UnitPrice != price || lines[i].Position != i+1 {
+					t.Errorf("line %d = %+v, want unit price %v", i, lines[i], price)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,400 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// recommendationLimit adalah jumlah produk terkait yang disimpan untuk setiap produk
+const recommendationLimit = 10
+
+// recommendationWeights adalah bobot skor untuk setiap alasan keterkaitan
+var recommendationWeights = map[string]float64{
+	models.RecommendationReasonCategory: 3,
+	models.RecommendationReasonTags:     2,
+	models.RecommendationReasonContract: 1.5,
+	models.RecommendationReasonHistory:  1,
+}
+
+// recommendationReasons menentukan urutan alasan pada respons
+var recommendationReasons = []string{
+	models.RecommendationReasonCategory,
+	models.RecommendationReasonTags,
+	models.RecommendationReasonContract,
+	models.RecommendationReasonHistory,
+}
+
+var (
+	// recommendationJob mencegah job terjadwal dan rebuild manual berjalan bersamaan
+	recommendationJob sync.Mutex
+	// recommendationCache menyimpan hasil job terakhir di memori
+	recommendationCache struct {
+		mu     sync.RWMutex
+		loaded bool
+		items  map[int64][]models.ProductRecommendation
+	}
+)
+
+type recommendationScore struct {
+	score   float64
+	reasons map[string]bool
+}
+
+// recommendationGroupRow adalah satu produk dalam satu kelompok, misalnya satu tag atau satu kontrak
+type recommendationGroupRow struct {
+	GroupId   int64
+	ProductId int64
+}
+
+// recommendationGroupLimit adalah ukuran kelompok terbesar yang semua pasangan produknya dihitung
+const recommendationGroupLimit = 200
+
+// recommendationGroups mengelompokkan produk terbit tanpa duplikat dalam satu kelompok
+func recommendationGroups(rows []recommendationGroupRow, published map[int64]bool) map[int64][]int64 {
+	seen := make(map[[2]int64]bool)
+	groups := make(map[int64][]int64)
+	for _, row := range rows {
+		key := [2]int64{row.GroupId, row.ProductId}
+		if !published[row.ProductId] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		groups[row.GroupId] = append(groups[row.GroupId], row.ProductId)
+	}
+	return groups
+}
+
+// recommendationScores menyimpan skor kandidat per produk, recommendationScores[produk][kandidat]
+type recommendationScores map[int64]map[int64]*recommendationScore
+
+func (scores recommendationScores) add(productId int64, recommendedId int64, reason string) {
+	candidates := scores[productId]
+	if candidates == nil {
+		candidates = make(map[int64]*recommendationScore)
+		scores[productId] = candidates
+	}
+	entry := candidates[recommendedId]
+	if entry == nil {
+		entry = &recommendationScore{reasons: make(map[string]bool)}
+		candidates[recommendedId] = entry
+	}
+	entry.score += recommendationWeights[reason]
+	entry.reasons[reason] = true
+}
+
+// recommendationWideGroup adalah kelompok yang terlalu besar untuk dihitung semua pasangannya
+type recommendationWideGroup struct {
+	reason  string
+	groupId int64
+	ids     []int64
+	members map[int64]bool
+}
+
+// addRecommendationGroups menambah skor setiap pasangan produk dalam kelompok yang sama.
+// Kelompok yang lebih besar dari recommendationGroupLimit dilewati dan dikembalikan untuk addWideRecommendationGroups
+func addRecommendationGroups(scores recommendationScores, groups map[int64][]int64, reason string) []recommendationWideGroup {
+	var wide []recommendationWideGroup
+	for groupId, ids := range groups {
+		if len(ids) > recommendationGroupLimit {
+			sorted := append([]int64(nil), ids...)
+			sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+			members := make(map[int64]bool, len(sorted))
+			for _, id := range sorted {
+				members[id] = true
+			}
+			wide = append(wide, recommendationWideGroup{reason: reason, groupId: groupId, ids: sorted, members: members})
+			continue
+		}
+		for _, productId := range ids {
+			for _, recommendedId := range ids {
+				if productId != recommendedId {
+					scores.add(productId, recommendedId, reason)
+				}
+			}
+		}
+	}
+	return wide
+}
+
+// addWideRecommendationGroups menilai kelompok besar tanpa membentuk semua pasangannya.
+// Kandidat yang sudah ada mendapat bobot setiap kelompok besar yang sama, lalu dari setiap kelompok
+// ditambahkan paling banyak recommendationLimit anggota baru dengan id terkecil, sama seperti urutan saat skor seri
+func addWideRecommendationGroups(scores recommendationScores, wide []recommendationWideGroup) {
+	order := make(map[string]int, len(recommendationReasons))
+	for i, reason := range recommendationReasons {
+		order[reason] = i
+	}
+	sort.Slice(wide, func(i, j int) bool {
+		if wide[i].reason != wide[j].reason {
+			return order[wide[i].reason] < order[wide[j].reason]
+		}
+		return wide[i].groupId < wide[j].groupId
+	})
+
+	memberOf := make(map[int64][]int)
+	for i, group := range wide {
+		for _, id := range group.ids {
+			memberOf[id] = append(memberOf[id], i)
+		}
+	}
+	addShared := func(productId int64, recommendedId int64, groups []int) {
+		for _, i := range groups {
+			if wide[i].members[recommendedId] {
+				scores.add(productId, recommendedId, wide[i].reason)
+			}
+		}
+	}
+
+	for productId, groups := range memberOf {
+		for recommendedId := range scores[productId] {
+			addShared(productId, recommendedId, groups)
+		}
+		for _, i := range groups {
+			added := 0
+			for _, recommendedId := range wide[i].ids {
+				if added == recommendationLimit {
+					break
+				}
+				if recommendedId == productId || scores[productId][recommendedId] != nil {
+					continue
+				}
+				addShared(productId, recommendedId, groups)
+				added++
+			}
+		}
+	}
+}
+
+// computeRecommendations menghitung produk terkait dari kategori, tag, kontrak yang sama
+// dan riwayat pemasangan pada pelanggan yang sama
+func computeRecommendations() (map[int64][]models.ProductRecommendation, error) {
+	var products []recommendationGroupRow
+	if err := initialize.DB.Model(&models.Product{}).Scopes(publishedScope("products")).
+		Select("category_id AS group_id, product_id").Scan(&products).Error; err != nil {
+		return nil, err
+	}
+	published := make(map[int64]bool, len(products))
+	for _, product := range products {
+		published[product.ProductId] = true
+	}
+
+	var tags []recommendationGroupRow
+	if err := initialize.DB.Table("ProductTag").
+		Select("tag_tag_id AS group_id, product_product_id AS product_id").Scan(&tags).Error; err != nil {
+		return nil, err
+	}
+	var contracts []recommendationGroupRow
+	if err := initialize.DB.Table("ContractProduct").
+		Select("ContractProduct.contract_contract_id AS group_id, ContractProduct.product_product_id AS product_id").
+		Joins("JOIN contracts ON contracts.contract_id = ContractProduct.contract_contract_id AND contracts.deleted_at IS NULL").
+		Scan(&contracts).Error; err != nil {
+		return nil, err
+	}
+	var histories []recommendationGroupRow
+	if err := initialize.DB.Model(&models.History{}).Where("user_id <> 0").
+		Select("user_id AS group_id, product_id").Scan(&histories).Error; err != nil {
+		return nil, err
+	}
+
+	return rankRecommendations(map[string][]recommendationGroupRow{
+		models.RecommendationReasonCategory: products,
+		models.RecommendationReasonTags:     tags,
+		models.RecommendationReasonContract: contracts,
+		models.RecommendationReasonHistory:  histories,
+	}, published, time.Now()), nil
+}
+
+// rankRecommendations menghitung skor dari setiap alasan lalu menyimpan recommendationLimit kandidat teratas per produk
+func rankRecommendations(rows map[string][]recommendationGroupRow, published map[int64]bool, now time.Time) map[int64][]models.ProductRecommendation {
+	scores := make(recommendationScores)
+	var wide []recommendationWideGroup
+	for _, reason := range recommendationReasons {
+		wide = append(wide, addRecommendationGroups(scores, recommendationGroups(rows[reason], published), reason)...)
+	}
+	addWideRecommendationGroups(scores, wide)
+
+	items := make(map[int64][]models.ProductRecommendation, len(scores))
+	for productId, candidates := range scores {
+		list := make([]models.ProductRecommendation, 0, len(candidates))
+		for recommendedId, entry := range candidates {
+			reasons := make([]string, 0, len(entry.reasons))
+			for _, reason := range recommendationReasons {
+				if entry.reasons[reason] {
+					reasons = append(reasons, reason)
+				}
+			}
+			list = append(list, models.ProductRecommendation{
+				ProductId:     productId,
+				RecommendedId: recommendedId,
+				Score:         entry.score,
+				Reasons:       reasons,
+				CreatedAt:     now,
+			})
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Score != list[j].Score {
+				return list[i].Score > list[j].Score
+			}
+			return list[i].RecommendedId < list[j].RecommendedId
+		})
+		if len(list) > recommendationLimit {
+			list = list[:recommendationLimit]
+		}
+		items[productId] = list
+	}
+	return items
+}
+
+// rebuildRecommendations menghitung ulang seluruh rekomendasi, menyimpannya lalu mengganti cache
+func rebuildRecommendations() (int, error) {
+	recommendationJob.Lock()
+	defer recommendationJob.Unlock()
+
+	items, err := computeRecommendations()
+	if err != nil {
+		return 0, err
+	}
+	var rows []models.ProductRecommendation
+	for _, list := range items {
+		rows = append(rows, list...)
+	}
+	err = initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.ProductRecommendation{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(rows, 500).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	recommendationCache.mu.Lock()
+	recommendationCache.items = items
+	recommendationCache.loaded = true
+	recommendationCache.mu.Unlock()
+	return len(items), nil
+}
+
+// cachedRecommendations mengambil rekomendasi dari cache, atau dari hasil tersimpan jika job belum selesai sejak aplikasi mulai
+func cachedRecommendations(productId int64) ([]models.ProductRecommendation, error) {
+	recommendationCache.mu.RLock()
+	loaded := recommendationCache.loaded
+	items := recommendationCache.items[productId]
+	recommendationCache.mu.RUnlock()
+	if loaded {
+		return items, nil
+	}
+
+	var stored []models.ProductRecommendation
+	err := initialize.DB.Where("product_id = ?", productId).Order("score DESC, recommended_id ASC").Find(&stored).Error
+	return stored, err
+}
+
+// StartRecommendationJob menghitung rekomendasi saat aplikasi mulai lalu setiap jam
+func StartRecommendationJob() {
+	go func() {
+		for {
+			count, err := rebuildRecommendations()
+			if err != nil {
+				log.Printf("recommendation: %v", err)
+			} else {
+				log.Printf("recommendation: computed for %d products", count)
+			}
+			time.Sleep(time.Hour)
+		}
+	}()
+}
+
+// GetProductRecommendations menampilkan produk terkait yang sudah dihitung oleh job rekomendasi
+func GetProductRecommendations(c *fiber.Ctx) error {
+	productId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidID)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	if err := initialize.DB.Scopes(visibleScope(c, "products")).Select("product_id").First(&models.Product{}, productId).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgProductNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	if limit <= 0 || limit > recommendationLimit {
+		limit = 5
+	}
+
+	recommendations, err := cachedRecommendations(productId)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgRecommendationFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	ids := make([]int64, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		ids = append(ids, recommendation.RecommendedId)
+	}
+
+	// Produk yang sudah tidak terbit sejak job terakhir dilewati
+	var found []models.Product
+	if len(ids) > 0 {
+		if err := initialize.DB.Preload("Category").Preload("File").Scopes(publishedScope("products")).
+			Where("product_id IN ?", ids).Find(&found).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgRecommendationFetchFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+	}
+	translateProducts(c, found)
+	byID := make(map[int64]models.Product, len(found))
+	for _, product := range found {
+		byID[product.ProductId] = product
+	}
+
+	data := make([]models.ProductRecommendationResponse, 0, limit)
+	for _, recommendation := range recommendations {
+		product, ok := byID[recommendation.RecommendedId]
+		if !ok {
+			continue
+		}
+		data = append(data, models.ProductRecommendationResponse{
+			ProductId:  product.ProductId,
+			Title:      product.Title,
+			Slug:       product.Slug,
+			CategoryId: product.CategoryId,
+			Category:   product.Category.Category,
+			PathFile:   product.File.Path,
+			Score:      recommendation.Score,
+			Reasons:    recommendation.Reasons,
+		})
+		if len(data) == limit {
+			break
+		}
+	}
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   data,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// RebuildRecommendations menghitung ulang rekomendasi tanpa menunggu jadwal job
+func RebuildRecommendations(c *fiber.Ctx) error {
+	if _, err := rebuildRecommendations(); err != nil {
+		log.Printf("recommendation: %v", err)
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgRecommendationRebuildFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgRecommendationRebuilt)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
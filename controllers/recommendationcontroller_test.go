@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"Matahariled/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRankRecommendations(t *testing.T) {
+	wideCategory := make([]recommendationGroupRow, 0, recommendationGroupLimit+50)
+	published := make(map[int64]bool)
+	for id := int64(1); id <= recommendationGroupLimit+50; id++ {
+		wideCategory = append(wideCategory, recommendationGroupRow{GroupId: 1, ProductId: id})
+		published[id] = true
+	}
+
+	tests := []struct {
+		name      string
+		rows      map[string][]recommendationGroupRow
+		product   int64
+		wantIds   []int64
+		wantFirst models.ProductRecommendation
+	}{
+		{
+			name: "small groups add up",
+			rows: map[string][]recommendationGroupRow{
+				models.RecommendationReasonCategory: {{GroupId: 1, ProductId: 1}, {GroupId: 1, ProductId: 2}, {GroupId: 1, ProductId: 3}},
+				models.RecommendationReasonTags:     {{GroupId: 7, ProductId: 1}, {GroupId: 7, ProductId: 3}},
+			},
+			product: 1,
+			wantIds: []int64{3, 2},
+			wantFirst: models.ProductRecommendation{
+				ProductId: 1, RecommendedId: 3, Score: 5,
+				Reasons: []string{models.RecommendationReasonCategory, models.RecommendationReasonTags},
+			},
+		},
+		{
+			name: "unpublished products are skipped",
+			rows: map[string][]recommendationGroupRow{
+				models.RecommendationReasonTags: {{GroupId: 7, ProductId: 1}, {GroupId: 7, ProductId: 9999}, {GroupId: 7, ProductId: 2}},
+			},
+			product: 1,
+			wantIds: []int64{2},
+			wantFirst: models.ProductRecommendation{
+				ProductId: 1, RecommendedId: 2, Score: 2,
+				Reasons: []string{models.RecommendationReasonTags},
+			},
+		},
+		{
+			name: "wide group keeps shared tag on top",
+			rows: map[string][]recommendationGroupRow{
+				models.RecommendationReasonCategory: wideCategory,
+				models.RecommendationReasonTags:     {{GroupId: 7, ProductId: 100}, {GroupId: 7, ProductId: 200}},
+			},
+			product: 100,
+			wantIds: []int64{200, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			wantFirst: models.ProductRecommendation{
+				ProductId: 100, RecommendedId: 200, Score: 5,
+				Reasons: []string{models.RecommendationReasonCategory, models.RecommendationReasonTags},
+			},
+		},
+	}
+
+	now := time.Now()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := rankRecommendations(tt.rows, published, now)
+			list := items[tt.product]
+			ids := make([]int64, 0, len(list))
+			for _, item := range list {
+				ids = append(ids, item.RecommendedId)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIds) {
+				t.Fatalf("recommended ids = %v, want %v", ids, tt.wantIds)
+			}
+			tt.wantFirst.CreatedAt = now
+			if !reflect.DeepEqual(list[0], tt.wantFirst) {
+				t.Errorf("first = %+v, want %+v", list[0], tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestRankRecommendationsWideGroupIsBounded(t *testing.T) {
+	published := make(map[int64]bool)
+	var rows []recommendationGroupRow
+	for id := int64(1); id <= 5000; id++ {
+		rows = append(rows, recommendationGroupRow{GroupId: 1, ProductId: id})
+		published[id] = true
+	}
+	items := rankRecommendations(map[string][]recommendationGroupRow{models.RecommendationReasonCategory: rows}, published, time.Now())
+	if len(items) != 5000 {
+		t.Fatalf("products with recommendations = %d, want 5000", len(items))
+	}
+	for productId, list := range items {
+		if len(list) != recommendationLimit {
+			t.Fatalf("product %d has %d recommendations, want %d", productId, len(list), recommendationLimit)
+		}
+	}
+}
@@ -0,0 +1,489 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// revisionChange adalah satu perbedaan antara dua revisi
+type revisionChange struct {
+	Field   string             `json:"field"`
+	From    interface{}        `json:"from,omitempty"`
+	To      interface{}        `json:"to,omitempty"`
+	Added   interface{}        `json:"added,omitempty"`
+	Removed interface{}        `json:"removed,omitempty"`
+	Lines   []helpers.DiffLine `json:"lines,omitempty"`
+}
+
+// recordProductRevision menyimpan salinan produk saat ini sebagai revisi baru
+func recordProductRevision(c *fiber.Ctx, productId int64, note string) (models.ProductRevision, error) {
+	// Catat pengubah jika request membawa token
+	var author *int64
+	if userID, ok := c.Locals("userID").(float64); ok {
+		id := int64(userID)
+		author = &id
+	}
+	return saveProductRevision(productId, note, author)
+}
+
+// saveProductRevision menyalin kondisi produk saat ini menjadi revisi baru
+func saveProductRevision(productId int64, note string, author *int64) (models.ProductRevision, error) {
+	var product models.Product
+	if err := initialize.DB.Preload("Category").Preload("File").Preload("Gallery").Preload("Videos").Preload("Tags").
+		Preload("Attributes.Attribute").First(&product, productId).Error; err != nil {
+		return models.ProductRevision{}, err
+	}
+
+	revision := models.ProductRevision{
+		ProductId:     product.ProductId,
+		Title:         product.Title,
+		Slug:          product.Slug,
+		Description:   product.Description,
+		Specification: product.Specification,
+		CategoryId:    product.CategoryId,
+		Category:      product.Category.Category,
+		File:          models.RevisionFile{FileId: product.FileId, Path: product.File.Path},
+		Gallery:       []models.RevisionFile{},
+		VideoIds:      []int64{},
+		TagIds:        []int64{},
+		Attributes:    productAttributeMap(product.Attributes),
+		Note:          note,
+		UserId:        author,
+	}
+	for _, gallery := range product.Gallery {
+		revision.Gallery = append(revision.Gallery, models.RevisionFile{FileId: gallery.FileId, Path: gallery.Path, Name: gallery.Gallery_name})
+	}
+	for _, video := range product.Videos {
+		revision.VideoIds = append(revision.VideoIds, video.VideoId)
+	}
+	for _, tag := range product.Tags {
+		revision.TagIds = append(revision.TagIds, tag.TagId)
+	}
+
+	// Nomor revisi berurutan per produk, baris produk dikunci agar dua penyimpanan tidak mendapat nomor yang sama
+	err := initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("product_id").First(&models.Product{}, productId).Error; err != nil {
+			return err
+		}
+		var last int
+		if err := tx.Model(&models.ProductRevision{}).Where("product_id = ?", productId).Select("COALESCE(MAX(number), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		revision.Number = last + 1
+		return tx.Omit("User").Create(&revision).Error
+	})
+	return revision, err
+}
+
+// findProductRevision mencari revisi berdasarkan nomor revisi pada produk
+func findProductRevision(productId string, number string) (models.ProductRevision, error) {
+	var revision models.ProductRevision
+	err := initialize.DB.Preload("User").Where("product_id = ? AND number = ?", productId, number).First(&revision).Error
+	return revision, err
+}
+
+func revisionNotFound(c *fiber.Ctx, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgRevisionNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+	response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgRevisionFetchFailed)
+	return c.Status(fiber.StatusInternalServerError).JSON(response)
+}
+
+func GetProductRevisions(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	page, _ := strconv.Atoi(c.Query("page"))
+	if limit <= 0 {
+		limit = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	query := initialize.DB.Model(&models.ProductRevision{}).Where("product_id = ?", c.Params("id"))
+	var totalRecords int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgRevisionFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Daftar revisi hanya berisi ringkasan, isi lengkap diambil per revisi
+	var revisions []models.ProductRevision
+	if err := query.Preload("User").Select("revision_id", "product_id", "number", "title", "note", "user_id", "created_at").
+		Order("number DESC").Limit(limit).Offset(offset).Find(&revisions).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgRevisionFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.DataTableResponse{
+		CurrentPage: page,
+		From:        offset + 1,
+		LastPage:    int((totalRecords + int64(limit) - 1) / int64(limit)),
+		To:          offset + len(revisions),
+		Total:       int(totalRecords),
+		Data:        make([]interface{}, len(revisions)),
+	}
+	for i, revision := range revisions {
+		response.Data[i] = map[string]interface{}{
+			"revision_id": revision.RevisionId,
+			"number":      revision.Number,
+			"title":       revision.Title,
+			"note":        revision.Note,
+			"user_id":     revision.UserId,
+			"author":      revision.Author,
+			"created_at":  revision.CreatedAt,
+		}
+	}
+	return c.JSON(helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   response,
+	})
+}
+
+func GetProductRevision(c *fiber.Ctx) error {
+	revision, err := findProductRevision(c.Params("id"), c.Params("number"))
+	if err != nil {
+		return revisionNotFound(c, err)
+	}
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   revision,
+	}
+	return c.JSON(response)
+}
+
+// normalizeRevisionValue menyamakan bentuk nilai atribut lewat JSON agar bisa dibandingkan
+func normalizeRevisionValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	json.Unmarshal(data, &normalized)
+	return normalized
+}
+
+// diffIds mengembalikan ID yang ditambahkan dan dihapus
+func diffIds(from, to []int64) ([]int64, []int64) {
+	inFrom := make(map[int64]bool)
+	inTo := make(map[int64]bool)
+	for _, id := range from {
+		inFrom[id] = true
+	}
+	for _, id := range to {
+		inTo[id] = true
+	}
+	var added, removed []int64
+	for _, id := range to {
+		if !inFrom[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range from {
+		if !inTo[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+// diffProductRevisions membandingkan dua revisi; deskripsi dan spesifikasi dibandingkan per baris
+func diffProductRevisions(from, to models.ProductRevision) []revisionChange {
+	changes := []revisionChange{}
+	for _, field := range []struct {
+		name     string
+		from, to string
+	}{
+		{"title", from.Title, to.Title},
+		{"slug", from.Slug, to.Slug},
+		{"category", from.Category, to.Category},
+	} {
+		if field.from != field.to {
+			changes = append(changes, revisionChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+	for _, field := range []struct {
+		name     string
+		from, to string
+	}{
+		{"description", from.Description, to.Description},
+		{"specification", from.Specification, to.Specification},
+	} {
+		if field.from != field.to {
+			changes = append(changes, revisionChange{Field: field.name, Lines: helpers.DiffLines(field.from, field.to)})
+		}
+	}
+
+	if from.File.FileId != to.File.FileId {
+		changes = append(changes, revisionChange{Field: "file", From: from.File, To: to.File})
+	}
+
+	fromGallery := make(map[int64]models.RevisionFile)
+	toGallery := make(map[int64]models.RevisionFile)
+	var fromGalleryIds, toGalleryIds []int64
+	for _, file := range from.Gallery {
+		fromGallery[file.FileId] = file
+		fromGalleryIds = append(fromGalleryIds, file.FileId)
+	}
+	for _, file := range to.Gallery {
+		toGallery[file.FileId] = file
+		toGalleryIds = append(toGalleryIds, file.FileId)
+	}
+	if added, removed := diffIds(fromGalleryIds, toGalleryIds); len(added) > 0 || len(removed) > 0 {
+		change := revisionChange{Field: "gallery"}
+		var addedFiles, removedFiles []models.RevisionFile
+		for _, id := range added {
+			addedFiles = append(addedFiles, toGallery[id])
+		}
+		for _, id := range removed {
+			removedFiles = append(removedFiles, fromGallery[id])
+		}
+		if len(addedFiles) > 0 {
+			change.Added = addedFiles
+		}
+		if len(removedFiles) > 0 {
+			change.Removed = removedFiles
+		}
+		changes = append(changes, change)
+	}
+
+	for _, field := range []struct {
+		name     string
+		from, to []int64
+	}{
+		{"videos", from.VideoIds, to.VideoIds},
+		{"tags", from.TagIds, to.TagIds},
+	} {
+		if added, removed := diffIds(field.from, field.to); len(added) > 0 || len(removed) > 0 {
+			change := revisionChange{Field: field.name}
+			if len(added) > 0 {
+				change.Added = added
+			}
+			if len(removed) > 0 {
+				change.Removed = removed
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	names := make(map[string]bool)
+	for name := range from.Attributes {
+		names[name] = true
+	}
+	for name := range to.Attributes {
+		names[name] = true
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+	for _, name := range sortedNames {
+		fromValue := normalizeRevisionValue(from.Attributes[name])
+		toValue := normalizeRevisionValue(to.Attributes[name])
+		if !reflect.DeepEqual(fromValue, toValue) {
+			changes = append(changes, revisionChange{Field: "attributes." + name, From: fromValue, To: toValue})
+		}
+	}
+	return changes
+}
+
+func DiffProductRevisions(c *fiber.Ctx) error {
+	if c.Query("from") == "" || c.Query("to") == "" {
+		response := helpers.NewResponseError(c, map[string][]string{"from": {helpers.Message(c, helpers.MsgRevisionRangeRequired)}})
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	from, err := findProductRevision(c.Params("id"), c.Query("from"))
+	if err != nil {
+		return revisionNotFound(c, err)
+	}
+	to, err := findProductRevision(c.Params("id"), c.Query("to"))
+	if err != nil {
+		return revisionNotFound(c, err)
+	}
+
+	summary := func(revision models.ProductRevision) map[string]interface{} {
+		return map[string]interface{}{
+			"number":     revision.Number,
+			"author":     revision.Author,
+			"note":       revision.Note,
+			"created_at": revision.CreatedAt,
+		}
+	}
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"from":    summary(from),
+			"to":      summary(to),
+			"changes": diffProductRevisions(from, to),
+		},
+	}
+	return c.JSON(response)
+}
+
+// recordExists memeriksa apakah data yang direferensikan revisi masih ada
+func recordExists(model interface{}, idColumn string, id int64) bool {
+	var count int64
+	initialize.DB.Model(model).Where(idColumn+" = ?", id).Count(&count)
+	return count > 0
+}
+
+// restoreProductRevision menerapkan isi revisi ke produk. Media yang sudah dihapus dari storage dilewati.
+func restoreProductRevision(product *models.Product, revision models.ProductRevision) error {
+	product.Title = revision.Title
+	product.Description = revision.Description
+	product.Specification = revision.Specification
+
+	if recordExists(&models.Category{}, "category_id", revision.CategoryId) {
+		product.CategoryId = revision.CategoryId
+	}
+	if revision.File.FileId != 0 && recordExists(&models.File{}, "file_id", revision.File.FileId) {
+		product.FileId = revision.File.FileId
+	}
+
+	// Slug lama dipakai kembali hanya jika belum dipakai produk lain
+	oldSlug := product.Slug
+	if revision.Slug != "" && revision.Slug != product.Slug {
+		if slug, err := resolveSlug(models.SlugEntityProduct, revision.Slug, revision.Title, product.ProductId); err == nil {
+			product.Slug = slug
+		}
+	}
+
+	// Semua perubahan diterapkan dalam satu transaksi agar kegagalan tidak meninggalkan produk yang setengah dipulihkan
+	err := initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(product).Error; err != nil {
+			return err
+		}
+		if err := recordSlugChange(tx, models.SlugEntityProduct, product.ProductId, oldSlug, product.Slug); err != nil {
+			return err
+		}
+
+		videos := []models.Video{}
+		if len(revision.VideoIds) > 0 {
+			if err := tx.Where("video_id IN ?", revision.VideoIds).Find(&videos).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(product).Association("Videos").Replace(videos); err != nil {
+			return err
+		}
+		tags := []models.Tag{}
+		if len(revision.TagIds) > 0 {
+			if err := tx.Where("tag_id IN ?", revision.TagIds).Find(&tags).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(product).Association("Tags").Replace(tags); err != nil {
+			return err
+		}
+
+		// Nilai atribut disesuaikan dengan skema kategori saat ini, nilai yang tidak lagi valid dilewati
+		var schema []models.CategoryAttribute
+		if err := tx.Where("category_id = ?", product.CategoryId).Find(&schema).Error; err != nil {
+			return err
+		}
+		var values []models.ProductAttributeValue
+		for _, attr := range schema {
+			raw, ok := revision.Attributes[attr.Name]
+			if !ok || raw == nil {
+				continue
+			}
+			if value, err := attributeValue(attr, normalizeRevisionValue(raw)); err == nil {
+				values = append(values, value)
+			}
+		}
+		if err := saveProductAttributes(tx, product.ProductId, values); err != nil {
+			return err
+		}
+
+		// Galeri dikembalikan sesuai revisi; file yang dilepas tetap disimpan karena masih direferensikan revisi
+		keep := make(map[int64]bool)
+		for _, file := range revision.Gallery {
+			keep[file.FileId] = true
+		}
+		var galleries []models.Gallery
+		if err := tx.Where("product_id = ?", product.ProductId).Find(&galleries).Error; err != nil {
+			return err
+		}
+		present := make(map[int64]bool)
+		for _, gallery := range galleries {
+			if !keep[gallery.FileId] {
+				if err := tx.Delete(&gallery).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			present[gallery.FileId] = true
+		}
+		for _, file := range revision.Gallery {
+			if present[file.FileId] || file.FileId == 0 || !recordExists(&models.File{}, "file_id", file.FileId) {
+				continue
+			}
+			gallery := models.Gallery{
+				Path:         file.Path,
+				Gallery_name: file.Name,
+				FileId:       file.FileId,
+				ProductId:    product.ProductId,
+			}
+			if err := tx.Create(&gallery).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Callback pencarian di dalam transaksi berjalan sebelum commit sehingga produk diantrekan ulang
+	queueSearchChange(searchTypeProduct, product.ProductId)
+	return nil
+}
+
+func RestoreProductRevision(c *fiber.Ctx) error {
+	revision, err := findProductRevision(c.Params("id"), c.Params("number"))
+	if err != nil {
+		return revisionNotFound(c, err)
+	}
+
+	var product models.Product
+	if err := initialize.DB.First(&product, revision.ProductId).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgProductNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	if err := restoreProductRevision(&product, revision); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgRevisionRestoreFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Pemulihan dicatat sebagai revisi baru, revisi lama tidak diubah
+	restored, err := recordProductRevision(c, product.ProductId, fmt.Sprintf("Restored from revision %d", revision.Number))
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductRevisionSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   restored,
+	}
+	return c.JSON(response)
+}
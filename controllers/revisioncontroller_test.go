@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/models"
+	"reflect"
+	"testing"
+)
+
+func TestDiffIds(t *testing.T) {
+	tests := []struct {
+		name           string
+		from, to       []int64
+		added, removed []int64
+	}{
+		{"unchanged, order ignored", []int64{1, 2}, []int64{2, 1}, nil, nil},
+		{"added and removed", []int64{1, 2, 3}, []int64{3, 4, 1}, []int64{4}, []int64{2}},
+		{"from empty", nil, []int64{5}, []int64{5}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := diffIds(tt.from, tt.to)
+			if !reflect.DeepEqual(added, tt.added) || !reflect.DeepEqual(removed, tt.removed) {
+				t.Errorf("diffIds = %v, %v, want %v, %v", added, removed, tt.added, tt.removed)
+			}
+		})
+	}
+}
+
+func TestDiffProductRevisions(t *testing.T) {
+	cover := models.RevisionFile{FileId: 1, Path: "/uploads/a.jpg"}
+	from := models.ProductRevision{
+		Title:       "Videotron P10",
+		Slug:        "videotron-p10",
+		Category:    "Outdoor",
+		Description: "Layar LED\nUntuk luar ruangan",
+		File:        cover,
+		Gallery:     []models.RevisionFile{{FileId: 2, Path: "/uploads/b.jpg"}, {FileId: 3, Path: "/uploads/c.jpg"}},
+		VideoIds:    []int64{7},
+		TagIds:      []int64{1, 2},
+		Attributes:  map[string]interface{}{"pitch": 10, "waterproof": true},
+	}
+
+	tests := []struct {
+		name   string
+		change func(r *models.ProductRevision)
+		want   []revisionChange
+	}{
+		{"identical revisions", func(r *models.ProductRevision) {
+			// Nilai dari JSON tersimpan dan dari memori dianggap sama
+			r.Attributes = map[string]interface{}{"pitch": 10.0, "waterproof": true}
+		}, []revisionChange{}},
+		{"scalar fields", func(r *models.ProductRevision) {
+			r.Title = "Videotron P8"
+			r.Slug = "videotron-p8"
+		}, []revisionChange{
+			{Field: "title", From: "Videotron P10", To: "Videotron P8"},
+			{Field: "slug", From: "videotron-p10", To: "videotron-p8"},
+		}},
+		{"description by line", func(r *models.ProductRevision) {
+			r.Description = "Layar LED\nUntuk dalam ruangan"
+		}, []revisionChange{
+			{Field: "description", Lines: []helpers.DiffLine{
+				{Op: helpers.DiffEqual, Text: "Layar LED"},
+				{Op: helpers.DiffDelete, Text: "Untuk luar ruangan"},
+				{Op: helpers.DiffInsert, Text: "Untuk dalam ruangan"},
+			}},
+		}},
+		{"files and relations", func(r *models.ProductRevision) {
+			r.File = models.RevisionFile{FileId: 9, Path: "/uploads/z.jpg"}
+			r.Gallery = []models.RevisionFile{{FileId: 3, Path: "/uploads/c.jpg"}, {FileId: 4, Path: "/uploads/d.jpg"}}
+			r.VideoIds = nil
+			r.TagIds = []int64{2, 1, 5}
+		}, []revisionChange{
+			{Field: "file", From: cover, To: models.RevisionFile{FileId: 9, Path: "/uploads/z.jpg"}},
+			{Field: "gallery", Added: []models.RevisionFile{{FileId: 4, Path: "/uploads/d.jpg"}}, Removed: []models.RevisionFile{{FileId: 2, Path: "/uploads/b.jpg"}}},
+			{Field: "videos", Removed: []int64{7}},
+			{Field: "tags", Added: []int64{5}},
+		}},
+		{"attributes sorted by name", func(r *models.ProductRevision) {
+			r.Attributes = map[string]interface{}{"waterproof": false, "brightness": map[string]float64{"min": 800, "max": 1200}}
+		}, []revisionChange{
+			{Field: "attributes.brightness", To: map[string]interface{}{"min": 800.0, "max": 1200.0}},
+			{Field: "attributes.pitch", From: 10.0},
+			{Field: "attributes.waterproof", From: true, To: false},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := from
+			tt.change(&to)
+			if got := diffProductRevisions(from, to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changes = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
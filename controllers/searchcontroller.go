@@ -0,0 +1,384 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	searchTypeProduct  = "product"
+	searchTypeHistory  = "history"
+	searchTypeCategory = "category"
+	searchTypeTag      = "tag"
+)
+
+var (
+	searchIndex = helpers.NewSearchIndex()
+
+	// Perubahan data dikirim lewat channel agar indeks diperbarui setelah transaksi selesai
+	searchChanges       = make(chan searchChange, 1024)
+	searchRebuildNeeded atomic.Bool
+)
+
+// searchChange menandai satu baris yang berubah; Id 0 berarti seluruh tipe perlu dibangun ulang
+type searchChange struct {
+	docType string
+	id      int64
+}
+
+// searchTables memetakan tabel database ke tipe dokumen pencarian
+var searchTables = map[string]string{
+	"products":   searchTypeProduct,
+	"histories":  searchTypeHistory,
+	"categories": searchTypeCategory,
+	"tags":       searchTypeTag,
+}
+
+// tagNames menggabungkan nama tag agar ikut diindeks
+func tagNames(tags []models.Tag) string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Tag)
+	}
+	return strings.Join(names, " ")
+}
+
+func productSearchDocument(product models.Product) helpers.SearchDocument {
+	return helpers.SearchDocument{
+		Type:  searchTypeProduct,
+		Id:    product.ProductId,
+		Title: product.Title,
+		Fields: []helpers.SearchField{
+			{Name: "title", Text: product.Title, Boost: 3},
+			{Name: "category", Text: product.Category.Category, Boost: 1.5},
+			{Name: "description", Text: product.Description, Boost: 1},
+			{Name: "specification", Text: product.Specification, Boost: 1},
+		},
+		Meta: map[string]interface{}{
+			"slug":     product.Slug,
+			"category": product.Category.Category,
+		},
+	}
+}
+
+func historySearchDocument(history models.History) helpers.SearchDocument {
+	return helpers.SearchDocument{
+		Type:  searchTypeHistory,
+		Id:    history.HistoryId,
+		Title: history.Title,
+		Fields: []helpers.SearchField{
+			{Name: "title", Text: history.Title, Boost: 3},
+			{Name: "description", Text: history.Description, Boost: 1},
+			{Name: "product", Text: history.Product.Title, Boost: 1},
+		},
+		Meta: map[string]interface{}{
+			"slug":    history.Slug,
+			"product": history.Product.Title,
+		},
+	}
+}
+
+func categorySearchDocument(category models.Category) helpers.SearchDocument {
+	return helpers.SearchDocument{
+		Type:   searchTypeCategory,
+		Id:     category.CategoryId,
+		Title:  category.Category,
+		Fields: []helpers.SearchField{{Name: "name", Text: category.Category, Boost: 2}},
+		Meta:   map[string]interface{}{"slug": category.Slug},
+	}
+}
+
+func tagSearchDocument(tag models.Tag) helpers.SearchDocument {
+	return helpers.SearchDocument{
+		Type:   searchTypeTag,
+		Id:     tag.TagId,
+		Title:  tag.Tag,
+		Fields: []helpers.SearchField{{Name: "name", Text: tag.Tag, Boost: 2}},
+	}
+}
+
+// indexSearchType membangun ulang semua dokumen dengan tipe tertentu dari database
+func indexSearchType(docType string) error {
+	var docs []helpers.SearchDocument
+	switch docType {
+	case searchTypeProduct:
+		var products []models.Product
+		if err := initialize.DB.Preload("Category").Preload("Tags").Scopes(publishedScope("products")).Find(&products).Error; err != nil {
+			return err
+		}
+		for _, product := range products {
+			docs = append(docs, productSearchDocument(product))
+		}
+	case searchTypeHistory:
+		var histories []models.History
+		if err := initialize.DB.Preload("Product").Preload("Tags").Scopes(publishedScope("histories")).Find(&histories).Error; err != nil {
+			return err
+		}
+		for _, history := range histories {
+			docs = append(docs, historySearchDocument(history))
+		}
+	case searchTypeCategory:
+		var categories []models.Category
+		if err := initialize.DB.Find(&categories).Error; err != nil {
+			return err
+		}
+		for _, category := range categories {
+			docs = append(docs, categorySearchDocument(category))
+		}
+	case searchTypeTag:
+		var tags []models.Tag
+		if err := initialize.DB.Find(&tags).Error; err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			docs = append(docs, tagSearchDocument(tag))
+		}
+	}
+
+	searchIndex.RemoveType(docType)
+	for _, doc := range docs {
+		searchIndex.Put(doc)
+	}
+	return nil
+}
+
+// indexSearchDocument memperbarui satu dokumen, atau menghapusnya jika baris sudah tidak ada atau belum terbit
+func indexSearchDocument(docType string, id int64) error {
+	var err error
+	switch docType {
+	case searchTypeProduct:
+		var product models.Product
+		if err = initialize.DB.Preload("Category").Preload("Tags").Scopes(publishedScope("products")).First(&product, id).Error; err == nil {
+			searchIndex.Put(productSearchDocument(product))
+		}
+	case searchTypeHistory:
+		var history models.History
+		if err = initialize.DB.Preload("Product").Preload("Tags").Scopes(publishedScope("histories")).First(&history, id).Error; err == nil {
+			searchIndex.Put(historySearchDocument(history))
+		}
+	case searchTypeCategory:
+		var category models.Category
+		if err = initialize.DB.First(&category, id).Error; err == nil {
+			searchIndex.Put(categorySearchDocument(category))
+		}
+	case searchTypeTag:
+		var tag models.Tag
+		if err = initialize.DB.First(&tag, id).Error; err == nil {
+			searchIndex.Put(tagSearchDocument(tag))
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		searchIndex.Remove(docType, id)
+		return nil
+	}
+	return err
+}
+
+// queueSearchChanges adalah callback GORM setelah create/update/delete yang mencatat baris yang berubah
+func queueSearchChanges(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.Schema.PrioritizedPrimaryField == nil {
+		return
+	}
+	docType, ok := searchTables[db.Statement.Schema.Table]
+	if !ok {
+		return
+	}
+
+	var ids []int64
+	collect := func(value reflect.Value) {
+		for value.Kind() == reflect.Ptr {
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return
+		}
+		pk, zero := db.Statement.Schema.PrioritizedPrimaryField.ValueOf(db.Statement.Context, value)
+		if id, ok := pk.(int64); ok && !zero {
+			ids = append(ids, id)
+		}
+	}
+	switch value := reflect.Indirect(db.Statement.ReflectValue); value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			collect(value.Index(i))
+		}
+	default:
+		collect(value)
+	}
+
+	// Update/Delete dengan kondisi Where saja tidak membawa ID, bangun ulang seluruh tipe
+	if len(ids) == 0 {
+		ids = []int64{0}
+	}
+	for _, id := range ids {
+		queueSearchChange(docType, id)
+	}
+}
+
+// queueSearchChange mencatat perubahan yang tidak terlihat oleh callback, misalnya relasi many-to-many
+func queueSearchChange(docType string, id int64) {
+	select {
+	case searchChanges <- searchChange{docType: docType, id: id}:
+	default:
+		searchRebuildNeeded.Store(true)
+	}
+}
+
+// runSearchIndexer memproses perubahan secara berkelompok setelah jeda singkat
+func runSearchIndexer() {
+	for change := range searchChanges {
+		pending := map[searchChange]bool{change: true}
+		timeout := time.After(250 * time.Millisecond)
+	collect:
+		for {
+			select {
+			case change := <-searchChanges:
+				pending[change] = true
+			case <-timeout:
+				break collect
+			}
+		}
+
+		if searchRebuildNeeded.Swap(false) {
+			for _, docType := range searchTables {
+				pending[searchChange{docType: docType}] = true
+			}
+		}
+
+		// Nama kategori, produk dan tag ikut diindeks pada dokumen produk dan history
+		var related []searchChange
+		for change := range pending {
+			switch {
+			case change.docType == searchTypeCategory && change.id == 0:
+				related = append(related, searchChange{docType: searchTypeProduct})
+			case change.docType == searchTypeCategory:
+				var productIds []int64
+				initialize.DB.Model(&models.Product{}).Where("category_id = ?", change.id).Pluck("product_id", &productIds)
+				for _, productId := range productIds {
+					related = append(related, searchChange{docType: searchTypeProduct, id: productId})
+				}
+			case change.docType == searchTypeTag && change.id == 0:
+				related = append(related, searchChange{docType: searchTypeProduct}, searchChange{docType: searchTypeHistory})
+			case change.docType == searchTypeTag:
+				var productIds, historyIds []int64
+				initialize.DB.Model(&models.ProductTag{}).Where("tag_tag_id = ?", change.id).Pluck("product_product_id", &productIds)
+				initialize.DB.Model(&models.HistoryTag{}).Where("tag_tag_id = ?", change.id).Pluck("history_history_id", &historyIds)
+				for _, productId := range productIds {
+					related = append(related, searchChange{docType: searchTypeProduct, id: productId})
+				}
+				for _, historyId := range historyIds {
+					related = append(related, searchChange{docType: searchTypeHistory, id: historyId})
+				}
+			case change.docType == searchTypeProduct && change.id == 0:
+				related = append(related, searchChange{docType: searchTypeHistory})
+			case change.docType == searchTypeProduct:
+				var historyIds []int64
+				initialize.DB.Model(&models.History{}).Where("product_id = ?", change.id).Pluck("history_id", &historyIds)
+				for _, historyId := range historyIds {
+					related = append(related, searchChange{docType: searchTypeHistory, id: historyId})
+				}
+			}
+		}
+		for _, change := range related {
+			pending[change] = true
+		}
+
+		rebuilt := make(map[string]bool)
+		for change := range pending {
+			if change.id == 0 {
+				rebuilt[change.docType] = true
+				if err := indexSearchType(change.docType); err != nil {
+					log.Printf("search index %s: %v", change.docType, err)
+				}
+			}
+		}
+		for change := range pending {
+			if change.id == 0 || rebuilt[change.docType] {
+				continue
+			}
+			if err := indexSearchDocument(change.docType, change.id); err != nil {
+				log.Printf("search index %s %d: %v", change.docType, change.id, err)
+			}
+		}
+	}
+}
+
+// StartSearchIndex membangun indeks pencarian dan mendaftarkan callback agar indeks selalu sinkron dengan database
+func StartSearchIndex() {
+	callbacks := initialize.DB.Callback()
+	if err := callbacks.Create().After("gorm:commit_or_rollback_transaction").Register("search:sync_create", queueSearchChanges); err != nil {
+		log.Printf("search index: %v", err)
+	}
+	if err := callbacks.Update().After("gorm:commit_or_rollback_transaction").Register("search:sync_update", queueSearchChanges); err != nil {
+		log.Printf("search index: %v", err)
+	}
+	if err := callbacks.Delete().After("gorm:commit_or_rollback_transaction").Register("search:sync_delete", queueSearchChanges); err != nil {
+		log.Printf("search index: %v", err)
+	}
+
+	for _, docType := range []string{searchTypeCategory, searchTypeTag, searchTypeProduct, searchTypeHistory} {
+		if err := indexSearchType(docType); err != nil {
+			log.Printf("search index %s: %v", docType, err)
+		}
+	}
+	log.Printf("search index: %d documents", searchIndex.Len())
+
+	go runSearchIndexer()
+}
+
+func Search(c *fiber.Ctx) error {
+	// Ambil kata kunci dan parameter halaman dari query string
+	q := strings.TrimSpace(c.Query("q"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	page, _ := strconv.Atoi(c.Query("page"))
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	if q == "" {
+		response := helpers.NewResponseError(c, map[string][]string{"q": {helpers.Message(c, helpers.MsgSearchQueryRequired)}})
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	// Filter tipe, misalnya type=product,history
+	var types []string
+	for _, t := range strings.Split(c.Query("type"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+
+	result := searchIndex.Search(helpers.SearchQuery{
+		Text:   q,
+		Types:  types,
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	})
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"query": q,
+			"page":  page,
+			"limit": limit,
+			"total": result.Total,
+			"hits":  result.Hits,
+		},
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
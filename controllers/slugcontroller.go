@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidSlug = helpers.NewError(helpers.MsgInvalidSlug)
+	ErrSlugTaken   = helpers.NewError(helpers.MsgSlugTaken)
+)
+
+// slugTables memetakan entity ke tabel dan kolom ID-nya
+var slugTables = map[string][2]string{
+	models.SlugEntityProduct:  {"products", "product_id"},
+	models.SlugEntityCategory: {"categories", "category_id"},
+	models.SlugEntityHistory:  {"histories", "history_id"},
+}
+
+// resolveSlug menentukan slug baru: slug yang diminta harus unik, tanpa permintaan slug dibuat dari judul
+func resolveSlug(entity string, requested string, title string, excludeId int64) (string, error) {
+	table := slugTables[entity]
+	if requested == "" {
+		return helpers.UniqueSlug(initialize.DB, table[0], table[1], title, entity, excludeId)
+	}
+
+	slug := helpers.Slugify(requested)
+	if slug == "" {
+		return "", ErrInvalidSlug
+	}
+	var count int64
+	if err := initialize.DB.Table(table[0]).Where("slug = ? AND "+table[1]+" <> ?", slug, excludeId).Count(&count).Error; err != nil {
+		return "", err
+	}
+	if count > 0 {
+		return "", ErrSlugTaken
+	}
+	return slug, nil
+}
+
+// recordSlugChange menyimpan slug lama sebagai redirect permanen ke data yang sama
+func recordSlugChange(db *gorm.DB, entity string, entityId int64, oldSlug string, newSlug string) error {
+	// Slug yang kini dipakai data aktif tidak boleh lagi diarahkan ke tempat lain
+	if err := db.Where("entity = ? AND old_slug = ?", entity, newSlug).Delete(&models.SlugRedirect{}).Error; err != nil {
+		return err
+	}
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+	redirect := models.SlugRedirect{Entity: entity, OldSlug: oldSlug, EntityId: entityId}
+	return db.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"entity_id", "updated_at"}),
+	}).Create(&redirect).Error
+}
+
+// slugErrorResponse mengubah kesalahan resolveSlug menjadi respons validasi
+func slugErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, ErrInvalidSlug) || errors.Is(err, ErrSlugTaken) {
+		response := helpers.NewResponseError(c, map[string][]string{"slug": {helpers.ErrorMessage(c, err)}})
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgSlugGenerateFailed)
+	return c.Status(fiber.StatusInternalServerError).JSON(response)
+}
+
+// redirectOldSlug mengarahkan slug lama ke slug terbaru dengan 301, atau 404 jika slug tidak dikenal
+func redirectOldSlug(c *fiber.Ctx, entity string, slug string, prefix string) error {
+	var redirect models.SlugRedirect
+	if err := initialize.DB.Where("entity = ? AND old_slug = ?", entity, slug).First(&redirect).Error; err == nil {
+		table := slugTables[entity]
+		var current string
+		initialize.DB.Table(table[0]).Where(table[1]+" = ?", redirect.EntityId).Select("slug").Scan(&current)
+		if current != "" {
+			return c.Redirect(prefix+current, fiber.StatusMovedPermanently)
+		}
+	}
+	response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgDataNotFound)
+	return c.Status(fiber.StatusNotFound).JSON(response)
+}
+
+func GetProductBySlug(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+
+	var product models.Product
+	if err := initialize.DB.Select("product_id").Where("slug = ?", slug).First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return redirectOldSlug(c, models.SlugEntityProduct, slug, "/api/product/slug/")
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgProductFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	return sendProduct(c, product.ProductId)
+}
+
+func GetCategoryBySlug(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+
+	var category models.Category
+	if err := initialize.DB.Preload("Tags").Preload("Attributes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).Preload("Children", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC, category ASC")
+	}).Where("slug = ?", slug).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return redirectOldSlug(c, models.SlugEntityCategory, slug, "/api/category/slug/")
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgCategoryFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Nama kategori dan subkategori mengikuti bahasa permintaan
+	categories := []models.Category{category}
+	translateCategories(c, categories)
+	category = categories[0]
+	translateCategories(c, category.Children)
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   category,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func GetHistoryBySlug(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+
+	var history models.History
+	if err := initialize.DB.Select("history_id").Where("slug = ?", slug).First(&history).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return redirectOldSlug(c, models.SlugEntityHistory, slug, "/api/history/slug/")
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgHistoryFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	return sendHistory(c, history.HistoryId)
+}
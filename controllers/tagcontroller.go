@@ -4,10 +4,9 @@ import (
 	"Matahariled/helpers"
 	"Matahariled/initialize"
 	"Matahariled/models"
-	"fmt"
 	"strconv"
+	"strings"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -15,28 +14,13 @@ func CreateTag(c *fiber.Ctx) error {
 	// Parse body request ke dalam struct TagCreateRequest
 	var requestBody models.TagRequest
 	if err := c.BodyParser(&requestBody); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid request body",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Validasi request body
 	if err := validate.Struct(&requestBody); err != nil {
-		errors := make(map[string][]string)
-		for _, err := range err.(validator.ValidationErrors) {
-			field := err.Field()
-			tag := err.Tag()
-			message := fmt.Sprintf("%s is %s", field, tag)
-			errors[field] = append(errors[field], message)
-		}
-		response := helpers.ResponseError{
-			Code:   fiber.StatusBadRequest,
-			Status: "Bad Request",
-			Error:  errors,
-		}
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
@@ -47,20 +31,12 @@ func CreateTag(c *fiber.Ctx) error {
 
 	// Menyimpan tag ke dalam database
 	if err := initialize.DB.Create(&tag).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save tag",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTagSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Mengirimkan respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "Tag created successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgTagCreated)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
@@ -68,91 +44,63 @@ func DeleteTag(c *fiber.Ctx) error {
 	// Ambil ID tag dari parameter URL
 	tagID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid tag ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidTagID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Cari tag berdasarkan ID di database
 	var tag models.Tag
 	if err := initialize.DB.First(&tag, tagID).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusNotFound,
-			Status:  "Not Found",
-			Message: "Tag not found",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgTagNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
+	// Catat produk dan riwayat yang memakai tag sebelum relasinya ikut terhapus
+	var productIds, historyIds []int64
+	initialize.DB.Model(&models.ProductTag{}).Where("tag_tag_id = ?", tag.TagId).Pluck("product_product_id", &productIds)
+	initialize.DB.Model(&models.HistoryTag{}).Where("tag_tag_id = ?", tag.TagId).Pluck("history_history_id", &historyIds)
+
 	// Hapus tag dari database
 	if err := initialize.DB.Delete(&tag).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to delete tag",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTagDeleteFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
+	for _, productId := range productIds {
+		queueSearchChange(searchTypeProduct, productId)
+	}
+	for _, historyId := range historyIds {
+		queueSearchChange(searchTypeHistory, historyId)
+	}
 
 	// Mengirimkan respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "Tag deleted successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgTagDeleted)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 func UpdateTag(c *fiber.Ctx) error {
 	// Ambil ID tag dari parameter URL
 	tagID, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid tag ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidTagID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Parse body request ke dalam struct TagUpdateRequest
 	var requestBody models.TagRequest
 	if err := c.BodyParser(&requestBody); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid request body",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Validasi request body
 	if err := validate.Struct(&requestBody); err != nil {
-		errors := make(map[string][]string)
-		for _, err := range err.(validator.ValidationErrors) {
-			field := err.Field()
-			tag := err.Tag()
-			message := fmt.Sprintf("%s is %s", field, tag)
-			errors[field] = append(errors[field], message)
-		}
-		response := helpers.ResponseError{
-			Code:   fiber.StatusBadRequest,
-			Status: "Bad Request",
-			Error:  errors,
-		}
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Cari tag berdasarkan ID di database
 	var tag models.Tag
 	if err := initialize.DB.First(&tag, tagID).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusNotFound,
-			Status:  "Not Found",
-			Message: "Tag not found",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgTagNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
@@ -161,20 +109,12 @@ func UpdateTag(c *fiber.Ctx) error {
 
 	// Simpan perubahan ke dalam database
 	if err := initialize.DB.Save(&tag).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to update tag",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTagUpdateFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Mengirimkan respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "Tag updated successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgTagUpdated)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
@@ -182,11 +122,7 @@ func GetAllTag(c *fiber.Ctx) error {
 	// Ambil semua video dari database
 	var tag []models.Tag
 	if err := initialize.DB.Find(&tag).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to fetch videos",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgVideoFetchFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -198,34 +134,110 @@ func GetAllTag(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+// tagsFromForm membaca tag_ids[] dari form. present bernilai false jika field tidak dikirim sama sekali
+func tagsFromForm(c *fiber.Ctx) (tags []models.Tag, present bool, err error) {
+	var values []string
+	if form, formErr := c.MultipartForm(); formErr == nil {
+		values, present = form.Value["tag_ids[]"]
+	} else if args := c.Request().PostArgs(); args.Has("tag_ids[]") {
+		present = true
+		for _, v := range args.PeekMulti("tag_ids[]") {
+			values = append(values, string(v))
+		}
+	}
+
+	tags, err = parseTagIDs(values)
+	return tags, present, err
+}
+
+// parseTagIDs membaca daftar ID tag yang dipisahkan koma dan memastikan setiap tag ada
+func parseTagIDs(values []string) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	seen := make(map[int64]bool)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			tagId, err := strconv.ParseInt(part, 10, 64)
+			if err != nil {
+				return nil, helpers.NewError(helpers.MsgInvalidTagIDValue, part)
+			}
+			if seen[tagId] {
+				continue
+			}
+			seen[tagId] = true
+			var tag models.Tag
+			if err := initialize.DB.First(&tag, tagId).Error; err != nil {
+				return nil, helpers.NewError(helpers.MsgTagIDNotFound, tagId)
+			}
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// tagUsage menghitung pemakaian setiap tag pada satu tabel penghubung
+func tagUsage(table string, result map[int64]int64) error {
+	var rows []struct {
+		TagId int64
+		Count int64
+	}
+	if err := initialize.DB.Table(table).Select("tag_tag_id AS tag_id, COUNT(*) AS count").Group("tag_tag_id").Scan(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		result[row.TagId] = row.Count
+	}
+	return nil
+}
+
 func GetTagLabel(c *fiber.Ctx) error {
-	// Ambil semua pengguna dari database
+	// Ambil semua tag dari database
 	var tags []models.Tag
 	if err := initialize.DB.Find(&tags).Error; err != nil {
-		// Jika terjadi kesalahan saat mengambil pengguna, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		// Jika terjadi kesalahan saat mengambil tag, kirim respons kesalahan ke klien
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
+	// Hitung pemakaian tag per jenis data
+	categoryUsage := make(map[int64]int64)
+	productUsage := make(map[int64]int64)
+	historyUsage := make(map[int64]int64)
+	for table, usage := range map[string]map[int64]int64{
+		"CategoryTag": categoryUsage,
+		"ProductTag":  productUsage,
+		"HistoryTag":  historyUsage,
+	} {
+		if err := tagUsage(table, usage); err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+	}
+
 	// Buat respons dengan format yang diinginkan
-	var userOptions []map[string]interface{}
+	var tagOptions []map[string]interface{}
 	for _, tag := range tags {
 		option := map[string]interface{}{
 			"value": tag.TagId,
-			"label": tag.Tag, // Atur atribut yang sesuai dengan nama pengguna
+			"label": tag.Tag,
+			"usage": map[string]int64{
+				"category": categoryUsage[tag.TagId],
+				"product":  productUsage[tag.TagId],
+				"history":  historyUsage[tag.TagId],
+				"total":    categoryUsage[tag.TagId] + productUsage[tag.TagId] + historyUsage[tag.TagId],
+			},
 		}
-		userOptions = append(userOptions, option)
+		tagOptions = append(tagOptions, option)
 	}
 
-	// Kembalikan respons sukses dengan data pengguna ke klien
+	// Kembalikan respons sukses dengan data tag ke klien
 	response := helpers.GeneralResponse{
 		Code:   fiber.StatusOK,
 		Status: "OK",
-		Data:   userOptions,
+		Data:   tagOptions,
 	}
 	return c.JSON(response)
 }
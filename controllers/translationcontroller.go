@@ -0,0 +1,386 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// translationResource menjelaskan tabel yang kolomnya dapat diterjemahkan
+type translationResource struct {
+	table       string
+	idColumn    string
+	titleColumn string
+	softDelete  bool
+	publishable bool
+}
+
+var translationResources = map[string]translationResource{
+	models.SlugEntityProduct:  {"products", "product_id", "title", true, true},
+	models.SlugEntityCategory: {"categories", "category_id", "category", false, false},
+	models.SlugEntityHistory:  {"histories", "history_id", "title", true, true},
+}
+
+// textValue mengubah nilai hasil scan ke map menjadi teks
+func textValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+// translator berisi terjemahan satu jenis data untuk bahasa permintaan, dimuat sekaligus untuk banyak ID
+type translator struct {
+	values map[int64]map[string]string
+}
+
+// loadTranslator memuat terjemahan; bahasa bawaan tidak membutuhkan query
+func loadTranslator(locale string, entity string, ids []int64) translator {
+	t := translator{values: make(map[int64]map[string]string)}
+	if locale == models.DefaultLocale || len(ids) == 0 {
+		return t
+	}
+	var translations []models.Translation
+	if err := initialize.DB.Where("entity = ? AND locale = ? AND entity_id IN ?", entity, locale, ids).Find(&translations).Error; err != nil {
+		return t
+	}
+	for _, translation := range translations {
+		if t.values[translation.EntityId] == nil {
+			t.values[translation.EntityId] = make(map[string]string)
+		}
+		t.values[translation.EntityId][translation.Field] = translation.Value
+	}
+	return t
+}
+
+// text mengembalikan terjemahan, atau teks bahasa bawaan jika terjemahan belum ada
+func (t translator) text(id int64, field string, fallback string) string {
+	if value := t.values[id][field]; value != "" {
+		return value
+	}
+	return fallback
+}
+
+// editingRequest bernilai true untuk admin. Datatable dan detail dipakai sebagai isi form edit yang disimpan kembali
+// ke kolom bahasa bawaan, sehingga admin selalu menerima teks asli; terjemahan diubah lewat endpoint translations.
+func editingRequest(c *fiber.Ctx) bool {
+	if !isAdminRequest(c) {
+		return false
+	}
+	c.Set(fiber.HeaderContentLanguage, models.DefaultLocale)
+	return true
+}
+
+// translateCategories menerjemahkan nama kategori sesuai bahasa permintaan
+func translateCategories(c *fiber.Ctx, categories []models.Category) {
+	locale := helpers.Locale(c)
+	c.Set(fiber.HeaderContentLanguage, locale)
+	ids := make([]int64, 0, len(categories))
+	for _, category := range categories {
+		ids = append(ids, category.CategoryId)
+	}
+	t := loadTranslator(locale, models.SlugEntityCategory, ids)
+	for i := range categories {
+		categories[i].Category = t.text(categories[i].CategoryId, "category", categories[i].Category)
+	}
+}
+
+// translateProducts menerjemahkan judul, deskripsi, spesifikasi dan nama kategori produk
+func translateProducts(c *fiber.Ctx, products []models.Product) {
+	locale := helpers.Locale(c)
+	c.Set(fiber.HeaderContentLanguage, locale)
+	translateProductsIn(locale, products)
+}
+
+// translateProductsIn sama dengan translateProducts untuk bahasa tertentu, dipakai di luar siklus permintaan seperti ekspor
+func translateProductsIn(locale string, products []models.Product) {
+	productIds := make([]int64, 0, len(products))
+	categoryIds := make([]int64, 0, len(products))
+	for _, product := range products {
+		productIds = append(productIds, product.ProductId)
+		categoryIds = append(categoryIds, product.CategoryId)
+	}
+	productText := loadTranslator(locale, models.SlugEntityProduct, productIds)
+	categoryText := loadTranslator(locale, models.SlugEntityCategory, categoryIds)
+	for i := range products {
+		product := &products[i]
+		product.Title = productText.text(product.ProductId, "title", product.Title)
+		product.Description = productText.text(product.ProductId, "description", product.Description)
+		product.Specification = productText.text(product.ProductId, "specification", product.Specification)
+		product.Category.Category = categoryText.text(product.CategoryId, "category", product.Category.Category)
+	}
+}
+
+// translateHistories menerjemahkan judul dan deskripsi riwayat beserta produk dan kategorinya
+func translateHistories(c *fiber.Ctx, histories []models.History) {
+	locale := helpers.Locale(c)
+	c.Set(fiber.HeaderContentLanguage, locale)
+	translateHistoriesIn(locale, histories)
+}
+
+// translateHistoriesIn sama dengan translateHistories untuk bahasa tertentu
+func translateHistoriesIn(locale string, histories []models.History) {
+	historyIds := make([]int64, 0, len(histories))
+	productIds := make([]int64, 0, len(histories))
+	categoryIds := make([]int64, 0, len(histories))
+	for _, history := range histories {
+		historyIds = append(historyIds, history.HistoryId)
+		productIds = append(productIds, history.ProductId)
+		categoryIds = append(categoryIds, history.Product.CategoryId)
+	}
+	historyText := loadTranslator(locale, models.SlugEntityHistory, historyIds)
+	productText := loadTranslator(locale, models.SlugEntityProduct, productIds)
+	categoryText := loadTranslator(locale, models.SlugEntityCategory, categoryIds)
+	for i := range histories {
+		history := &histories[i]
+		history.Title = historyText.text(history.HistoryId, "title", history.Title)
+		history.Description = historyText.text(history.HistoryId, "description", history.Description)
+		history.Product.Title = productText.text(history.ProductId, "title", history.Product.Title)
+		history.Product.Category.Category = categoryText.text(history.Product.CategoryId, "category", history.Product.Category.Category)
+	}
+}
+
+// deleteTranslations menghapus seluruh terjemahan satu data
+func deleteTranslations(db *gorm.DB, entity string, entityId int64) error {
+	return db.Where("entity = ? AND entity_id = ?", entity, entityId).Delete(&models.Translation{}).Error
+}
+
+// findTranslationBase mengambil teks bahasa bawaan; gorm.ErrRecordNotFound jika data tidak ada
+// atau belum terbit bagi pengunjung selain admin
+func findTranslationBase(c *fiber.Ctx, resource translationResource, entity string, id int64) (map[string]interface{}, error) {
+	query := initialize.DB.Table(resource.table).Select(models.TranslatableFields[entity]).Where(resource.idColumn+" = ?", id)
+	if resource.softDelete {
+		query = query.Where("deleted_at IS NULL")
+	}
+	if resource.publishable {
+		query = query.Scopes(visibleScope(c, resource.table))
+	}
+	base := map[string]interface{}{}
+	result := query.Limit(1).Take(&base)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return base, nil
+}
+
+// GetTranslations menampilkan teks bahasa bawaan dan terjemahan setiap bahasa untuk satu data
+func GetTranslations(entity string) fiber.Handler {
+	resource := translationResources[entity]
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidID)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+
+		base, err := findTranslationBase(c, resource, entity, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgDataNotFound)
+				return c.Status(fiber.StatusNotFound).JSON(response)
+			}
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTranslationsFetchFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+
+		var translations []models.Translation
+		if err := initialize.DB.Where("entity = ? AND entity_id = ?", entity, id).Find(&translations).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTranslationsFetchFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+
+		// Setiap bahasa berisi semua kolom, kolom tanpa terjemahan bernilai kosong
+		locales := make(map[string]map[string]string, len(models.Locales))
+		for _, locale := range models.Locales {
+			locales[locale] = make(map[string]string)
+			for _, field := range models.TranslatableFields[entity] {
+				if locale == models.DefaultLocale {
+					locales[locale][field] = textValue(base[field])
+				} else {
+					locales[locale][field] = ""
+				}
+			}
+		}
+		for _, translation := range translations {
+			if fields, ok := locales[translation.Locale]; ok {
+				fields[translation.Field] = translation.Value
+			}
+		}
+
+		response := helpers.GeneralResponse{
+			Code:   fiber.StatusOK,
+			Status: "OK",
+			Data:   locales,
+		}
+		return c.JSON(response)
+	}
+}
+
+// UpdateTranslations menyimpan terjemahan satu bahasa; nilai kosong menghapus terjemahan kolom itu
+func UpdateTranslations(entity string) fiber.Handler {
+	resource := translationResources[entity]
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidID)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+
+		// Teks bahasa bawaan diubah lewat endpoint update biasa
+		locale := c.Params("locale")
+		if locale == models.DefaultLocale || !helpers.SupportedLocale(locale) {
+			response := helpers.NewResponseError(c, map[string][]string{"locale": {helpers.Message(c, helpers.MsgInvalidTranslationLocale, locale)}})
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+
+		var reqBody map[string]string
+		if err := c.BodyParser(&reqBody); err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		allowed := make(map[string]bool)
+		for _, field := range models.TranslatableFields[entity] {
+			allowed[field] = true
+		}
+		fieldErrors := make(map[string][]string)
+		for field := range reqBody {
+			if !allowed[field] {
+				fieldErrors[field] = append(fieldErrors[field], helpers.Message(c, helpers.MsgFieldNotTranslatable, field))
+			}
+		}
+		if len(fieldErrors) > 0 {
+			response := helpers.NewResponseError(c, fieldErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+
+		if _, err := findTranslationBase(c, resource, entity, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgDataNotFound)
+				return c.Status(fiber.StatusNotFound).JSON(response)
+			}
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTranslationsSaveFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+
+		err = initialize.DB.Transaction(func(tx *gorm.DB) error {
+			for field, value := range reqBody {
+				if value == "" {
+					if err := tx.Where("entity = ? AND entity_id = ? AND locale = ? AND field = ?", entity, id, locale, field).Delete(&models.Translation{}).Error; err != nil {
+						return err
+					}
+					continue
+				}
+				translation := models.Translation{Entity: entity, EntityId: id, Locale: locale, Field: field, Value: value}
+				if err := tx.Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"})}).Create(&translation).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTranslationsSaveFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+
+		response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgTranslationsSaved)
+		return c.JSON(response)
+	}
+}
+
+// GetMissingTranslations melaporkan data yang kolomnya belum diterjemahkan ke bahasa tertentu
+func GetMissingTranslations(c *fiber.Ctx) error {
+	locale := c.Query("locale", models.LocaleEnglish)
+	if locale == models.DefaultLocale || !helpers.SupportedLocale(locale) {
+		response := helpers.NewResponseError(c, map[string][]string{"locale": {helpers.Message(c, helpers.MsgInvalidTranslationLocale, locale)}})
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	entities := []string{models.SlugEntityProduct, models.SlugEntityCategory, models.SlugEntityHistory}
+	if entity := c.Query("entity"); entity != "" {
+		if _, ok := translationResources[entity]; !ok {
+			response := helpers.NewResponseError(c, map[string][]string{"entity": {helpers.Message(c, helpers.MsgEntityNotTranslatable, entity)}})
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		entities = []string{entity}
+	}
+
+	items := []models.MissingTranslation{}
+	summary := make(map[string]fiber.Map)
+	for _, entity := range entities {
+		resource := translationResources[entity]
+		fields := models.TranslatableFields[entity]
+
+		columns := append([]string{resource.idColumn + " AS id", resource.titleColumn + " AS label"}, fields...)
+		query := initialize.DB.Table(resource.table).Select(columns).Order(resource.idColumn + " ASC")
+		if resource.softDelete {
+			query = query.Where("deleted_at IS NULL")
+		}
+		var rows []map[string]interface{}
+		if err := query.Find(&rows).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTranslationsFetchFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+
+		var translations []models.Translation
+		if err := initialize.DB.Where("entity = ? AND locale = ? AND value <> ''", entity, locale).Find(&translations).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTranslationsFetchFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		translated := make(map[int64]map[string]bool)
+		for _, translation := range translations {
+			if translated[translation.EntityId] == nil {
+				translated[translation.EntityId] = make(map[string]bool)
+			}
+			translated[translation.EntityId][translation.Field] = true
+		}
+
+		// Kolom yang kosong dalam bahasa bawaan tidak perlu diterjemahkan
+		incomplete := 0
+		for _, row := range rows {
+			id, _ := strconv.ParseInt(textValue(row["id"]), 10, 64)
+			var missing []string
+			for _, field := range fields {
+				if textValue(row[field]) != "" && !translated[id][field] {
+					missing = append(missing, field)
+				}
+			}
+			if len(missing) > 0 {
+				incomplete++
+				items = append(items, models.MissingTranslation{
+					Entity:   entity,
+					EntityId: id,
+					Title:    textValue(row["label"]),
+					Missing:  missing,
+				})
+			}
+		}
+		summary[entity] = fiber.Map{
+			"total":      len(rows),
+			"complete":   len(rows) - incomplete,
+			"incomplete": incomplete,
+		}
+	}
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data: fiber.Map{
+			"locale":  locale,
+			"summary": summary,
+			"items":   items,
+		},
+	}
+	return c.JSON(response)
+}
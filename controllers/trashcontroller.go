@@ -0,0 +1,373 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/initialize"
+	"Matahariled/models"
+	"errors"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// ErrTrashInUse dikembalikan jika data di trash masih direferensikan data lain sehingga belum bisa dihapus permanen
+var ErrTrashInUse = helpers.NewError(helpers.MsgTrashInUse)
+
+// trashResource menjelaskan tabel yang memakai soft delete beserta cara menghapusnya permanen
+type trashResource struct {
+	table       string
+	idColumn    string
+	titleColumn string
+	searchType  string
+	publishable bool
+	purge       func(id int64) error
+}
+
+var trashResources = map[string]trashResource{
+	"product":  {"products", "product_id", "title", searchTypeProduct, true, purgeProduct},
+	"history":  {"histories", "history_id", "title", searchTypeHistory, true, purgeHistory},
+	"contract": {"contracts", "contract_id", "title", "", false, purgeContract},
+	"user":     {"users", "user_id", "full_name", "", false, purgeUser},
+}
+
+// trashRetention dibaca dari TRASH_RETENTION_DAYS (bawaan 30 hari), 0 berarti data di trash tidak dihapus otomatis
+func trashRetention() time.Duration {
+	days := 30
+	if value := os.Getenv("TRASH_RETENTION_DAYS"); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+			days = parsed
+		}
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// removeUpload menghapus file lewat tabel files, atau langsung dari disk untuk data lama tanpa file_id
+func removeUpload(fileId int64, path string) error {
+	if fileId != 0 {
+		return deleteFileIfUnused(fileId)
+	}
+	if path == "" || sharedAssetPaths[path] {
+		return nil
+	}
+	if err := os.Remove("." + path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ensureNoStock mengembalikan ErrTrashInUse jika masih ada unit inventaris yang merujuk data tersebut
+func ensureNoStock(column string, id int64) error {
+	var count int64
+	if err := initialize.DB.Model(&models.StockItem{}).Where(column+" = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTrashInUse
+	}
+	return nil
+}
+
+// purgeProduct menghapus permanen produk di trash beserta hero, galeri dan filenya
+func purgeProduct(id int64) error {
+	var product models.Product
+	if err := initialize.DB.Unscoped().Preload("Gallery").Where("deleted_at IS NOT NULL").First(&product, id).Error; err != nil {
+		return err
+	}
+
+	// Riwayat pemasangan, termasuk yang ada di trash, harus dihapus lebih dulu
+	var historyCount int64
+	if err := initialize.DB.Unscoped().Model(&models.History{}).Where("product_id = ?", id).Count(&historyCount).Error; err != nil {
+		return err
+	}
+	if historyCount > 0 {
+		return ErrTrashInUse
+	}
+	// Unit fisik bernomor seri tetap tercatat di inventaris sehingga produknya tidak boleh hilang
+	if err := ensureNoStock("product_id", id); err != nil {
+		return err
+	}
+
+	var heroes []models.Hero
+	if err := initialize.DB.Where("product_id = ?", id).Find(&heroes).Error; err != nil {
+		return err
+	}
+	var documents []models.ProductDocument
+	if err := initialize.DB.Where("product_id = ?", id).Find(&documents).Error; err != nil {
+		return err
+	}
+
+	// Semua baris dihapus dalam satu transaksi, file di disk baru dihapus setelah commit berhasil
+	err := initialize.DB.Transaction(func(tx *gorm.DB) error {
+		for _, hero := range heroes {
+			if err := tx.Delete(&hero).Error; err != nil {
+				return err
+			}
+		}
+		for _, gallery := range product.Gallery {
+			if err := tx.Delete(&gallery).Error; err != nil {
+				return err
+			}
+		}
+		// Video dan tag tetap tersimpan di pustaka, hanya relasinya yang dilepas
+		if err := tx.Model(&product).Association("Videos").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Model(&product).Association("Tags").Clear(); err != nil {
+			return err
+		}
+		if err := deleteTranslations(tx, models.SlugEntityProduct, product.ProductId); err != nil {
+			return err
+		}
+		if err := tx.Where("product_id = ? OR recommended_id = ?", id, id).Delete(&models.ProductRecommendation{}).Error; err != nil {
+			return err
+		}
+		// Revisi ikut dihapus agar file yang hanya direferensikan revisi bisa dibersihkan
+		if err := tx.Where("product_id = ?", id).Delete(&models.ProductRevision{}).Error; err != nil {
+			return err
+		}
+		// Baris dokumen ikut terhapus lewat cascade
+		return tx.Unscoped().Delete(&product).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, hero := range heroes {
+		if err := removeUpload(hero.FileId, hero.Path); err != nil {
+			return err
+		}
+	}
+	for _, gallery := range product.Gallery {
+		if err := removeUpload(gallery.FileId, gallery.Path); err != nil {
+			return err
+		}
+	}
+	for _, document := range documents {
+		if err := removeDocumentFile(document.StorageName); err != nil {
+			return err
+		}
+	}
+	return removeUpload(product.FileId, "")
+}
+
+// purgeHistory menghapus permanen riwayat di trash beserta filenya
+func purgeHistory(id int64) error {
+	var history models.History
+	if err := initialize.DB.Unscoped().Where("deleted_at IS NOT NULL").First(&history, id).Error; err != nil {
+		return err
+	}
+	if err := ensureNoStock("history_id", id); err != nil {
+		return err
+	}
+	if err := initialize.DB.Model(&history).Association("Videos").Clear(); err != nil {
+		return err
+	}
+	if err := initialize.DB.Model(&history).Association("Tags").Clear(); err != nil {
+		return err
+	}
+	if err := deleteTranslations(initialize.DB, models.SlugEntityHistory, history.HistoryId); err != nil {
+		return err
+	}
+	if err := initialize.DB.Unscoped().Delete(&history).Error; err != nil {
+		return err
+	}
+	return removeUpload(history.FileId, "")
+}
+
+// purgeContract menghapus permanen kontrak di trash beserta hubungannya dengan produk
+func purgeContract(id int64) error {
+	var contract models.Contract
+	if err := initialize.DB.Unscoped().Where("deleted_at IS NOT NULL").First(&contract, id).Error; err != nil {
+		return err
+	}
+	if err := ensureNoStock("contract_id", id); err != nil {
+		return err
+	}
+	return initialize.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&contract).Association("Products").Clear(); err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&contract).Error
+	})
+}
+
+// purgeUser menghapus permanen pengguna di trash beserta fotonya
+func purgeUser(id int64) error {
+	var user models.User
+	if err := initialize.DB.Unscoped().Where("deleted_at IS NOT NULL").First(&user, id).Error; err != nil {
+		return err
+	}
+	for _, model := range []interface{}{&models.History{}, &models.Contract{}, &models.Quotation{}} {
+		var count int64
+		if err := initialize.DB.Unscoped().Model(model).Where("user_id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrTrashInUse
+		}
+	}
+	if err := initialize.DB.Unscoped().Delete(&user).Error; err != nil {
+		return err
+	}
+	if user.FileId != nil {
+		return deleteFileIfUnused(*user.FileId)
+	}
+	return nil
+}
+
+// purgeExpiredTrash menghapus permanen data yang sudah berada di trash lebih lama dari masa simpan
+func purgeExpiredTrash(retention time.Duration) {
+	cutoff := time.Now().Add(-retention)
+	for name, resource := range trashResources {
+		var ids []int64
+		if err := initialize.DB.Table(resource.table).Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Pluck(resource.idColumn, &ids).Error; err != nil {
+			log.Printf("trash %s: %v", name, err)
+			continue
+		}
+		for _, id := range ids {
+			if err := resource.purge(id); err != nil {
+				log.Printf("trash %s %d: %v", name, id, err)
+			}
+		}
+		if len(ids) > 0 {
+			log.Printf("trash %s: purged %d expired items", name, len(ids))
+		}
+	}
+}
+
+// StartTrashRetention menjalankan pembersihan trash saat aplikasi mulai lalu setiap jam
+func StartTrashRetention() {
+	retention := trashRetention()
+	if retention == 0 {
+		log.Printf("trash retention: disabled")
+		return
+	}
+	go func() {
+		purgeExpiredTrash(retention)
+		for range time.Tick(time.Hour) {
+			purgeExpiredTrash(retention)
+		}
+	}()
+}
+
+// GetTrash menampilkan data resource yang berada di trash, terbaru lebih dulu
+func GetTrash(resourceName string) fiber.Handler {
+	resource := trashResources[resourceName]
+	return func(c *fiber.Ctx) error {
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		page, _ := strconv.Atoi(c.Query("page"))
+		if limit <= 0 {
+			limit = 10
+		}
+		if page <= 0 {
+			page = 1
+		}
+		offset := (page - 1) * limit
+
+		query := initialize.DB.Table(resource.table).Where("deleted_at IS NOT NULL")
+		if resource.publishable {
+			query = query.Scopes(visibleScope(c, resource.table))
+		}
+		if search := c.Query("search"); search != "" {
+			query = query.Where(resource.titleColumn+" LIKE ?", "%"+search+"%")
+		}
+
+		var totalRecords int64
+		if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTrashFetchFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+
+		items := []models.TrashItem{}
+		if err := query.Select(resource.idColumn + " AS id, " + resource.titleColumn + " AS title, deleted_at").
+			Order("deleted_at DESC").Limit(limit).Offset(offset).Scan(&items).Error; err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTrashFetchFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		if retention := trashRetention(); retention > 0 {
+			for i := range items {
+				purgeAt := items[i].DeletedAt.Add(retention)
+				items[i].PurgeAt = &purgeAt
+			}
+		}
+
+		response := helpers.DataTableResponse{
+			CurrentPage: page,
+			From:        offset + 1,
+			LastPage:    int(math.Ceil(float64(totalRecords) / float64(limit))),
+			To:          offset + len(items),
+			Total:       int(totalRecords),
+			Data:        make([]interface{}, len(items)),
+		}
+		for i, item := range items {
+			response.Data[i] = item
+		}
+		return c.JSON(helpers.GeneralResponse{
+			Code:   fiber.StatusOK,
+			Status: "OK",
+			Data:   response,
+		})
+	}
+}
+
+// RestoreTrash mengembalikan data dari trash
+func RestoreTrash(resourceName string) fiber.Handler {
+	resource := trashResources[resourceName]
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidID)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+
+		result := initialize.DB.Table(resource.table).Where(resource.idColumn+" = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+		if result.Error != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgRestoreFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		if result.RowsAffected == 0 {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgTrashNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		// Update lewat Table tidak melewati callback pencarian
+		if resource.searchType != "" {
+			queueSearchChange(resource.searchType, id)
+		}
+
+		response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgDataRestored)
+		return c.JSON(response)
+	}
+}
+
+// PurgeTrash menghapus permanen satu data yang sudah berada di trash
+func PurgeTrash(resourceName string) fiber.Handler {
+	resource := trashResources[resourceName]
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidID)
+			return c.Status(fiber.StatusBadRequest).JSON(response)
+		}
+
+		if err := resource.purge(id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgTrashNotFound)
+				return c.Status(fiber.StatusNotFound).JSON(response)
+			}
+			if errors.Is(err, ErrTrashInUse) {
+				response := helpers.NewErrorMassage(c, fiber.StatusConflict, err)
+				return c.Status(fiber.StatusConflict).JSON(response)
+			}
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgPurgeFailed)
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+
+		response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgDataPurged)
+		return c.JSON(response)
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"Matahariled/helpers"
 	"Matahariled/initialize"
 	"Matahariled/models"
+	"encoding/json"
 	"fmt"
+	"log"
 	"math"
 	"os"
-	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -16,13 +18,16 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+	if err := helpers.RegisterValidatorTranslations(validate); err != nil {
+		log.Fatalf("register validator translations: %v", err)
+	}
 }
 
 func Index(c *fiber.Ctx) error {
@@ -41,30 +46,18 @@ func LoginUser(c *fiber.Ctx) error {
 	// Parse body permintaan
 	var req models.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid request body",
-		})
+		return c.Status(fiber.StatusBadRequest).JSON(helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody))
 	}
 
 	// Ambil data pengguna dari database berdasarkan email
 	var user models.User
-	if err := initialize.DB.Where("email = ?", req.Email).First(&user).Error; err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(helpers.ResponseMassage{
-			Code:    fiber.StatusUnauthorized,
-			Status:  "Unauthorized",
-			Message: "Invalid email or password",
-		})
+	if err := initialize.DB.Preload("File").Where("email = ?", req.Email).First(&user).Error; err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(helpers.NewResponseMassage(c, fiber.StatusUnauthorized, helpers.MsgInvalidCredentials))
 	}
 
 	// Periksa kecocokan password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(helpers.ResponseMassage{
-			Code:    fiber.StatusUnauthorized,
-			Status:  "Unauthorized",
-			Message: "Invalid email or password",
-		})
+		return c.Status(fiber.StatusUnauthorized).JSON(helpers.NewResponseMassage(c, fiber.StatusUnauthorized, helpers.MsgInvalidCredentials))
 	}
 	token := jwt.New(jwt.SigningMethodHS256)
 
@@ -76,26 +69,18 @@ func LoginUser(c *fiber.Ctx) error {
 	claims["phone_number"] = user.PhoneNumber
 	claims["fullname"] = user.FullName
 	claims["address"] = user.Address
-	claims["path"] = user.File.Path
+	claims["path"] = user.AvatarPath()
 	claims["role"] = user.Role
 	claims["exp"] = time.Now().Add(time.Hour * 24).Unix() // Token berlaku selama 24 jam
 
 	// Tanda tangani token dengan secret key
 	secret := []byte(os.Getenv("JWT_SECRET"))
 	if secret == nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "JWT secret key not found",
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgJWTSecretMissing))
 	}
 	tokenString, err := token.SignedString(secret)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to sign JWT token",
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTokenSignFailed))
 	}
 	c.Set("Authorization", "Bearer "+tokenString)
 	// Kirim token JWT dan model User dalam respons
@@ -115,11 +100,7 @@ func GetUsersLabel(c *fiber.Ctx) error {
 	var users []models.User
 	if err := initialize.DB.Find(&users).Error; err != nil {
 		// Jika terjadi kesalahan saat mengambil pengguna, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -146,11 +127,7 @@ func GetCountUser(c *fiber.Ctx) error {
 	var count int64
 	if err := initialize.DB.Model(&models.User{}).Count(&count).Error; err != nil {
 		// Jika terjadi kesalahan saat menghitung pengguna, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -169,11 +146,7 @@ func GetUserById(c *fiber.Ctx) error {
 	userId, err := strconv.ParseInt(userIdStr, 10, 64)
 	if err != nil {
 		// Jika ID tidak valid, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    400,
-			Status:  "Bad Request",
-			Message: "ID pengguna tidak valid",
-		}
+		response := helpers.NewResponseMassage(c, 400, helpers.MsgInvalidUserID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
@@ -183,11 +156,7 @@ func GetUserById(c *fiber.Ctx) error {
 	// Cari pengguna dengan ID yang sesuai dalam database
 	if err := initialize.DB.Where("user_id = ?", userId).First(&user).Error; err != nil {
 		// Jika pengguna tidak ditemukan, kirim respons ke klien
-		response := helpers.ResponseMassage{
-			Code:    404,
-			Status:  "Not Found",
-			Message: "Pengguna tidak ditemukan",
-		}
+		response := helpers.NewResponseMassage(c, 404, helpers.MsgUserNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
@@ -203,21 +172,13 @@ func GetProfile(c *fiber.Ctx) error {
 	// Ambil ID pengguna dari lokal konteks
 	userID, ok := c.Locals("userID").(float64)
 	if !ok {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"code":    fiber.StatusInternalServerError,
-			"status":  "Internal Server Error",
-			"message": "Gagal Mengambil Id Dari Token",
-		})
+		return c.Status(fiber.StatusInternalServerError).JSON(helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgTokenUserFailed))
 	}
 
 	// Query database untuk mendapatkan profil pengguna berdasarkan ID
 	var user models.User
 	if err := initialize.DB.Where("user_id = ?", int64(userID)).First(&user).Error; err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"code":    fiber.StatusNotFound,
-			"status":  "Not Found",
-			"message": "Pengguna Tidak Tersedia",
-		})
+		return c.Status(fiber.StatusNotFound).JSON(helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgUserNotFound))
 	}
 
 	// Kirim respons dengan profil pengguna
@@ -234,11 +195,7 @@ func CreateUser(c *fiber.Ctx) error {
 	var newUser models.User
 	if err := c.BodyParser(&newUser); err != nil {
 		// Jika terjadi kesalahan dalam mengurai permintaan, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    400,
-			Status:  "Bad Request",
-			Message: "Gagal Membuat User",
-		}
+		response := helpers.NewResponseMassage(c, 400, helpers.MsgUserCreateFailed)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
@@ -246,31 +203,27 @@ func CreateUser(c *fiber.Ctx) error {
 
 	// Validasi manual untuk setiap field
 	if newUser.FullName == "" {
-		validationErrors["full_name"] = append(validationErrors["full_name"], "Full Name Wajib Diisi")
+		validationErrors["full_name"] = append(validationErrors["full_name"], helpers.Message(c, helpers.MsgFieldRequired, "Full Name"))
 	}
 	if newUser.UserName == "" {
-		validationErrors["username"] = append(validationErrors["username"], "Username Wajib Diisi")
+		validationErrors["username"] = append(validationErrors["username"], helpers.Message(c, helpers.MsgFieldRequired, "Username"))
 	}
 	if newUser.PhoneNumber == "" {
-		validationErrors["phone_number"] = append(validationErrors["phone_number"], "Phone Number Wajib Diisi")
+		validationErrors["phone_number"] = append(validationErrors["phone_number"], helpers.Message(c, helpers.MsgFieldRequired, "Phone Number"))
 	}
 	if newUser.Password == "" {
-		validationErrors["password"] = append(validationErrors["password"], "Password Wajib Diisi")
+		validationErrors["password"] = append(validationErrors["password"], helpers.Message(c, helpers.MsgFieldRequired, "Password"))
 	}
 	if newUser.Email == "" {
-		validationErrors["email"] = append(validationErrors["email"], "Email Wajib Diisi")
+		validationErrors["email"] = append(validationErrors["email"], helpers.Message(c, helpers.MsgFieldRequired, "Email"))
 	}
 	if newUser.Role == "" {
-		validationErrors["role"] = append(validationErrors["role"], "Role Wajib Diisi")
+		validationErrors["role"] = append(validationErrors["role"], helpers.Message(c, helpers.MsgFieldRequired, "Role"))
 	}
 
 	// Jika ada error, kirim response
 	if len(validationErrors) > 0 {
-		response := helpers.ResponseError{
-			Code:   fiber.StatusBadRequest,
-			Status: "Bad Request",
-			Error:  validationErrors,
-		}
+		response := helpers.NewResponseError(c, validationErrors)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
@@ -278,11 +231,7 @@ func CreateUser(c *fiber.Ctx) error {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newUser.Password), bcrypt.DefaultCost)
 	if err != nil {
 		// Jika terjadi kesalahan dalam menghasilkan hash password, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    500,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, 500, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -295,25 +244,16 @@ func CreateUser(c *fiber.Ctx) error {
 		Password:    string(hashedPassword),
 		Email:       newUser.Email,
 		Role:        newUser.Role,
-		FileId:      1,
 	}
 	// Buat pengguna baru di database
 	if err := initialize.DB.Create(&user).Error; err != nil {
 		// Jika terjadi kesalahan dalam membuat pengguna baru, kirim respons kesalahan ke klien
-		response := helpers.ResponseMassage{
-			Code:    500,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, 500, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Jika pembuatan pengguna berhasil, kirim respons sukses ke klien
-	response := helpers.ResponseMassage{
-		Code:    200,
-		Status:  "OK",
-		Message: "Pengguna berhasil dibuat",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusCreated, helpers.MsgUserCreated)
 	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
@@ -324,11 +264,7 @@ func DeleteUser(c *fiber.Ctx) error {
 	// Konversi ID pengguna dari string ke integer
 	userId, err := strconv.ParseInt(userIdStr, 10, 64)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    400,
-			Status:  "Bad Request",
-			Message: "ID pengguna tidak valid",
-		}
+		response := helpers.NewResponseMassage(c, 400, helpers.MsgInvalidUserID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
@@ -338,31 +274,19 @@ func DeleteUser(c *fiber.Ctx) error {
 	// Cari pengguna dengan ID yang sesuai dalam database
 	if err := initialize.DB.Where("user_id = ?", userId).First(&user).Error; err != nil {
 		// Jika pengguna tidak ditemukan, kirim respons ke klien
-		response := helpers.ResponseMassage{
-			Code:    404,
-			Status:  "Not Found",
-			Message: "Pengguna tidak ditemukan",
-		}
+		response := helpers.NewResponseMassage(c, 404, helpers.MsgUserNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
 	// Hapus pengguna dari database
 	if err := initialize.DB.Delete(&user).Error; err != nil {
 		// Jika terjadi kesalahan saat menghapus, kirim respons ke klien
-		response := helpers.ResponseMassage{
-			Code:    500,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, 500, helpers.MsgInternalError)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Jika pengguna berhasil dihapus, kirim respons sukses ke klien
-	response := helpers.ResponseMassage{
-		Code:    200,
-		Status:  "OK",
-		Message: "Pengguna berhasil dihapus",
-	}
+	response := helpers.NewResponseMassage(c, 200, helpers.MsgUserDeleted)
 	return c.JSON(response)
 }
 
@@ -370,11 +294,7 @@ func EditUser(c *fiber.Ctx) error {
 	// Ambil data pengguna yang akan diubah dari body permintaan
 	var updatedUser models.User
 	if err := c.BodyParser(&updatedUser); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    400,
-			Status:  "Bad Request",
-			Message: "Gagal mengurai data pengguna yang diperbarui",
-		}
+		response := helpers.NewResponseMassage(c, 400, helpers.MsgInvalidRequestBody)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
@@ -382,41 +302,78 @@ func EditUser(c *fiber.Ctx) error {
 	var existingUser models.User
 	if err := initialize.DB.Where("user_id = ?", updatedUser.UserId).First(&existingUser).Error; err != nil {
 		// Jika pengguna tidak ditemukan, kirim respons ke klien
-		response := helpers.ResponseMassage{
-			Code:    404,
-			Status:  "Not Found",
-			Message: "Pengguna tidak ditemukan",
-		}
+		response := helpers.NewResponseMassage(c, 404, helpers.MsgUserNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
 	// Update data pengguna yang ada dengan data baru
+	if err := applyUserChanges(c, &existingUser, updatedUser); err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusForbidden, err)
+		return c.Status(fiber.StatusForbidden).JSON(response)
+	}
+
+	// Simpan perubahan ke database
+	if err := initialize.DB.Save(&existingUser).Error; err != nil {
+		// Jika terjadi kesalahan saat menyimpan, kirim respons ke klien
+		response := helpers.NewResponseMassage(c, 500, helpers.MsgSaveChangesFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	// Jika pengguna berhasil diubah, kirim respons sukses ke klien
+	response := helpers.NewResponseMassage(c, 200, helpers.MsgUserUpdated)
+	return c.JSON(response)
+}
+
+// applyUserChanges menyalin field profil ke pengguna yang ada.
+// Tier harga hanya diubah jika price_tier dikirim, dan hanya oleh Admin/SuperAdmin karena menentukan harga pelanggan
+func applyUserChanges(c *fiber.Ctx, existingUser *models.User, updatedUser models.User) error {
+	if bodyHasField(c, "price_tier") && updatedUser.PriceTier != existingUser.PriceTier {
+		if !isAdminRequest(c) {
+			return helpers.NewError(helpers.MsgAccessDenied)
+		}
+		existingUser.PriceTier = updatedUser.PriceTier
+	}
 	existingUser.FullName = updatedUser.FullName
 	existingUser.UserName = updatedUser.UserName
 	existingUser.Role = updatedUser.Role
 	existingUser.Email = updatedUser.Email
 	existingUser.PhoneNumber = updatedUser.PhoneNumber
 	existingUser.Address = updatedUser.Address
-	// Jika diperlukan, Anda dapat menambahkan logika untuk mengedit bidang lainnya
+	return nil
+}
 
-	// Simpan perubahan ke database
-	if err := initialize.DB.Save(&existingUser).Error; err != nil {
-		// Jika terjadi kesalahan saat menyimpan, kirim respons ke klien
-		response := helpers.ResponseMassage{
-			Code:    500,
-			Status:  "Internal Server Error",
-			Message: "Terjadi Kesalahan Server saat menyimpan perubahan",
+// bodyHasField memeriksa apakah field dikirim di body JSON, multipart atau urlencoded, walaupun nilainya kosong
+func bodyHasField(c *fiber.Ctx, name string) bool {
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(c.Body(), &fields); err != nil {
+			return false
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(response)
+		_, ok := fields[name]
+		return ok
+	}
+	if form, err := c.MultipartForm(); err == nil {
+		_, ok := form.Value[name]
+		return ok
 	}
+	return c.Request().PostArgs().Has(name)
+}
 
-	// Jika pengguna berhasil diubah, kirim respons sukses ke klien
-	response := helpers.ResponseMassage{
-		Code:    200,
-		Status:  "OK",
-		Message: "Pengguna berhasil diperbarui",
+// userExportKeys adalah kolom ekspor datatable pengguna, kata sandi tidak pernah ikut diekspor
+var userExportKeys = []string{"user_id", "full_name", "username", "email", "phone_number", "address", "role", "created_at", "updated_at"}
+
+func userExportRows(locale string, query *gorm.DB) ([][]interface{}, error) {
+	var users []models.UserResponse
+	if err := query.Find(&users).Error; err != nil {
+		return nil, err
 	}
-	return c.JSON(response)
+	rows := make([][]interface{}, 0, len(users))
+	for _, user := range users {
+		rows = append(rows, []interface{}{
+			user.UserId, user.FullName, user.UserName, user.Email, user.PhoneNumber, user.Address, user.Role, user.CreatedAt, user.UpdatedAt,
+		})
+	}
+	return rows, nil
 }
 
 func UserDatatable(c *fiber.Ctx) error {
@@ -453,6 +410,11 @@ func UserDatatable(c *fiber.Ctx) error {
 			query = query.Order(fmt.Sprintf("%s %s", sortBy, "DESC"))
 		}
 	}
+
+	// Ekspor memakai filter dan urutan yang sama tanpa paginasi
+	if format := c.Query("format"); format != "" {
+		return exportTable(c, format, "users", userExportKeys, query.Order("user_id"), userExportRows)
+	}
 	query = query.Limit(limit).Offset(offset)
 
 	var totalRecords int64
@@ -520,31 +482,10 @@ func CreateUserForm(c *fiber.Ctx) error {
 	// Periksa apakah ada file yang diunggah
 	file, err := c.FormFile("file")
 	if err == nil {
-		// Jika ada file yang diunggah
-		filename := uuid.New().String() + filepath.Ext(file.Filename)
-
-		// Buat entitas File untuk disimpan dalam database
-		fileModel = models.File{
-			Path:      fmt.Sprintf("/public/%s", filename),
-			File_name: filename,
-			Size:      strconv.FormatInt(file.Size, 10),
-			Format:    filepath.Ext(file.Filename),
-		}
-		// Simpan file ke dalam database
-		if err := initialize.DB.Create(&fileModel).Error; err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to save file database",
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(response)
-		}
-		if err := c.SaveFile(file, fmt.Sprintf("./public/%s", filename)); err != nil {
-			response := helpers.ResponseMassage{
-				Code:    fiber.StatusInternalServerError,
-				Status:  "Internal Server Error",
-				Message: "Failed to save file",
-			}
+		// Jika ada file yang diunggah, simpan beserta metadatanya
+		fileModel, err = saveUpload(c, file)
+		if err != nil {
+			response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgFileSaveFailed)
 			return c.Status(fiber.StatusInternalServerError).JSON(response)
 		}
 	}
@@ -552,11 +493,7 @@ func CreateUserForm(c *fiber.Ctx) error {
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to hash password",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgPasswordHashFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -573,83 +510,39 @@ func CreateUserForm(c *fiber.Ctx) error {
 
 	// Tambahkan ID file jika ada file yang diunggah
 	if fileModel.FileId != 0 {
-		user.FileId = fileModel.FileId
+		user.FileId = &fileModel.FileId
 	}
 	// Simpan pengguna ke dalam database
 	if err := initialize.DB.Create(&user).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to save user",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgUserSaveFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
 	// Kirim respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "User saved successfully",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgUserCreated)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 func DeleteUserT(c *fiber.Ctx) error {
 	UserId, err := strconv.ParseInt(c.Query("id"), 10, 64)
 	if err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusBadRequest,
-			Status:  "Bad Request",
-			Message: "Invalid User ID",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidUserID)
 		return c.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
 	// Ambil data produk yang akan dihapus dari database
 	var User models.User
 	if err := initialize.DB.Preload("File").First(&User, UserId).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusNotFound,
-			Status:  "Not Found",
-			Message: "User not found",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgUserNotFound)
 		return c.Status(fiber.StatusNotFound).JSON(response)
 	}
 
-	// Hapus produk dari database
+	// Pengguna dipindahkan ke trash, foto baru dihapus saat purge
 	if err := initialize.DB.Delete(&User).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to delete User",
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(response)
-	}
-
-	// Jika produk memiliki file terkait, hapus file tersebut
-	if err := os.Remove("." + User.File.Path); err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Gagal Menghapus Data Dilocal",
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(response)
-	}
-
-	// Hapus entitas file terkait dari basis data
-	if err := initialize.DB.Delete(&User.File).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Kesalahan Server",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgUserDeleteFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 	// Kirim respons sukses
-	response := helpers.ResponseMassage{
-		Code:    fiber.StatusOK,
-		Status:  "OK",
-		Message: "User Berhasil dihapus",
-	}
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgUserTrashed)
 	return c.Status(fiber.StatusOK).JSON(response)
 }
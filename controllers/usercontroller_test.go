@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/models"
+	"errors"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+func TestApplyUserChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		role        string
+		wantTier    string
+		wantErr     string
+	}{
+		{"json without price_tier keeps tier", fiber.MIMEApplicationJSON, `{"user_id":1,"full_name":"Budi"}`, "", "reseller", ""},
+		{"form without price_tier keeps tier", fiber.MIMEApplicationForm, "user_id=1&full_name=Budi", "", "reseller", ""},
+		{"same tier from customer is allowed", fiber.MIMEApplicationJSON, `{"full_name":"Budi","price_tier":"reseller"}`, "", "reseller", ""},
+		{"customer cannot change tier", fiber.MIMEApplicationJSON, `{"full_name":"Budi","price_tier":"distributor"}`, "Customer", "reseller", helpers.MsgAccessDenied},
+		{"customer cannot clear tier", fiber.MIMEApplicationForm, "full_name=Budi&price_tier=", "", "reseller", helpers.MsgAccessDenied},
+		{"admin changes tier", fiber.MIMEApplicationJSON, `{"full_name":"Budi","price_tier":"distributor"}`, "Admin", "distributor", ""},
+		{"admin clears tier", fiber.MIMEApplicationJSON, `{"full_name":"Budi","price_tier":""}`, "SuperAdmin", "", ""},
+	}
+
+	app := fiber.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := app.AcquireCtx(&fasthttp.RequestCtx{})
+			defer app.ReleaseCtx(c)
+			c.Request().Header.SetContentType(tt.contentType)
+			c.Request().SetBodyString(tt.body)
+			if tt.role != "" {
+				c.Locals("role", tt.role)
+			}
+
+			var updated models.User
+			if err := c.BodyParser(&updated); err != nil {
+				t.Fatal(err)
+			}
+			existing := models.User{FullName: "Budi Santoso", PriceTier: "reseller"}
+			err := applyUserChanges(c, &existing, updated)
+			if tt.wantErr != "" {
+				var appErr *helpers.AppError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantErr {
+					t.Fatalf("err = %v, want %s", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if existing.PriceTier != tt.wantTier {
+				t.Errorf("price tier = %q, want %q", existing.PriceTier, tt.wantTier)
+			}
+		})
+	}
+}
@@ -4,22 +4,134 @@ import (
 	"Matahariled/helpers"
 	"Matahariled/initialize"
 	"Matahariled/models"
+	"errors"
 	"fmt"
+	"log"
 	"math"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
+// newVideo menormalkan input video (URL/ID YouTube atau Vimeo) dan membuat iframe kanonik di server
+func newVideo(title string, input string) (models.Video, error) {
+	source, err := helpers.ParseVideoSource(input)
+	if err != nil {
+		return models.Video{}, err
+	}
+
+	video := models.Video{
+		Title:           strings.TrimSpace(title),
+		Provider:        source.Provider,
+		ProviderVideoId: source.Id,
+		Url:             source.WatchURL(),
+	}
+
+	// Kegagalan oEmbed tidak menggagalkan penyimpanan video
+	if meta, err := helpers.FetchOEmbed(source); err != nil {
+		log.Printf("oembed %s/%s: %v", source.Provider, source.Id, err)
+	} else {
+		if video.Title == "" {
+			video.Title = meta.Title
+		}
+		if strings.HasPrefix(meta.ThumbnailURL, "https://") || strings.HasPrefix(meta.ThumbnailURL, "http://") {
+			video.ThumbnailUrl = meta.ThumbnailURL
+		}
+	}
+
+	video.Embed = source.EmbedHTML(video.Title)
+	return video, nil
+}
+
+// findOrPrepareVideo memakai ulang video di pustaka jika provider dan ID-nya sama, atau menyiapkan video baru
+// beserta metadata oEmbed tanpa menyimpannya. Dipanggil di luar transaksi karena oEmbed adalah permintaan HTTP
+func findOrPrepareVideo(title string, input string) (models.Video, error) {
+	source, err := helpers.ParseVideoSource(input)
+	if err != nil {
+		return models.Video{}, err
+	}
+
+	var existing models.Video
+	if err := initialize.DB.Where("provider = ? AND provider_video_id = ?", source.Provider, source.Id).First(&existing).Error; err == nil {
+		return existing, nil
+	}
+	return newVideo(title, input)
+}
+
+// saveVideo menyimpan video dari findOrPrepareVideo jika belum ada di pustaka.
+// Video yang ditambahkan request lain sejak disiapkan dipakai ulang
+func saveVideo(tx *gorm.DB, video *models.Video) error {
+	if video.VideoId != 0 {
+		return nil
+	}
+	var existing models.Video
+	if err := tx.Where("provider = ? AND provider_video_id = ?", video.Provider, video.ProviderVideoId).First(&existing).Error; err == nil {
+		*video = existing
+		return nil
+	}
+	return tx.Create(video).Error
+}
+
+// videosFromForm membaca video_ids[] dari form. present bernilai false jika field tidak dikirim sama sekali
+func videosFromForm(c *fiber.Ctx) (videos []models.Video, present bool, err error) {
+	var values []string
+	if form, formErr := c.MultipartForm(); formErr == nil {
+		values, present = form.Value["video_ids[]"]
+	} else if args := c.Request().PostArgs(); args.Has("video_ids[]") {
+		present = true
+		for _, v := range args.PeekMulti("video_ids[]") {
+			values = append(values, string(v))
+		}
+	}
+
+	videos = []models.Video{}
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			videoId, err := strconv.ParseInt(part, 10, 64)
+			if err != nil {
+				return nil, present, helpers.NewError(helpers.MsgInvalidVideoIDValue, part)
+			}
+			var video models.Video
+			if err := initialize.DB.First(&video, videoId).Error; err != nil {
+				return nil, present, helpers.NewError(helpers.MsgVideoIDNotFound, videoId)
+			}
+			videos = append(videos, video)
+		}
+	}
+	return videos, present, nil
+}
+
+// firstVideo dipakai untuk field embed/video_title lama yang hanya mendukung satu video
+func firstVideo(videos []models.Video) models.Video {
+	if len(videos) == 0 {
+		return models.Video{}
+	}
+	return videos[0]
+}
+
+// videoUsage menghitung berapa banyak riwayat dan produk yang masih memakai video
+func videoUsage(videoId int64) (int64, int64, error) {
+	var historyCount, productCount int64
+	if err := initialize.DB.Model(&models.HistoryVideo{}).Where("video_video_id = ?", videoId).Count(&historyCount).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := initialize.DB.Model(&models.ProductVideo{}).Where("video_video_id = ?", videoId).Count(&productCount).Error; err != nil {
+		return 0, 0, err
+	}
+	return historyCount, productCount, nil
+}
+
 func GetAllVideos(c *fiber.Ctx) error {
 	// Ambil semua video dari database
 	var videos []models.Video
 	if err := initialize.DB.Find(&videos).Error; err != nil {
-		response := helpers.ResponseMassage{
-			Code:    fiber.StatusInternalServerError,
-			Status:  "Internal Server Error",
-			Message: "Failed to fetch videos",
-		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgVideoFetchFailed)
 		return c.Status(fiber.StatusInternalServerError).JSON(response)
 	}
 
@@ -31,6 +143,23 @@ func GetAllVideos(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+// videoExportKeys adalah kolom ekspor datatable video
+var videoExportKeys = []string{"video_id", "video_title", "provider", "url", "thumbnail", "created_at", "updated_at"}
+
+func videoExportRows(locale string, query *gorm.DB) ([][]interface{}, error) {
+	var videos []models.Video
+	if err := query.Find(&videos).Error; err != nil {
+		return nil, err
+	}
+	rows := make([][]interface{}, 0, len(videos))
+	for _, video := range videos {
+		rows = append(rows, []interface{}{
+			video.VideoId, video.Title, video.Provider, video.Url, video.ThumbnailUrl, video.CreatedAt, video.UpdatedAt,
+		})
+	}
+	return rows, nil
+}
+
 func GetDatatableVideos(c *fiber.Ctx) error {
 	// Ambil nilai parameter limit, sort, sort_by, dan search dari query string
 	limit, _ := strconv.Atoi(c.Query("limit"))
@@ -59,6 +188,11 @@ func GetDatatableVideos(c *fiber.Ctx) error {
 		query = query.Order(fmt.Sprintf("%s %s", sortBy, sort))
 	}
 
+	// Ekspor memakai filter dan urutan yang sama tanpa paginasi
+	if format := c.Query("format"); format != "" {
+		return exportTable(c, format, "videos", videoExportKeys, query.Order("video_id"), videoExportRows)
+	}
+
 	var totalRecords int64
 	if err := initialize.DB.Model(&models.Product{}).Count(&totalRecords).Error; err != nil {
 		response := helpers.GeneralResponse{
@@ -105,6 +239,9 @@ func GetDatatableVideos(c *fiber.Ctx) error {
 			"video_id":    video.VideoId,
 			"video_title": video.Title,
 			"embed":       video.Embed,
+			"provider":    video.Provider,
+			"url":         video.Url,
+			"thumbnail":   video.ThumbnailUrl,
 			"created_at":  video.CreatedAt,
 			"updated_at":  video.UpdatedAt,
 		}
@@ -120,3 +257,154 @@ func GetDatatableVideos(c *fiber.Ctx) error {
 		Data:   response,
 	})
 }
+
+func GetVideoById(c *fiber.Ctx) error {
+	// Ambil ID video dari parameter URL
+	videoId := c.Params("id")
+
+	var video models.Video
+	if err := initialize.DB.Where("video_id = ?", videoId).First(&video).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgVideoNotFound)
+			return c.Status(fiber.StatusNotFound).JSON(response)
+		}
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgVideoFetchFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   video,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func CreateVideo(c *fiber.Ctx) error {
+	// Parse body request ke dalam struct VideoRequest
+	var requestBody models.VideoRequest
+	if err := c.BodyParser(&requestBody); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	// Validasi request body
+	if err := validate.Struct(&requestBody); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	video, err := newVideo(requestBody.Title, requestBody.Url)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	// Video yang sama cukup dipakai ulang, tidak perlu disimpan dua kali
+	var existing models.Video
+	if err := initialize.DB.Where("provider = ? AND provider_video_id = ?", video.Provider, video.ProviderVideoId).First(&existing).Error; err == nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgVideoExists, existing.VideoId)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+
+	if err := initialize.DB.Create(&video).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgVideoSaveFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.GeneralResponse{
+		Code:   fiber.StatusOK,
+		Status: "OK",
+		Data:   video,
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func UpdateVideo(c *fiber.Ctx) error {
+	// Ambil ID video dari parameter URL
+	videoId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidVideoID)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	var requestBody models.VideoRequest
+	if err := c.BodyParser(&requestBody); err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidRequestBody)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	// Validasi request body
+	if err := validate.Struct(&requestBody); err != nil {
+		response := helpers.NewResponseError(c, helpers.ValidationMessages(c, err))
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	var video models.Video
+	if err := initialize.DB.First(&video, videoId).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgVideoNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	updated, err := newVideo(requestBody.Title, requestBody.Url)
+	if err != nil {
+		response := helpers.NewErrorMassage(c, fiber.StatusBadRequest, err)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	// Tolak jika tautan baru sudah dipakai video lain di pustaka
+	var existing models.Video
+	if err := initialize.DB.Where("provider = ? AND provider_video_id = ? AND video_id <> ?", updated.Provider, updated.ProviderVideoId, videoId).First(&existing).Error; err == nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgVideoExists, existing.VideoId)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+
+	video.Title = updated.Title
+	video.Provider = updated.Provider
+	video.ProviderVideoId = updated.ProviderVideoId
+	video.Url = updated.Url
+	video.ThumbnailUrl = updated.ThumbnailUrl
+	video.Embed = updated.Embed
+
+	if err := initialize.DB.Save(&video).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgVideoUpdateFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgVideoUpdated)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func DeleteVideo(c *fiber.Ctx) error {
+	// Ambil ID video dari parameter URL
+	videoId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusBadRequest, helpers.MsgInvalidVideoID)
+		return c.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	var video models.Video
+	if err := initialize.DB.First(&video, videoId).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusNotFound, helpers.MsgVideoNotFound)
+		return c.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	// Video yang masih dipakai riwayat atau produk tidak boleh dihapus
+	historyCount, productCount, err := videoUsage(videoId)
+	if err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgInternalError)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+	if historyCount > 0 || productCount > 0 {
+		response := helpers.NewResponseMassage(c, fiber.StatusConflict, helpers.MsgVideoInUse, historyCount, productCount)
+		return c.Status(fiber.StatusConflict).JSON(response)
+	}
+
+	if err := initialize.DB.Delete(&video).Error; err != nil {
+		response := helpers.NewResponseMassage(c, fiber.StatusInternalServerError, helpers.MsgVideoDeleteFailed)
+		return c.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	response := helpers.NewResponseMassage(c, fiber.StatusOK, helpers.MsgVideoDeleted)
+	return c.Status(fiber.StatusOK).JSON(response)
+}
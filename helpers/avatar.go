@@ -0,0 +1,97 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Warna latar avatar default, dipilih berdasarkan nama agar konsisten untuk pengguna yang sama
+var avatarPalette = []string{"#F59E0B", "#EF4444", "#10B981", "#3B82F6", "#8B5CF6", "#EC4899", "#14B8A6", "#F97316"}
+
+// AvatarInitials mengambil maksimal dua huruf awal dari nama lengkap
+func AvatarInitials(name string) string {
+	var initials []rune
+	for _, word := range strings.Fields(name) {
+		for _, r := range word {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				initials = append(initials, unicode.ToUpper(r))
+				break
+			}
+		}
+		if len(initials) == 2 {
+			break
+		}
+	}
+	if len(initials) == 0 {
+		return "?"
+	}
+	return string(initials)
+}
+
+// InitialsAvatarSVG membuat avatar SVG berisi inisial pengguna
+func InitialsAvatarSVG(name string, size int) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(strings.ToLower(strings.TrimSpace(name))))
+	color := avatarPalette[hash.Sum32()%uint32(len(avatarPalette))]
+
+	return fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 100 100">`+
+			`<rect width="100" height="100" fill="%s"/>`+
+			`<text x="50" y="50" dy=".35em" text-anchor="middle" font-family="Arial, Helvetica, sans-serif" font-size="42" fill="#FFFFFF">%s</text>`+
+			`</svg>`,
+		size, size, color, html.EscapeString(AvatarInitials(name)),
+	)
+}
+
+// CropImageFile memotong gambar JPEG/PNG pada rect lalu menyimpannya kembali dengan format yang sama
+func CropImageFile(path string, rect image.Rectangle) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	rect = rect.Add(src.Bounds().Min).Intersect(src.Bounds())
+	if rect.Empty() {
+		return NewError(MsgCropOutOfBounds)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, rect.Min, draw.Src)
+
+	var out bytes.Buffer
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&out, dst, &jpeg.Options{Quality: 92})
+	case "png":
+		err = png.Encode(&out, dst)
+	default:
+		return NewError(MsgCropUnsupportedFormat, format)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, out.Bytes(), 0644)
+}
+
+// CenterSquare menghasilkan area persegi terbesar di tengah gambar berukuran width x height
+func CenterSquare(width int, height int) image.Rectangle {
+	side := width
+	if height < side {
+		side = height
+	}
+	x := (width - side) / 2
+	y := (height - side) / 2
+	return image.Rect(x, y, x+side, y+side)
+}
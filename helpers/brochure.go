@@ -0,0 +1,380 @@
+package helpers
+
+import (
+	"Matahariled/models"
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// BrochureBranding adalah identitas perusahaan yang dicetak di kop dan kaki setiap halaman brosur
+type BrochureBranding struct {
+	CompanyName string `json:"company_name"`
+	Tagline     string `json:"tagline"`
+	Address     string `json:"address"`
+	Phone       string `json:"phone"`
+	Email       string `json:"email"`
+	Website     string `json:"website"`
+	LogoPath    string `json:"logo_path"`
+	Color       string `json:"color"`
+}
+
+// BrandingFromEnv membaca identitas perusahaan dari variabel lingkungan COMPANY_* dan BROCHURE_COLOR
+func BrandingFromEnv() BrochureBranding {
+	branding := BrochureBranding{
+		CompanyName: os.Getenv("COMPANY_NAME"),
+		Tagline:     os.Getenv("COMPANY_TAGLINE"),
+		Address:     os.Getenv("COMPANY_ADDRESS"),
+		Phone:       os.Getenv("COMPANY_PHONE"),
+		Email:       os.Getenv("COMPANY_EMAIL"),
+		Website:     os.Getenv("COMPANY_WEBSITE"),
+		LogoPath:    os.Getenv("COMPANY_LOGO"),
+		Color:       os.Getenv("BROCHURE_COLOR"),
+	}
+	if branding.CompanyName == "" {
+		branding.CompanyName = "Matahari LED"
+	}
+	if branding.Color == "" {
+		branding.Color = "#F59E0B"
+	}
+	return branding
+}
+
+const (
+	brochureMargin       = 15.0
+	brochureHeaderHeight = 20.0
+	brochureImageMaxSide = 1600
+	brochureGalleryMax   = 9
+)
+
+// brochureDocument adalah satu file PDF beserta template kop dan kaki halamannya
+type brochureDocument struct {
+	pdf      *gofpdf.Fpdf
+	tr       func(string) string
+	branding BrochureBranding
+	locale   string
+	color    [3]int
+	// images mencatat gambar yang sudah dimuat per path, nil jika gambar tidak dapat dibaca
+	images map[string]*gofpdf.ImageInfoType
+}
+
+func newBrochureDocument(branding BrochureBranding, locale string, title string) *brochureDocument {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	d := &brochureDocument{
+		pdf:      pdf,
+		tr:       pdf.UnicodeTranslatorFromDescriptor(""),
+		branding: branding,
+		locale:   locale,
+		color:    brochureColor(branding.Color),
+		images:   make(map[string]*gofpdf.ImageInfoType),
+	}
+	pdf.SetTitle(title, true)
+	pdf.SetAuthor(branding.CompanyName, true)
+	pdf.SetCreator(branding.CompanyName, true)
+	pdf.SetMargins(brochureMargin, brochureHeaderHeight+8, brochureMargin)
+	pdf.SetAutoPageBreak(true, 22)
+	pdf.AliasNbPages("")
+	pdf.SetHeaderFunc(d.header)
+	pdf.SetFooterFunc(d.footer)
+	return d
+}
+
+// brochureColor membaca warna hex #RRGGBB, warna tidak valid memakai warna bawaan
+func brochureColor(hex string) [3]int {
+	hex = strings.TrimPrefix(hex, "#")
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 6 || err != nil {
+		return [3]int{0xF5, 0x9E, 0x0B}
+	}
+	return [3]int{int(value >> 16 & 0xFF), int(value >> 8 & 0xFF), int(value & 0xFF)}
+}
+
+// header mencetak kop berwarna berisi logo, nama dan tagline perusahaan
+func (d *brochureDocument) header() {
+	pdf := d.pdf
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.SetFillColor(d.color[0], d.color[1], d.color[2])
+	pdf.Rect(0, 0, pageWidth, brochureHeaderHeight, "F")
+
+	x := brochureMargin
+	if d.branding.LogoPath != "" {
+		if width := d.image(d.branding.LogoPath, x, 4, 40, brochureHeaderHeight-8, false); width > 0 {
+			x += width + 4
+		}
+	}
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.SetXY(x, 5)
+	pdf.CellFormat(pageWidth-x-brochureMargin, 6, d.tr(d.branding.CompanyName), "", 2, "L", false, 0, "")
+	if d.branding.Tagline != "" {
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(pageWidth-x-brochureMargin, 5, d.tr(d.branding.Tagline), "", 0, "L", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetY(brochureHeaderHeight + 8)
+}
+
+// footer mencetak kontak perusahaan dan nomor halaman
+func (d *brochureDocument) footer() {
+	pdf := d.pdf
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.SetY(-16)
+	pdf.SetDrawColor(d.color[0], d.color[1], d.color[2])
+	pdf.SetLineWidth(0.4)
+	pdf.Line(brochureMargin, pdf.GetY(), pageWidth-brochureMargin, pdf.GetY())
+
+	var contacts []string
+	for _, contact := range []string{d.branding.Address, d.branding.Phone, d.branding.Email, d.branding.Website} {
+		if contact != "" {
+			contacts = append(contacts, contact)
+		}
+	}
+	pdf.SetY(-14)
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 5, d.tr(strings.Join(contacts, " · ")), "", 0, "L", false, 0, "")
+	pdf.SetX(brochureMargin)
+	pdf.CellFormat(0, 5, d.tr(MessageIn(d.locale, MsgBrochurePage, pdf.PageNo(), "{nb}")), "", 0, "R", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// image mencetak gambar di tengah kotak x, y, maxWidth x maxHeight dengan rasio tetap dan mengembalikan lebar yang dipakai.
+// Gambar yang hilang atau tidak dapat dibaca dilewati agar brosur tetap bisa dibuat.
+func (d *brochureDocument) image(path string, x, y, maxWidth, maxHeight float64, center bool) float64 {
+	// Gambar yang sama, misalnya logo di setiap halaman, hanya disematkan sekali
+	options := gofpdf.ImageOptions{ImageType: "JPG"}
+	info, ok := d.images[path]
+	if !ok {
+		if data, err := brochureJPEG(path); err == nil {
+			info = d.pdf.RegisterImageOptionsReader(path, options, bytes.NewReader(data))
+		}
+		d.images[path] = info
+	}
+	if info == nil || info.Width() == 0 || info.Height() == 0 {
+		return 0
+	}
+
+	width, height := maxWidth, maxWidth*info.Height()/info.Width()
+	if height > maxHeight {
+		width, height = maxHeight*info.Width()/info.Height(), maxHeight
+	}
+	if center {
+		x += (maxWidth - width) / 2
+		y += (maxHeight - height) / 2
+	}
+	d.pdf.ImageOptions(path, x, y, width, height, false, options, 0, "")
+	return width
+}
+
+// brochureJPEG membaca gambar dari disk, meratakan transparansi ke latar putih, memperkecil gambar besar
+// lalu menyimpannya sebagai JPEG sehingga semua format yang dapat dibaca aman dicetak
+func brochureJPEG(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	src, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, shrinkImage(flat, brochureImageMaxSide), &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// shrinkImage memperkecil gambar dengan rata-rata area sampai sisi terpanjang tidak melebihi maxSide
+func shrinkImage(src *image.RGBA, maxSide int) *image.RGBA {
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	if width <= maxSide && height <= maxSide {
+		return src
+	}
+	dstWidth, dstHeight := maxSide, height*maxSide/width
+	if height > width {
+		dstWidth, dstHeight = width*maxSide/height, maxSide
+	}
+	if dstWidth < 1 {
+		dstWidth = 1
+	}
+	if dstHeight < 1 {
+		dstHeight = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := y*height/dstHeight, (y+1)*height/dstHeight
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := x*width/dstWidth, (x+1)*width/dstWidth
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					for i := 0; i < 4; i++ {
+						sum[i] += int(row[sx*4+i])
+					}
+				}
+			}
+			count := (y1 - y0) * (x1 - x0)
+			offset := y*dst.Stride + x*4
+			for i := 0; i < 4; i++ {
+				dst.Pix[offset+i] = uint8(sum[i] / count)
+			}
+		}
+	}
+	return dst
+}
+
+// section mencetak judul bagian berwarna dengan garis bawah
+func (d *brochureDocument) section(title string) {
+	pdf := d.pdf
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.SetTextColor(d.color[0], d.color[1], d.color[2])
+	pdf.CellFormat(0, 7, d.tr(title), "", 1, "L", false, 0, "")
+	pdf.SetDrawColor(220, 220, 220)
+	pdf.SetLineWidth(0.2)
+	pdf.Line(brochureMargin, pdf.GetY(), pageWidth-brochureMargin, pdf.GetY())
+	pdf.Ln(2)
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// paragraph mencetak teks panjang dengan baris baru yang dipertahankan
+func (d *brochureDocument) paragraph(text string) {
+	d.pdf.SetFont("Helvetica", "", 10)
+	d.pdf.MultiCell(0, 5, d.tr(strings.TrimSpace(text)), "", "L", false)
+}
+
+// productPages mencetak satu produk mulai dari halaman baru
+func (d *brochureDocument) productPages(product models.BrochureProduct) {
+	pdf := d.pdf
+	pageWidth, pageHeight := pdf.GetPageSize()
+	contentWidth := pageWidth - 2*brochureMargin
+	pdf.AddPage()
+	pdf.Bookmark(d.tr(product.Title), 0, -1)
+
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.MultiCell(0, 9, d.tr(product.Title), "", "L", false)
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(100, 100, 100)
+	meta := product.Category
+	if product.UpdatedAt != "" {
+		if meta != "" {
+			meta += " · "
+		}
+		meta += MessageIn(d.locale, MsgBrochureUpdated, product.UpdatedAt)
+	}
+	pdf.CellFormat(0, 6, d.tr(meta), "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	if product.Image != nil {
+		y := pdf.GetY() + 3
+		if d.image("."+product.Image.Path, brochureMargin, y, contentWidth, 95, true) > 0 {
+			pdf.SetY(y + 98)
+		}
+	}
+
+	if strings.TrimSpace(product.Description) != "" {
+		d.section(MessageIn(d.locale, MsgBrochureDescription))
+		d.paragraph(product.Description)
+	}
+	if strings.TrimSpace(product.Specification) != "" {
+		d.section(MessageIn(d.locale, MsgBrochureSpecification))
+		d.paragraph(product.Specification)
+	}
+
+	if len(product.Attributes) > 0 {
+		d.section(MessageIn(d.locale, MsgBrochureAttributes))
+		pdf.SetFillColor(245, 245, 245)
+		for i, attribute := range product.Attributes {
+			fill := i%2 == 0
+			pdf.SetFont("Helvetica", "B", 10)
+			pdf.CellFormat(contentWidth*0.4, 7, d.tr(attribute.Label), "", 0, "L", fill, 0, "")
+			pdf.SetFont("Helvetica", "", 10)
+			pdf.CellFormat(contentWidth*0.6, 7, d.tr(attribute.Value), "", 1, "L", fill, 0, "")
+		}
+	}
+
+	gallery := product.Gallery
+	if len(gallery) > brochureGalleryMax {
+		gallery = gallery[:brochureGalleryMax]
+	}
+	if len(gallery) > 0 {
+		const columns, gap, boxHeight = 3, 6.0, 42.0
+		boxWidth := (contentWidth - gap*(columns-1)) / columns
+		_, _, _, bottom := pdf.GetMargins()
+		// Judul galeri tidak ditinggal sendirian di akhir halaman
+		if pdf.GetY()+boxHeight+15 > pageHeight-bottom {
+			pdf.AddPage()
+		}
+		d.section(MessageIn(d.locale, MsgBrochureGallery))
+		for i := 0; i < len(gallery); i += columns {
+			// Satu baris galeri tidak boleh terpotong di pergantian halaman
+			if pdf.GetY()+boxHeight > pageHeight-bottom {
+				pdf.AddPage()
+			}
+			y := pdf.GetY()
+			for j := 0; j < columns && i+j < len(gallery); j++ {
+				x := brochureMargin + float64(j)*(boxWidth+gap)
+				d.image("."+gallery[i+j].Path, x, y, boxWidth, boxHeight, true)
+			}
+			pdf.SetY(y + boxHeight + gap)
+		}
+	}
+}
+
+// coverPage mencetak sampul katalog
+func (d *brochureDocument) coverPage(title string, subtitle string) {
+	pdf := d.pdf
+	pdf.AddPage()
+	pdf.SetY(100)
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.SetTextColor(d.color[0], d.color[1], d.color[2])
+	pdf.CellFormat(0, 8, d.tr(MessageIn(d.locale, MsgBrochureCatalog)), "", 1, "C", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 28)
+	pdf.MultiCell(0, 13, d.tr(title), "", "C", false)
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 8, d.tr(subtitle), "", 1, "C", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+}
+
+func (d *brochureDocument) output(w io.Writer) error {
+	if err := d.pdf.Error(); err != nil {
+		return err
+	}
+	return d.pdf.Output(w)
+}
+
+// RenderProductBrochure membuat brosur PDF satu produk
+func RenderProductBrochure(w io.Writer, branding BrochureBranding, locale string, product models.BrochureProduct) error {
+	d := newBrochureDocument(branding, locale, product.Title)
+	d.productPages(product)
+	return d.output(w)
+}
+
+// RenderCatalog membuat katalog PDF berisi sampul lalu satu bagian untuk setiap produk
+func RenderCatalog(w io.Writer, branding BrochureBranding, locale string, title string, products []models.BrochureProduct) error {
+	d := newBrochureDocument(branding, locale, MessageIn(locale, MsgBrochureCatalog)+" "+title)
+	d.coverPage(title, MessageIn(locale, MsgBrochureProductCount, len(products)))
+	for _, product := range products {
+		d.productPages(product)
+	}
+	return d.output(w)
+}
@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     []DiffLine
+	}{
+		{"both empty", "", "", nil},
+		{"added text", "", "a\nb", []DiffLine{{DiffInsert, "a"}, {DiffInsert, "b"}}},
+		{"removed text", "a", "", []DiffLine{{DiffDelete, "a"}}},
+		{"changed middle line", "a\nb\nc", "a\nx\nc", []DiffLine{{DiffEqual, "a"}, {DiffDelete, "b"}, {DiffInsert, "x"}, {DiffEqual, "c"}}},
+		{"windows line endings", "a\r\nb", "a\nb\nc", []DiffLine{{DiffEqual, "a"}, {DiffEqual, "b"}, {DiffInsert, "c"}}},
+		{"moved line", "a\nb\nc", "b\nc\na", []DiffLine{{DiffDelete, "a"}, {DiffEqual, "b"}, {DiffEqual, "c"}, {DiffInsert, "a"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffLines(tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffLines = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
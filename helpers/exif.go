@@ -0,0 +1,629 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"strings"
+	"time"
+)
+
+// ExifData berisi informasi EXIF yang relevan sebelum metadata dihapus
+type ExifData struct {
+	Orientation int
+	CapturedAt  *time.Time
+	Latitude    *float64
+	Longitude   *float64
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// SanitizeImageFile memutar gambar sesuai tag orientasi lalu menghapus seluruh metadata EXIF/XMP/IPTC.
+// WebP dan HEIC/AVIF hanya dibersihkan metadatanya; file selain gambar dibiarkan apa adanya.
+func SanitizeImageFile(path string) (ExifData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ExifData{}, err
+	}
+
+	var exif ExifData
+	var cleaned []byte
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8}):
+		exif = parseExif(jpegExifPayload(data))
+		if exif.Orientation > 1 {
+			cleaned, err = reorientImage(data, exif.Orientation, "jpeg")
+		} else {
+			cleaned, err = stripJpegMetadata(data)
+		}
+	case bytes.HasPrefix(data, pngSignature):
+		exif = parseExif(pngExifPayload(data))
+		if exif.Orientation > 1 {
+			cleaned, err = reorientImage(data, exif.Orientation, "png")
+		} else {
+			cleaned, err = stripPngMetadata(data)
+		}
+	case isWebP(data):
+		exif = parseExif(webpExifPayload(data))
+		cleaned, err = stripWebPMetadata(data)
+	case isHeif(data):
+		// HEIC/AVIF tidak bisa di-encode ulang tanpa decoder, isi item Exif/XMP dikosongkan di tempat
+		var payload []byte
+		payload, cleaned, err = wipeHeifMetadata(data)
+		exif = parseExif(payload)
+	default:
+		return ExifData{}, nil
+	}
+	if err != nil {
+		return exif, err
+	}
+
+	return exif, os.WriteFile(path, cleaned, 0644)
+}
+
+// jpegExifPayload mengambil isi segmen APP1 Exif (header TIFF) dari JPEG
+func jpegExifPayload(data []byte) []byte {
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil
+		}
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		// Panjang segmen sudah termasuk dua byte panjang itu sendiri
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if length < 2 || pos+2+length > len(data) {
+			return nil
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		pos += 2 + length
+	}
+	return nil
+}
+
+// pngExifPayload mengambil isi chunk eXIf dari PNG
+func pngExifPayload(data []byte) []byte {
+	pos := len(pngSignature)
+	for pos+8 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		kind := string(data[pos+4 : pos+8])
+		if pos+12+length > len(data) {
+			return nil
+		}
+		if kind == "eXIf" {
+			return data[pos+8 : pos+8+length]
+		}
+		pos += 12 + length
+	}
+	return nil
+}
+
+// stripJpegMetadata menyalin JPEG tanpa segmen APP1 (EXIF/XMP), APP13 (IPTC) dan komentar
+func stripJpegMetadata(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil, errors.New("invalid jpeg marker")
+		}
+		marker := data[pos+1]
+		// Setelah SOS seluruh sisa file adalah data gambar
+		if marker == 0xDA {
+			out.Write(data[pos:])
+			return out.Bytes(), nil
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if length < 2 {
+			return nil, errors.New("invalid jpeg segment length")
+		}
+		if pos+2+length > len(data) {
+			return nil, errors.New("truncated jpeg segment")
+		}
+		if marker != 0xE1 && marker != 0xED && marker != 0xFE {
+			out.Write(data[pos : pos+2+length])
+		}
+		pos += 2 + length
+	}
+	out.Write(data[pos:])
+	return out.Bytes(), nil
+}
+
+// isWebP mengenali container RIFF berjenis WEBP
+func isWebP(data []byte) bool {
+	return len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP"
+}
+
+// webpChunks memanggil fn untuk setiap chunk WebP; end sudah termasuk byte padding
+func webpChunks(data []byte, fn func(kind string, payload []byte, start int, end int)) error {
+	pos := 12
+	for pos+8 <= len(data) {
+		kind := string(data[pos : pos+4])
+		length := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		end := pos + 8 + length + length%2
+		if pos+8+length > len(data) {
+			return errors.New("truncated webp chunk")
+		}
+		if end > len(data) {
+			end = len(data)
+		}
+		fn(kind, data[pos+8:pos+8+length], pos, end)
+		pos = end
+	}
+	return nil
+}
+
+// webpExifPayload mengambil isi chunk EXIF dari WebP, sebagian encoder menambahkan awalan "Exif\x00\x00"
+func webpExifPayload(data []byte) []byte {
+	var payload []byte
+	webpChunks(data, func(kind string, chunk []byte, start int, end int) {
+		if kind == "EXIF" && payload == nil {
+			payload = bytes.TrimPrefix(chunk, []byte("Exif\x00\x00"))
+		}
+	})
+	return payload
+}
+
+// stripWebPMetadata menyalin WebP tanpa chunk EXIF dan XMP lalu menghapus flag keduanya pada VP8X
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:12])
+	err := webpChunks(data, func(kind string, chunk []byte, start int, end int) {
+		switch kind {
+		case "EXIF", "XMP ":
+			return
+		case "VP8X":
+			if len(chunk) > 0 {
+				header := out.Len()
+				out.Write(data[start:end])
+				// Bit 3 adalah EXIF dan bit 2 adalah XMP
+				out.Bytes()[header+8] &^= 0x0C
+				return
+			}
+		}
+		out.Write(data[start:end])
+	})
+	if err != nil {
+		return nil, err
+	}
+	cleaned := out.Bytes()
+	binary.LittleEndian.PutUint32(cleaned[4:8], uint32(len(cleaned)-8))
+	return cleaned, nil
+}
+
+// heifBrands adalah brand ftyp untuk gambar HEIF (termasuk HEIC) dan AVIF
+var heifBrands = map[string]bool{
+	"heic": true, "heix": true, "hevc": true, "hevx": true, "heim": true, "heis": true,
+	"mif1": true, "msf1": true, "avif": true, "avis": true,
+}
+
+func isHeif(data []byte) bool {
+	return len(data) >= 12 && string(data[4:8]) == "ftyp" && heifBrands[string(data[8:12])]
+}
+
+// isoBox adalah satu box ISOBMFF; payload tidak termasuk header
+type isoBox struct {
+	kind    string
+	payload []byte
+}
+
+// isoBoxes membaca box-box berurutan dalam data
+func isoBoxes(data []byte) []isoBox {
+	var boxes []isoBox
+	pos := 0
+	for pos+8 <= len(data) {
+		size := uint64(binary.BigEndian.Uint32(data[pos : pos+4]))
+		kind := string(data[pos+4 : pos+8])
+		header := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data) - pos)
+		case 1:
+			if pos+16 > len(data) {
+				return boxes
+			}
+			size = binary.BigEndian.Uint64(data[pos+8 : pos+16])
+			header = 16
+		}
+		if size < header || uint64(pos)+size > uint64(len(data)) {
+			return boxes
+		}
+		boxes = append(boxes, isoBox{kind: kind, payload: data[uint64(pos)+header : uint64(pos)+size]})
+		pos += int(size)
+	}
+	return boxes
+}
+
+// heifField membaca bilangan big endian sepanjang size byte (0, 2, 4 atau 8) dan memajukan posisi
+func heifField(data []byte, pos *int, size int) (uint64, bool) {
+	if *pos+size > len(data) {
+		return 0, false
+	}
+	var value uint64
+	for _, b := range data[*pos : *pos+size] {
+		value = value<<8 | uint64(b)
+	}
+	*pos += size
+	return value, true
+}
+
+// heifMetadataItems mencari ID item Exif dan XMP (mime application/rdf+xml) pada box iinf
+func heifMetadataItems(iinf []byte) (map[uint64]bool, uint64) {
+	items := make(map[uint64]bool)
+	var exifItem uint64
+	if len(iinf) < 4 {
+		return items, 0
+	}
+	pos := 4
+	countSize := 2
+	if iinf[0] != 0 {
+		countSize = 4
+	}
+	if _, ok := heifField(iinf, &pos, countSize); !ok {
+		return items, 0
+	}
+	for _, box := range isoBoxes(iinf[pos:]) {
+		if box.kind != "infe" || len(box.payload) < 4 || box.payload[0] < 2 {
+			continue
+		}
+		p := 4
+		idSize := 2
+		if box.payload[0] == 3 {
+			idSize = 4
+		}
+		id, ok := heifField(box.payload, &p, idSize)
+		if !ok || p+6 > len(box.payload) {
+			continue
+		}
+		p += 2
+		itemType := string(box.payload[p : p+4])
+		p += 4
+		switch itemType {
+		case "Exif":
+			items[id] = true
+			if exifItem == 0 {
+				exifItem = id
+			}
+		case "mime":
+			// item_name lalu content_type, keduanya diakhiri byte nol
+			fields := bytes.SplitN(box.payload[p:], []byte{0}, 3)
+			if len(fields) >= 2 && string(fields[1]) == "application/rdf+xml" {
+				items[id] = true
+			}
+		}
+	}
+	return items, exifItem
+}
+
+// wipeHeifMetadata mengosongkan isi item Exif dan XMP pada HEIF/AVIF tanpa mengubah struktur file,
+// lalu mengembalikan blok TIFF Exif sebelum dikosongkan
+func wipeHeifMetadata(data []byte) ([]byte, []byte, error) {
+	cleaned := append([]byte(nil), data...)
+	var meta []byte
+	for _, box := range isoBoxes(cleaned) {
+		if box.kind == "meta" && len(box.payload) >= 4 {
+			meta = box.payload[4:]
+			break
+		}
+	}
+	if meta == nil {
+		return nil, cleaned, nil
+	}
+	var iinf, iloc []byte
+	for _, box := range isoBoxes(meta) {
+		switch box.kind {
+		case "iinf":
+			iinf = box.payload
+		case "iloc":
+			iloc = box.payload
+		}
+	}
+	items, exifItem := heifMetadataItems(iinf)
+	if len(items) == 0 || len(iloc) < 6 {
+		return nil, cleaned, nil
+	}
+
+	version := iloc[0]
+	offsetSize, lengthSize := int(iloc[4]>>4), int(iloc[4]&0x0F)
+	baseOffsetSize, indexSize := int(iloc[5]>>4), 0
+	if version == 1 || version == 2 {
+		indexSize = int(iloc[5] & 0x0F)
+	}
+	pos := 6
+	countSize, idSize := 2, 2
+	if version == 2 {
+		countSize, idSize = 4, 4
+	}
+	itemCount, ok := heifField(iloc, &pos, countSize)
+	if !ok {
+		return nil, nil, errors.New("invalid heif iloc")
+	}
+
+	var payload []byte
+	for i := uint64(0); i < itemCount; i++ {
+		id, ok := heifField(iloc, &pos, idSize)
+		method := uint64(0)
+		if ok && (version == 1 || version == 2) {
+			method, ok = heifField(iloc, &pos, 2)
+			method &= 0x0F
+		}
+		if ok {
+			_, ok = heifField(iloc, &pos, 2)
+		}
+		base, okBase := heifField(iloc, &pos, baseOffsetSize)
+		extents, okExtents := heifField(iloc, &pos, 2)
+		if !ok || !okBase || !okExtents {
+			return nil, nil, errors.New("invalid heif iloc")
+		}
+		for e := uint64(0); e < extents; e++ {
+			_, okIndex := heifField(iloc, &pos, indexSize)
+			offset, okOffset := heifField(iloc, &pos, offsetSize)
+			length, okLength := heifField(iloc, &pos, lengthSize)
+			if !okIndex || !okOffset || !okLength {
+				return nil, nil, errors.New("invalid heif iloc")
+			}
+			// Hanya item yang disimpan langsung di file (construction_method 0) yang bisa dikosongkan
+			if !items[id] || method != 0 {
+				continue
+			}
+			start := base + offset
+			end := start + length
+			if length == 0 || end > uint64(len(cleaned)) {
+				end = uint64(len(cleaned))
+			}
+			if start >= end {
+				continue
+			}
+			// Item Exif diawali offset 4 byte menuju header TIFF
+			if id == exifItem && payload == nil && end-start > 4 {
+				skip := uint64(binary.BigEndian.Uint32(cleaned[start : start+4]))
+				if start+4+skip < end {
+					payload = append([]byte(nil), cleaned[start+4+skip:end]...)
+				}
+			}
+			for j := start; j < end; j++ {
+				cleaned[j] = 0
+			}
+		}
+	}
+	return payload, cleaned, nil
+}
+
+// stripPngMetadata menyalin PNG tanpa chunk eXIf, teks dan waktu
+func stripPngMetadata(data []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(pngSignature)
+	pos := len(pngSignature)
+	for pos+8 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		kind := string(data[pos+4 : pos+8])
+		end := pos + 12 + length
+		if end > len(data) {
+			return nil, errors.New("truncated png chunk")
+		}
+		switch kind {
+		case "eXIf", "tEXt", "iTXt", "zTXt", "tIME":
+		default:
+			out.Write(data[pos:end])
+		}
+		pos = end
+	}
+	return out.Bytes(), nil
+}
+
+// reorientImage memutar/membalik piksel sesuai orientasi EXIF lalu meng-encode ulang tanpa metadata
+func reorientImage(data []byte, orientation int, format string) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	rotated := applyOrientation(src, orientation)
+
+	var out bytes.Buffer
+	if format == "png" {
+		err = png.Encode(&out, rotated)
+	} else {
+		err = jpeg.Encode(&out, rotated, &jpeg.Options{Quality: 92})
+	}
+	return out.Bytes(), err
+}
+
+func applyOrientation(src image.Image, orientation int) image.Image {
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	w, h := b.Dx(), b.Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 && orientation <= 8 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		for dx := 0; dx < dw; dx++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-dx, dy
+			case 3:
+				sx, sy = w-1-dx, h-1-dy
+			case 4:
+				sx, sy = dx, h-1-dy
+			case 5:
+				sx, sy = dy, dx
+			case 6:
+				sx, sy = dy, h-1-dx
+			case 7:
+				sx, sy = w-1-dy, h-1-dx
+			case 8:
+				sx, sy = w-1-dy, dx
+			default:
+				sx, sy = dx, dy
+			}
+			si := rgba.PixOffset(sx, sy)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], rgba.Pix[si:si+4])
+		}
+	}
+	return dst
+}
+
+// tiffReader membaca IFD pada blok TIFF milik EXIF
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+type tiffEntry struct {
+	kind  uint16
+	count uint32
+	value []byte
+}
+
+func parseExif(payload []byte) ExifData {
+	exif := ExifData{Orientation: 1}
+	if len(payload) < 8 {
+		return exif
+	}
+	t := tiffReader{data: payload}
+	switch string(payload[:2]) {
+	case "II":
+		t.order = binary.LittleEndian
+	case "MM":
+		t.order = binary.BigEndian
+	default:
+		return exif
+	}
+
+	ifd0 := t.readIFD(t.order.Uint32(payload[4:8]))
+	if e, ok := ifd0[0x0112]; ok {
+		if v := t.uint(e); v >= 1 && v <= 8 {
+			exif.Orientation = int(v)
+		}
+	}
+
+	// Tanggal pengambilan diutamakan dari DateTimeOriginal, cadangannya DateTime IFD0
+	captured := ""
+	if e, ok := ifd0[0x8769]; ok {
+		sub := t.readIFD(t.uint(e))
+		if d, ok := sub[0x9003]; ok {
+			captured = t.ascii(d)
+		}
+	}
+	if captured == "" {
+		if d, ok := ifd0[0x0132]; ok {
+			captured = t.ascii(d)
+		}
+	}
+	if captured != "" {
+		if parsed, err := time.ParseInLocation("2006:01:02 15:04:05", captured, time.Local); err == nil {
+			exif.CapturedAt = &parsed
+		}
+	}
+
+	if e, ok := ifd0[0x8825]; ok {
+		gps := t.readIFD(t.uint(e))
+		lat, latOk := t.coordinate(gps[0x0002], gps[0x0001], "S")
+		lng, lngOk := t.coordinate(gps[0x0004], gps[0x0003], "W")
+		if latOk && lngOk {
+			exif.Latitude = &lat
+			exif.Longitude = &lng
+		}
+	}
+
+	return exif
+}
+
+func (t tiffReader) readIFD(offset uint32) map[uint16]tiffEntry {
+	entries := make(map[uint16]tiffEntry)
+	start := int(offset)
+	if start <= 0 || start+2 > len(t.data) {
+		return entries
+	}
+	count := int(t.order.Uint16(t.data[start : start+2]))
+	for i := 0; i < count; i++ {
+		pos := start + 2 + i*12
+		if pos+12 > len(t.data) {
+			break
+		}
+		tag := t.order.Uint16(t.data[pos : pos+2])
+		kind := t.order.Uint16(t.data[pos+2 : pos+4])
+		n := t.order.Uint32(t.data[pos+4 : pos+8])
+
+		size := tiffTypeSize(kind) * int(n)
+		if size <= 0 {
+			continue
+		}
+		var value []byte
+		if size <= 4 {
+			value = t.data[pos+8 : pos+8+size]
+		} else {
+			valueOffset := int(t.order.Uint32(t.data[pos+8 : pos+12]))
+			if valueOffset < 0 || valueOffset+size > len(t.data) {
+				continue
+			}
+			value = t.data[valueOffset : valueOffset+size]
+		}
+		entries[tag] = tiffEntry{kind: kind, count: n, value: value}
+	}
+	return entries
+}
+
+func tiffTypeSize(kind uint16) int {
+	switch kind {
+	case 1, 2, 6, 7:
+		return 1
+	case 3, 8:
+		return 2
+	case 4, 9, 11:
+		return 4
+	case 5, 10, 12:
+		return 8
+	}
+	return 0
+}
+
+func (t tiffReader) uint(e tiffEntry) uint32 {
+	switch e.kind {
+	case 3:
+		return uint32(t.order.Uint16(e.value))
+	case 4:
+		return t.order.Uint32(e.value)
+	}
+	return 0
+}
+
+func (t tiffReader) ascii(e tiffEntry) string {
+	return strings.TrimRight(string(e.value), "\x00 ")
+}
+
+// coordinate mengubah derajat/menit/detik (3 RATIONAL) menjadi derajat desimal
+func (t tiffReader) coordinate(value tiffEntry, ref tiffEntry, negativeRef string) (float64, bool) {
+	if value.kind != 5 || value.count < 3 {
+		return 0, false
+	}
+	parts := make([]float64, 3)
+	for i := range parts {
+		num := t.order.Uint32(value.value[i*8 : i*8+4])
+		den := t.order.Uint32(value.value[i*8+4 : i*8+8])
+		if den == 0 {
+			return 0, false
+		}
+		parts[i] = float64(num) / float64(den)
+	}
+	result := parts[0] + parts[1]/60 + parts[2]/3600
+	if t.ascii(ref) == negativeRef {
+		result = -result
+	}
+	return result, true
+}
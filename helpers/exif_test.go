@@ -0,0 +1,178 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// gpsTiff membuat blok TIFF big endian berisi GPS 6°10'30" S, 106°49'12" E
+func gpsTiff() []byte {
+	var b bytes.Buffer
+	w := func(v interface{}) { binary.Write(&b, binary.BigEndian, v) }
+	b.WriteString("MM\x00\x2a")
+	w(uint32(8))
+	// IFD0: satu entri penunjuk GPS IFD di offset 26
+	w(uint16(1))
+	w([]uint16{0x8825, 4})
+	w([]uint32{1, 26})
+	w(uint32(0))
+	// GPS IFD: ref dan koordinat, rasional di offset 80 dan 104
+	w(uint16(4))
+	w([]uint16{0x0001, 2})
+	w(uint32(2))
+	b.WriteString("S\x00\x00\x00")
+	w([]uint16{0x0002, 5})
+	w([]uint32{3, 80})
+	w([]uint16{0x0003, 2})
+	w(uint32(2))
+	b.WriteString("E\x00\x00\x00")
+	w([]uint16{0x0004, 5})
+	w([]uint32{3, 104})
+	w(uint32(0))
+	w([]uint32{6, 1, 10, 1, 30, 1})
+	w([]uint32{106, 1, 49, 1, 12, 1})
+	return b.Bytes()
+}
+
+func riffChunk(kind string, payload []byte) []byte {
+	chunk := []byte(kind)
+	chunk = binary.LittleEndian.AppendUint32(chunk, uint32(len(payload)))
+	chunk = append(chunk, payload...)
+	if len(payload)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+func webpFile(chunks ...[]byte) []byte {
+	body := []byte("WEBP")
+	for _, chunk := range chunks {
+		body = append(body, chunk...)
+	}
+	data := []byte("RIFF")
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(body)))
+	return append(data, body...)
+}
+
+func isoBoxBytes(kind string, payload []byte) []byte {
+	box := binary.BigEndian.AppendUint32(nil, uint32(len(payload)+8))
+	box = append(box, kind...)
+	return append(box, payload...)
+}
+
+// heifFile membuat HEIC minimal dengan item gambar dan item Exif yang isinya berada di mdat
+func heifFile(tiff []byte) []byte {
+	ftyp := isoBoxBytes("ftyp", []byte("heic\x00\x00\x00\x00mif1heic"))
+
+	infe := func(id uint16, kind string) []byte {
+		payload := []byte{2, 0, 0, 0}
+		payload = binary.BigEndian.AppendUint16(payload, id)
+		payload = append(payload, 0, 0)
+		payload = append(payload, kind...)
+		return isoBoxBytes("infe", append(payload, 0))
+	}
+	iinf := isoBoxBytes("iinf", append([]byte{0, 0, 0, 0, 0, 2}, append(infe(1, "hvc1"), infe(2, "Exif")...)...))
+
+	exifItem := binary.BigEndian.AppendUint32(nil, 6)
+	exifItem = append(exifItem, "Exif\x00\x00"...)
+	exifItem = append(exifItem, tiff...)
+
+	// iloc versi 0: offset dan length 4 byte, tanpa base offset
+	ilocSize := 8 + 4 + 2 + 2 + 2 + 2 + 2 + 8
+	metaSize := 8 + 4 + len(iinf) + ilocSize
+	mdatStart := len(ftyp) + metaSize + 8
+	iloc := []byte{0, 0, 0, 0, 0x44, 0x00}
+	iloc = binary.BigEndian.AppendUint16(iloc, 1)
+	iloc = binary.BigEndian.AppendUint16(iloc, 2)
+	iloc = binary.BigEndian.AppendUint16(iloc, 0)
+	iloc = binary.BigEndian.AppendUint16(iloc, 1)
+	iloc = binary.BigEndian.AppendUint32(iloc, uint32(mdatStart))
+	iloc = binary.BigEndian.AppendUint32(iloc, uint32(len(exifItem)))
+
+	meta := isoBoxBytes("meta", append(append([]byte{0, 0, 0, 0}, iinf...), isoBoxBytes("iloc", iloc)...))
+	data := append(ftyp, meta...)
+	return append(data, isoBoxBytes("mdat", exifItem)...)
+}
+
+func TestSanitizeImageFileRemovesLocation(t *testing.T) {
+	tiff := gpsTiff()
+	vp8x := []byte{0x0C, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"webp", webpFile(riffChunk("VP8X", vp8x), riffChunk("VP8L", []byte{1, 2, 3}), riffChunk("EXIF", append([]byte("Exif\x00\x00"), tiff...)), riffChunk("XMP ", []byte("<x:xmpmeta/>")))},
+		{"heic", heifFile(tiff)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "photo")
+			if err := os.WriteFile(path, tt.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			exif, err := SanitizeImageFile(path)
+			if err != nil {
+				t.Fatalf("SanitizeImageFile: %v", err)
+			}
+			if exif.Latitude == nil || exif.Longitude == nil {
+				t.Fatalf("location not read before stripping")
+			}
+			if math.Abs(*exif.Latitude+6.175) > 1e-9 || math.Abs(*exif.Longitude-106.82) > 1e-9 {
+				t.Errorf("location = %v, %v", *exif.Latitude, *exif.Longitude)
+			}
+
+			cleaned, _ := os.ReadFile(path)
+			if bytes.Contains(cleaned, tiff[:16]) {
+				t.Errorf("exif still present in cleaned file")
+			}
+			again, _ := SanitizeImageFile(path)
+			if again.Latitude != nil {
+				t.Errorf("location still readable after stripping")
+			}
+		})
+	}
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	data := webpFile(riffChunk("VP8X", []byte{0x0C, 0, 0, 0, 0, 0, 0, 0, 0, 0}), riffChunk("VP8L", []byte{1, 2, 3}), riffChunk("XMP ", []byte("<x/>")))
+	cleaned, err := stripWebPMetadata(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := webpFile(riffChunk("VP8X", make([]byte, 10)), riffChunk("VP8L", []byte{1, 2, 3}))
+	if !bytes.Equal(cleaned, want) {
+		t.Errorf("cleaned = %q, want %q", cleaned, want)
+	}
+}
+
+func TestSanitizeImageFileRejectsMalformedJpeg(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"segment length 0", []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x00, 0xFF, 0xD9}},
+		{"segment length 1", []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01, 0xFF, 0xD9}},
+		{"segment past the end", []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x40, 'E', 'x', 'i', 'f', 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if payload := jpegExifPayload(tt.data); payload != nil {
+				t.Errorf("jpegExifPayload = %q, want nil", payload)
+			}
+			if _, err := stripJpegMetadata(tt.data); err == nil {
+				t.Errorf("stripJpegMetadata accepted a malformed segment")
+			}
+			path := filepath.Join(t.TempDir(), "photo.jpg")
+			if err := os.WriteFile(path, tt.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := SanitizeImageFile(path); err == nil {
+				t.Errorf("SanitizeImageFile accepted a malformed segment")
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package helpers
+
+import "Matahariled/models"
+
+// columnHeaders adalah judul kolom ekspor per bahasa; kunci "tabel.kolom" mengganti judul umum untuk tabel tertentu
+var columnHeaders = map[string]map[string]string{
+	"product_id":         {models.LocaleIndonesian: "ID Produk", models.LocaleEnglish: "Product ID"},
+	"name":               {models.LocaleIndonesian: "Nama Produk", models.LocaleEnglish: "Product Name"},
+	"slug":               {models.LocaleIndonesian: "Slug", models.LocaleEnglish: "Slug"},
+	"category":           {models.LocaleIndonesian: "Kategori", models.LocaleEnglish: "Category"},
+	"category_name":      {models.LocaleIndonesian: "Kategori", models.LocaleEnglish: "Category"},
+	"status":             {models.LocaleIndonesian: "Status", models.LocaleEnglish: "Status"},
+	"tags":               {models.LocaleIndonesian: "Tag", models.LocaleEnglish: "Tags"},
+	"attributes":         {models.LocaleIndonesian: "Atribut", models.LocaleEnglish: "Attributes"},
+	"description":        {models.LocaleIndonesian: "Deskripsi", models.LocaleEnglish: "Description"},
+	"specification":      {models.LocaleIndonesian: "Spesifikasi", models.LocaleEnglish: "Specification"},
+	"path_file":          {models.LocaleIndonesian: "Gambar", models.LocaleEnglish: "Image"},
+	"publish_at":         {models.LocaleIndonesian: "Jadwal Terbit", models.LocaleEnglish: "Scheduled Publish"},
+	"published_at":       {models.LocaleIndonesian: "Tanggal Terbit", models.LocaleEnglish: "Published At"},
+	"created_at":         {models.LocaleIndonesian: "Dibuat", models.LocaleEnglish: "Created At"},
+	"updated_at":         {models.LocaleIndonesian: "Diperbarui", models.LocaleEnglish: "Updated At"},
+	"history_id":         {models.LocaleIndonesian: "ID Riwayat", models.LocaleEnglish: "History ID"},
+	"title":              {models.LocaleIndonesian: "Judul", models.LocaleEnglish: "Title"},
+	"product_name":       {models.LocaleIndonesian: "Produk", models.LocaleEnglish: "Product"},
+	"user":               {models.LocaleIndonesian: "Pelanggan", models.LocaleEnglish: "Customer"},
+	"start_date":         {models.LocaleIndonesian: "Tanggal Mulai", models.LocaleEnglish: "Start Date"},
+	"end_date":           {models.LocaleIndonesian: "Tanggal Selesai", models.LocaleEnglish: "End Date"},
+	"videos":             {models.LocaleIndonesian: "Video", models.LocaleEnglish: "Videos"},
+	"contract_id":        {models.LocaleIndonesian: "ID Kontrak", models.LocaleEnglish: "Contract ID"},
+	"contracts.username": {models.LocaleIndonesian: "Pelanggan", models.LocaleEnglish: "Customer"},
+	"contracts.product":  {models.LocaleIndonesian: "Produk", models.LocaleEnglish: "Products"},
+	"user_id":            {models.LocaleIndonesian: "ID Pengguna", models.LocaleEnglish: "User ID"},
+	"full_name":          {models.LocaleIndonesian: "Nama Lengkap", models.LocaleEnglish: "Full Name"},
+	"username":           {models.LocaleIndonesian: "Nama Pengguna", models.LocaleEnglish: "Username"},
+	"email":              {models.LocaleIndonesian: "Email", models.LocaleEnglish: "Email"},
+	"phone_number":       {models.LocaleIndonesian: "Nomor Telepon", models.LocaleEnglish: "Phone Number"},
+	"address":            {models.LocaleIndonesian: "Alamat", models.LocaleEnglish: "Address"},
+	"role":               {models.LocaleIndonesian: "Peran", models.LocaleEnglish: "Role"},
+	"video_id":           {models.LocaleIndonesian: "ID Video", models.LocaleEnglish: "Video ID"},
+	"video_title":        {models.LocaleIndonesian: "Judul Video", models.LocaleEnglish: "Video Title"},
+	"provider":           {models.LocaleIndonesian: "Penyedia", models.LocaleEnglish: "Provider"},
+	"url":                {models.LocaleIndonesian: "URL", models.LocaleEnglish: "URL"},
+	"thumbnail":          {models.LocaleIndonesian: "Thumbnail", models.LocaleEnglish: "Thumbnail"},
+}
+
+// ColumnHeaders menyusun judul kolom yang mudah dibaca untuk ekspor tabel, kolom tanpa judul memakai kuncinya
+func ColumnHeaders(locale string, table string, keys []string) []string {
+	headers := make([]string, len(keys))
+	for i, key := range keys {
+		texts, ok := columnHeaders[table+"."+key]
+		if !ok {
+			texts, ok = columnHeaders[key]
+		}
+		switch {
+		case !ok:
+			headers[i] = key
+		case texts[locale] != "":
+			headers[i] = texts[locale]
+		default:
+			headers[i] = texts[models.DefaultLocale]
+		}
+	}
+	return headers
+}
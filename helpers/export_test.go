@@ -0,0 +1,125 @@
+package helpers
+
+import (
+	"Matahariled/models"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func exportRows() [][]interface{} {
+	created := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	var publishAt *time.Time
+	return [][]interface{}{
+		{int64(7), "Videotron <P10> & co", true, 1.5, []string{"outdoor", "led"}, created, publishAt},
+		{int64(8), "", false, 0.0, []string(nil), time.Time{}, &created},
+	}
+}
+
+func writeTable(t *testing.T, format string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	keys := []string{"product_id", "name", "active", "price", "tags", "created_at", "publish_at"}
+	writer, err := NewTableWriter(format, &buf, "Produk", keys, ColumnHeaders(models.LocaleEnglish, "products", keys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range exportRows() {
+		if err := writer.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestTableWriterSpreadsheets(t *testing.T) {
+	header := []string{"Product ID", "Product Name", "active", "price", "Tags", "Created At", "Scheduled Publish"}
+	tests := []struct {
+		format   string
+		filename string
+		want     [][]string
+	}{
+		{"csv", "export.csv", [][]string{
+			header,
+			{"7", "Videotron <P10> & co", "true", "1.5", "outdoor, led", "2024-03-01 08:30:00", ""},
+			{"8", "", "false", "0", "", "", "2024-03-01 08:30:00"},
+		}},
+		// Sel teks kosong tidak ditulis di XLSX, sel setelahnya tetap di kolom yang benar
+		{"xlsx", "export.xlsx", [][]string{
+			header,
+			{"7", "Videotron <P10> & co", "true", "1.5", "outdoor, led", "2024-03-01 08:30:00"},
+			{"8", "", "false", "0", "", "", "2024-03-01 08:30:00"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			data := writeTable(t, tt.format)
+			if tt.format == "csv" && !bytes.HasPrefix(data, []byte("\xef\xbb\xbf")) {
+				t.Errorf("csv export has no UTF-8 BOM")
+			}
+			rows, err := ReadSpreadsheet(tt.filename, data)
+			if err != nil {
+				t.Fatalf("ReadSpreadsheet: %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Errorf("rows = %q, want %q", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableWriterJSON(t *testing.T) {
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(writeTable(t, "json"), &rows); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+	want := map[string]interface{}{
+		"product_id": 7.0, "name": "Videotron <P10> & co", "active": true, "price": 1.5,
+		"tags": []interface{}{"outdoor", "led"}, "created_at": "2024-03-01T08:30:00Z", "publish_at": nil,
+	}
+	if !reflect.DeepEqual(rows[0], want) {
+		t.Errorf("row = %v, want %v", rows[0], want)
+	}
+}
+
+func TestNewTableWriterRejectsUnknownFormat(t *testing.T) {
+	if _, err := NewTableWriter("pdf", &bytes.Buffer{}, "Produk", nil, nil); err == nil {
+		t.Errorf("expected an error for an unsupported format")
+	}
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumnName(index); got != want {
+			t.Errorf("xlsxColumnName(%d) = %q, want %q", index, got, want)
+		}
+		if got := xlsxColumnIndex(want + "1"); got != index {
+			t.Errorf("xlsxColumnIndex(%q) = %d, want %d", want+"1", got, index)
+		}
+	}
+}
+
+func TestColumnHeaders(t *testing.T) {
+	keys := []string{"username", "product", "unknown_column"}
+	tests := []struct {
+		locale string
+		table  string
+		want   []string
+	}{
+		{models.LocaleIndonesian, "users", []string{"Nama Pengguna", "product", "unknown_column"}},
+		{models.LocaleEnglish, "contracts", []string{"Customer", "Products", "unknown_column"}},
+	}
+	for _, tt := range tests {
+		if got := ColumnHeaders(tt.locale, tt.table, keys); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ColumnHeaders(%s, %s) = %q, want %q", tt.locale, tt.table, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"Matahariled/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SupportedLocale bernilai true jika locale termasuk bahasa yang didukung
+func SupportedLocale(locale string) bool {
+	for _, candidate := range models.Locales {
+		if locale == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// Locale memilih bahasa dari ?lang= lalu Accept-Language, selain itu bahasa bawaan
+func Locale(c *fiber.Ctx) string {
+	if lang := c.Query("lang"); SupportedLocale(lang) {
+		return lang
+	}
+	if c.Get(fiber.HeaderAcceptLanguage) != "" {
+		if locale := c.AcceptsLanguages(models.Locales...); locale != "" {
+			return locale
+		}
+	}
+	return models.DefaultLocale
+}
@@ -0,0 +1,176 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// MediaInfo berisi metadata yang dibaca langsung dari isi file, bukan dari input pengguna
+type MediaInfo struct {
+	Size     int64
+	MimeType string
+	Format   string
+	Checksum string
+	Width    int
+	Height   int
+	Duration float64
+}
+
+// Ekstensi baku untuk MIME yang umum dipakai, agar format tidak bergantung pada nama file unggahan
+var mediaExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"image/svg+xml":   ".svg",
+	"video/mp4":       ".mp4",
+	"video/quicktime": ".mov",
+	"video/webm":      ".webm",
+	"application/pdf": ".pdf",
+}
+
+// safeExtension membatasi ekstensi cadangan dari nama file agar tetap aman dipakai sebagai nama file di disk
+var safeExtension = regexp.MustCompile(`^\.[a-z0-9]{1,10}$`)
+
+// MediaExtension mengembalikan ekstensi file berdasarkan MIME hasil deteksi
+func MediaExtension(mimeType string, fallback string) string {
+	if ext, ok := mediaExtensions[mimeType]; ok {
+		return ext
+	}
+	if ext := strings.ToLower(filepath.Ext(fallback)); safeExtension.MatchString(ext) {
+		return ext
+	}
+	return ""
+}
+
+// ProbeMediaFile membaca file di disk lalu menghitung ukuran, MIME, checksum dan dimensinya.
+// name adalah nama file asli untuk cadangan ekstensi; kosong berarti nama file di disk.
+func ProbeMediaFile(path string, name string) (MediaInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return MediaInfo{}, err
+	}
+	defer f.Close()
+
+	if name == "" {
+		name = filepath.Base(path)
+	}
+	return ProbeMedia(f, name)
+}
+
+// ProbeMedia menganalisis isi file. Nama file hanya dipakai sebagai cadangan ekstensi
+func ProbeMedia(r io.ReadSeeker, name string) (MediaInfo, error) {
+	var info MediaInfo
+
+	// Hitung SHA-256 dan ukuran sekaligus
+	hash := sha256.New()
+	size, err := io.Copy(hash, r)
+	if err != nil {
+		return info, err
+	}
+	info.Size = size
+	info.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	// Deteksi MIME dari isi file
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return info, err
+	}
+	mtype, err := mimetype.DetectReader(r)
+	if err != nil {
+		return info, err
+	}
+	info.MimeType = strings.Split(mtype.String(), ";")[0]
+	info.Format = MediaExtension(info.MimeType, name)
+
+	switch {
+	case strings.HasPrefix(info.MimeType, "image/"):
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return info, err
+		}
+		// Format yang tidak didukung decoder bawaan cukup dilewati
+		if cfg, _, err := image.DecodeConfig(r); err == nil {
+			info.Width = cfg.Width
+			info.Height = cfg.Height
+		}
+	case info.MimeType == "video/mp4" || info.MimeType == "video/quicktime":
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return info, err
+		}
+		info.Duration = mp4Duration(r)
+	}
+
+	return info, nil
+}
+
+// mp4Duration mencari box moov/mvhd pada file MP4/QuickTime lalu menghitung durasi dalam detik
+func mp4Duration(r io.ReadSeeker) float64 {
+	var walk func(end int64) float64
+	walk = func(end int64) float64 {
+		header := make([]byte, 8)
+		for {
+			start, err := r.Seek(0, io.SeekCurrent)
+			if err != nil || (end > 0 && start+8 > end) {
+				return 0
+			}
+			if _, err := io.ReadFull(r, header); err != nil {
+				return 0
+			}
+			size := int64(binary.BigEndian.Uint32(header[:4]))
+			kind := string(header[4:8])
+			headerLen := int64(8)
+			if size == 1 {
+				ext := make([]byte, 8)
+				if _, err := io.ReadFull(r, ext); err != nil {
+					return 0
+				}
+				size = int64(binary.BigEndian.Uint64(ext))
+				headerLen = 16
+			}
+			if size < headerLen {
+				return 0
+			}
+
+			switch kind {
+			case "moov":
+				return walk(start + size)
+			case "mvhd":
+				body := make([]byte, 32)
+				if _, err := io.ReadFull(r, body); err != nil {
+					return 0
+				}
+				// Versi 1 memakai field 64-bit untuk waktu dan durasi
+				if body[0] == 1 {
+					timescale := binary.BigEndian.Uint32(body[20:24])
+					duration := binary.BigEndian.Uint64(body[24:32])
+					if timescale == 0 {
+						return 0
+					}
+					return float64(duration) / float64(timescale)
+				}
+				timescale := binary.BigEndian.Uint32(body[12:16])
+				duration := binary.BigEndian.Uint32(body[16:20])
+				if timescale == 0 {
+					return 0
+				}
+				return float64(duration) / float64(timescale)
+			}
+
+			if _, err := r.Seek(start+size, io.SeekStart); err != nil {
+				return 0
+			}
+		}
+	}
+	return walk(0)
+}
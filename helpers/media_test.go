@@ -0,0 +1,108 @@
+package helpers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func pngBytes(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// mp4Bytes membuat MP4 minimal berisi ftyp dan moov/mvhd dengan versi mvhd tertentu
+func mp4Bytes(version byte, timescale uint32, duration uint64) []byte {
+	mvhd := []byte{version, 0, 0, 0}
+	if version == 1 {
+		mvhd = append(mvhd, make([]byte, 16)...)
+		mvhd = binary.BigEndian.AppendUint32(mvhd, timescale)
+		mvhd = binary.BigEndian.AppendUint64(mvhd, duration)
+	} else {
+		mvhd = append(mvhd, make([]byte, 8)...)
+		mvhd = binary.BigEndian.AppendUint32(mvhd, timescale)
+		mvhd = binary.BigEndian.AppendUint32(mvhd, uint32(duration))
+	}
+	mvhd = append(mvhd, make([]byte, 80)...)
+
+	data := isoBoxBytes("ftyp", []byte("isom\x00\x00\x02\x00isomiso2mp41"))
+	data = append(data, isoBoxBytes("free", make([]byte, 4))...)
+	return append(data, isoBoxBytes("moov", isoBoxBytes("mvhd", mvhd))...)
+}
+
+func TestProbeMedia(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         []byte
+		filename     string
+		wantMime     string
+		wantFormat   string
+		wantWidth    int
+		wantHeight   int
+		wantDuration float64
+	}{
+		{"png ignores misleading name", pngBytes(t, 3, 2), "photo.jpg", "image/png", ".png", 3, 2, 0},
+		{"mp4 duration", mp4Bytes(0, 1000, 12500), "clip.bin", "video/mp4", ".mp4", 0, 0, 12.5},
+		{"mp4 64-bit duration", mp4Bytes(1, 600, 1800), "clip.mp4", "video/mp4", ".mp4", 0, 0, 3},
+		{"pdf", []byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"), "brosur", "application/pdf", ".pdf", 0, 0, 0},
+		{"unknown type keeps original extension", []byte("ISO-10303-21;\nHEADER;\n"), "Kabinet.STEP", "text/plain", ".step", 0, 0, 0},
+		{"unsafe original extension is dropped", []byte("ISO-10303-21;\n"), "kabinet.st p", "text/plain", "", 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ProbeMedia(bytes.NewReader(tt.data), tt.filename)
+			if err != nil {
+				t.Fatalf("ProbeMedia: %v", err)
+			}
+			if info.MimeType != tt.wantMime || info.Format != tt.wantFormat {
+				t.Errorf("mime, format = %s, %q, want %s, %q", info.MimeType, info.Format, tt.wantMime, tt.wantFormat)
+			}
+			if info.Width != tt.wantWidth || info.Height != tt.wantHeight {
+				t.Errorf("size = %dx%d, want %dx%d", info.Width, info.Height, tt.wantWidth, tt.wantHeight)
+			}
+			if info.Duration != tt.wantDuration {
+				t.Errorf("duration = %v, want %v", info.Duration, tt.wantDuration)
+			}
+			if info.Size != int64(len(tt.data)) || len(info.Checksum) != 64 {
+				t.Errorf("size, checksum = %d, %q", info.Size, info.Checksum)
+			}
+		})
+	}
+}
+
+func TestProbeMediaFileUsesOriginalName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "abc123.upload")
+	if err := os.WriteFile(path, []byte("ISO-10303-21;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", ".upload"},
+		{"panel.dwg", ".dwg"},
+	}
+	for _, tt := range tests {
+		info, err := ProbeMediaFile(path, tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Format != tt.want {
+			t.Errorf("ProbeMediaFile(%q) format = %q, want %q", tt.name, info.Format, tt.want)
+		}
+	}
+	info, _ := ProbeMediaFile(path, "")
+	if sum := sha256.Sum256([]byte("ISO-10303-21;\n")); info.Checksum != hex.EncodeToString(sum[:]) {
+		t.Errorf("checksum = %q", info.Checksum)
+	}
+}
@@ -0,0 +1,1375 @@
+package helpers
+
+import (
+	"Matahariled/models"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Kode pesan bersifat tetap sehingga klien dapat mengenali kesalahan tanpa membaca teks pesan
+const (
+	// Umum
+	MsgInternalError            = "INTERNAL_ERROR"
+	MsgSaveChangesFailed        = "SAVE_CHANGES_FAILED"
+	MsgInvalidRequestBody       = "INVALID_REQUEST_BODY"
+	MsgValidationFailed         = "VALIDATION_FAILED"
+	MsgInvalidInput             = "INVALID_INPUT"
+	MsgInvalidID                = "INVALID_ID"
+	MsgDataNotFound             = "DATA_NOT_FOUND"
+	MsgFileRequired             = "FILE_REQUIRED"
+	MsgFileSaveFailed           = "FILE_SAVE_FAILED"
+	MsgFileLoadFailed           = "FILE_LOAD_FAILED"
+	MsgFileDeleteFailed         = "FILE_DELETE_FAILED"
+	MsgTitleDescriptionRequired = "TITLE_DESCRIPTION_REQUIRED"
+	MsgSlugGenerateFailed       = "SLUG_GENERATE_FAILED"
+	MsgSlugRedirectSaveFailed   = "SLUG_REDIRECT_SAVE_FAILED"
+	MsgExportUnsupportedFormat  = "EXPORT_UNSUPPORTED_FORMAT"
+	MsgExportFailed             = "EXPORT_FAILED"
+
+	// Autentikasi
+	MsgTokenRequired      = "TOKEN_REQUIRED"
+	MsgTokenInvalid       = "TOKEN_INVALID"
+	MsgTokenUserFailed    = "TOKEN_USER_FAILED"
+	MsgAccessDenied       = "ACCESS_DENIED"
+	MsgInvalidCredentials = "INVALID_CREDENTIALS"
+	MsgJWTSecretMissing   = "JWT_SECRET_MISSING"
+	MsgTokenSignFailed    = "TOKEN_SIGN_FAILED"
+	MsgPasswordHashFailed = "PASSWORD_HASH_FAILED"
+
+	// Pengguna
+	MsgInvalidUserID         = "INVALID_USER_ID"
+	MsgUserNotFound          = "USER_NOT_FOUND"
+	MsgUserIDNotFound        = "USER_ID_NOT_FOUND"
+	MsgUserCreateFailed      = "USER_CREATE_FAILED"
+	MsgUserSaveFailed        = "USER_SAVE_FAILED"
+	MsgUserDeleteFailed      = "USER_DELETE_FAILED"
+	MsgUserCreated           = "USER_CREATED"
+	MsgUserUpdated           = "USER_UPDATED"
+	MsgUserDeleted           = "USER_DELETED"
+	MsgUserTrashed           = "USER_TRASHED"
+	MsgAvatarDeleted         = "AVATAR_DELETED"
+	MsgAvatarInvalidImage    = "AVATAR_INVALID_IMAGE"
+	MsgCropOutOfBounds       = "CROP_OUT_OF_BOUNDS"
+	MsgCropUnsupportedFormat = "CROP_UNSUPPORTED_FORMAT"
+
+	// Produk
+	MsgInvalidProductID            = "INVALID_PRODUCT_ID"
+	MsgProductNotFound             = "PRODUCT_NOT_FOUND"
+	MsgProductIDNotFound           = "PRODUCT_ID_NOT_FOUND"
+	MsgInvalidProductIDValue       = "INVALID_PRODUCT_ID_VALUE"
+	MsgCompareIDsRequired          = "COMPARE_IDS_REQUIRED"
+	MsgRecommendationFetchFailed   = "RECOMMENDATION_FETCH_FAILED"
+	MsgRecommendationRebuildFailed = "RECOMMENDATION_REBUILD_FAILED"
+	MsgRecommendationRebuilt       = "RECOMMENDATION_REBUILT"
+	MsgBrochureFailed              = "BROCHURE_FAILED"
+	MsgCatalogEmpty                = "CATALOG_EMPTY"
+	MsgBrochureDescription         = "BROCHURE_DESCRIPTION"
+	MsgBrochureSpecification       = "BROCHURE_SPECIFICATION"
+	MsgBrochureAttributes          = "BROCHURE_ATTRIBUTES"
+	MsgBrochureGallery             = "BROCHURE_GALLERY"
+	MsgBrochureCatalog             = "BROCHURE_CATALOG"
+	MsgBrochureProductCount        = "BROCHURE_PRODUCT_COUNT"
+	MsgBrochureUpdated             = "BROCHURE_UPDATED"
+	MsgBrochurePage                = "BROCHURE_PAGE"
+	MsgImportUnsupportedFormat     = "IMPORT_UNSUPPORTED_FORMAT"
+	MsgImportReadFailed            = "IMPORT_READ_FAILED"
+	MsgImportEmpty                 = "IMPORT_EMPTY"
+	MsgImportColumnRequired        = "IMPORT_COLUMN_REQUIRED"
+	MsgImportImagesNotZip          = "IMPORT_IMAGES_NOT_ZIP"
+	MsgImportHasErrors             = "IMPORT_HAS_ERRORS"
+	MsgImportFailed                = "IMPORT_FAILED"
+	MsgImportCategoryNotFound      = "IMPORT_CATEGORY_NOT_FOUND"
+	MsgImportSlugDuplicate         = "IMPORT_SLUG_DUPLICATE"
+	MsgImportImageNotInArchive     = "IMPORT_IMAGE_NOT_IN_ARCHIVE"
+	MsgImportImageURLInvalid       = "IMPORT_IMAGE_URL_INVALID"
+	MsgImportImageDownloadFailed   = "IMPORT_IMAGE_DOWNLOAD_FAILED"
+	MsgImportImageInvalid          = "IMPORT_IMAGE_INVALID"
+	MsgImportImageTooLarge         = "IMPORT_IMAGE_TOO_LARGE"
+	MsgProductIDsRequired          = "PRODUCT_IDS_REQUIRED"
+	MsgProductFetchFailed          = "PRODUCT_FETCH_FAILED"
+	MsgProductSaveFailed           = "PRODUCT_SAVE_FAILED"
+	MsgProductUpdateFailed         = "PRODUCT_UPDATE_FAILED"
+	MsgProductDeleteFailed         = "PRODUCT_DELETE_FAILED"
+	MsgProductAttributesSaveFailed = "PRODUCT_ATTRIBUTES_SAVE_FAILED"
+	MsgProductVideosUpdateFailed   = "PRODUCT_VIDEOS_UPDATE_FAILED"
+	MsgProductTagsUpdateFailed     = "PRODUCT_TAGS_UPDATE_FAILED"
+	MsgProductRevisionSaveFailed   = "PRODUCT_REVISION_SAVE_FAILED"
+	MsgProductSaved                = "PRODUCT_SAVED"
+	MsgProductUpdated              = "PRODUCT_UPDATED"
+	MsgProductDeleted              = "PRODUCT_DELETED"
+	MsgProductTrashed              = "PRODUCT_TRASHED"
+	MsgGalleryFetchFailed          = "GALLERY_FETCH_FAILED"
+	MsgGallerySaveFailed           = "GALLERY_SAVE_FAILED"
+	MsgHeroFetchFailed             = "HERO_FETCH_FAILED"
+	MsgHeroSaveFailed              = "HERO_SAVE_FAILED"
+	MsgMediaNotFound               = "MEDIA_NOT_FOUND"
+	MsgMediaFetchFailed            = "MEDIA_FETCH_FAILED"
+	MsgRevisionNotFound            = "REVISION_NOT_FOUND"
+	MsgRevisionFetchFailed         = "REVISION_FETCH_FAILED"
+	MsgRevisionRestoreFailed       = "REVISION_RESTORE_FAILED"
+	MsgRevisionRangeRequired       = "REVISION_RANGE_REQUIRED"
+	MsgStatusUpdateFailed          = "STATUS_UPDATE_FAILED"
+	MsgInvalidStatus               = "INVALID_STATUS"
+	MsgInvalidPublishAt            = "INVALID_PUBLISH_AT"
+
+	// Kategori dan atribut
+	MsgInvalidCategoryID              = "INVALID_CATEGORY_ID"
+	MsgInvalidCategoryIDValue         = "INVALID_CATEGORY_ID_VALUE"
+	MsgCategoryNotFound               = "CATEGORY_NOT_FOUND"
+	MsgCategoryFetchFailed            = "CATEGORY_FETCH_FAILED"
+	MsgCategoryCreated                = "CATEGORY_CREATED"
+	MsgCategoryUpdated                = "CATEGORY_UPDATED"
+	MsgCategoryMoved                  = "CATEGORY_MOVED"
+	MsgCategoryParentNotFound         = "CATEGORY_PARENT_NOT_FOUND"
+	MsgCategoryCycle                  = "CATEGORY_CYCLE"
+	MsgCategoryAttributesUpdateFailed = "CATEGORY_ATTRIBUTES_UPDATE_FAILED"
+	MsgAttributeUnknown               = "ATTRIBUTE_UNKNOWN"
+	MsgAttributeNotNumber             = "ATTRIBUTE_NOT_NUMBER"
+	MsgAttributeBoundUnsupported      = "ATTRIBUTE_BOUND_UNSUPPORTED"
+	MsgAttributeNotBoolean            = "ATTRIBUTE_NOT_BOOLEAN"
+	MsgAttributeNotRange              = "ATTRIBUTE_NOT_RANGE"
+	MsgAttributeRangeInverted         = "ATTRIBUTE_RANGE_INVERTED"
+	MsgAttributeNotOption             = "ATTRIBUTE_NOT_OPTION"
+	MsgAttributeBetween               = "ATTRIBUTE_BETWEEN"
+	MsgAttributeAtLeast               = "ATTRIBUTE_AT_LEAST"
+	MsgAttributeAtMost                = "ATTRIBUTE_AT_MOST"
+	MsgAttributeNotDefined            = "ATTRIBUTE_NOT_DEFINED"
+	MsgAttributesNotObject            = "ATTRIBUTES_NOT_OBJECT"
+	MsgAttributeNameInvalid           = "ATTRIBUTE_NAME_INVALID"
+	MsgAttributeDuplicated            = "ATTRIBUTE_DUPLICATED"
+	MsgAttributeMinGreaterThanMax     = "ATTRIBUTE_MIN_GREATER_THAN_MAX"
+
+	// Kontrak
+	MsgInvalidContractID    = "INVALID_CONTRACT_ID"
+	MsgContractNotFound     = "CONTRACT_NOT_FOUND"
+	MsgContractFetchFailed  = "CONTRACT_FETCH_FAILED"
+	MsgContractUpdateFailed = "CONTRACT_UPDATE_FAILED"
+	MsgContractDeleteFailed = "CONTRACT_DELETE_FAILED"
+	MsgContractCreated      = "CONTRACT_CREATED"
+	MsgContractUpdated      = "CONTRACT_UPDATED"
+	MsgContractTrashed      = "CONTRACT_TRASHED"
+
+	// Harga dan penawaran
+	MsgInvalidDate                  = "INVALID_DATE"
+	MsgPriceListNotFound            = "PRICE_LIST_NOT_FOUND"
+	MsgPriceListFetchFailed         = "PRICE_LIST_FETCH_FAILED"
+	MsgPriceListSaveFailed          = "PRICE_LIST_SAVE_FAILED"
+	MsgPriceListDeleteFailed        = "PRICE_LIST_DELETE_FAILED"
+	MsgPriceListCreated             = "PRICE_LIST_CREATED"
+	MsgPriceListUpdated             = "PRICE_LIST_UPDATED"
+	MsgPriceListDeleted             = "PRICE_LIST_DELETED"
+	MsgPriceListPeriodInvalid       = "PRICE_LIST_PERIOD_INVALID"
+	MsgPriceListDuplicateProduct    = "PRICE_LIST_DUPLICATE_PRODUCT"
+	MsgPriceNotFound                = "PRICE_NOT_FOUND"
+	MsgQuotationNotFound            = "QUOTATION_NOT_FOUND"
+	MsgQuotationFetchFailed         = "QUOTATION_FETCH_FAILED"
+	MsgQuotationSaveFailed          = "QUOTATION_SAVE_FAILED"
+	MsgQuotationLocked              = "QUOTATION_LOCKED"
+	MsgQuotationStatusInvalid       = "QUOTATION_STATUS_INVALID"
+	MsgQuotationDiscountTooLarge    = "QUOTATION_DISCOUNT_TOO_LARGE"
+	MsgQuotationCurrencyMismatch    = "QUOTATION_CURRENCY_MISMATCH"
+	MsgQuotationNotAccepted         = "QUOTATION_NOT_ACCEPTED"
+	MsgQuotationVersionNotFound     = "QUOTATION_VERSION_NOT_FOUND"
+	MsgQuotationContractDescription = "QUOTATION_CONTRACT_DESCRIPTION"
+	MsgQuotationDocTitle            = "QUOTATION_DOC_TITLE"
+	MsgQuotationDocNumber           = "QUOTATION_DOC_NUMBER"
+	MsgQuotationDocDate             = "QUOTATION_DOC_DATE"
+	MsgQuotationDocValidUntil       = "QUOTATION_DOC_VALID_UNTIL"
+	MsgQuotationDocVersion          = "QUOTATION_DOC_VERSION"
+	MsgQuotationDocTo               = "QUOTATION_DOC_TO"
+	MsgQuotationDocProduct          = "QUOTATION_DOC_PRODUCT"
+	MsgQuotationDocQuantity         = "QUOTATION_DOC_QUANTITY"
+	MsgQuotationDocUnitPrice        = "QUOTATION_DOC_UNIT_PRICE"
+	MsgQuotationDocDiscount         = "QUOTATION_DOC_DISCOUNT"
+	MsgQuotationDocAmount           = "QUOTATION_DOC_AMOUNT"
+	MsgQuotationDocSubtotal         = "QUOTATION_DOC_SUBTOTAL"
+	MsgQuotationDocTaxBase          = "QUOTATION_DOC_TAX_BASE"
+	MsgQuotationDocTax              = "QUOTATION_DOC_TAX"
+	MsgQuotationDocTotal            = "QUOTATION_DOC_TOTAL"
+	MsgQuotationDocNotes            = "QUOTATION_DOC_NOTES"
+
+	// Inventaris
+	MsgWarehouseNotFound       = "WAREHOUSE_NOT_FOUND"
+	MsgWarehouseIDNotFound     = "WAREHOUSE_ID_NOT_FOUND"
+	MsgWarehouseExists         = "WAREHOUSE_EXISTS"
+	MsgWarehouseFetchFailed    = "WAREHOUSE_FETCH_FAILED"
+	MsgWarehouseSaveFailed     = "WAREHOUSE_SAVE_FAILED"
+	MsgWarehouseDeleteFailed   = "WAREHOUSE_DELETE_FAILED"
+	MsgWarehouseCreated        = "WAREHOUSE_CREATED"
+	MsgWarehouseUpdated        = "WAREHOUSE_UPDATED"
+	MsgWarehouseDeleted        = "WAREHOUSE_DELETED"
+	MsgWarehouseInUse          = "WAREHOUSE_IN_USE"
+	MsgStockNotFound           = "STOCK_NOT_FOUND"
+	MsgStockFetchFailed        = "STOCK_FETCH_FAILED"
+	MsgStockSaveFailed         = "STOCK_SAVE_FAILED"
+	MsgStockSerialDuplicate    = "STOCK_SERIAL_DUPLICATE"
+	MsgStockSerialExists       = "STOCK_SERIAL_EXISTS"
+	MsgStockMoveInvalid        = "STOCK_MOVE_INVALID"
+	MsgStockContractRequired   = "STOCK_CONTRACT_REQUIRED"
+	MsgStockAssignmentRequired = "STOCK_ASSIGNMENT_REQUIRED"
+	MsgStockWarehouseRequired  = "STOCK_WAREHOUSE_REQUIRED"
+	MsgStockLocationRequired   = "STOCK_LOCATION_REQUIRED"
+	MsgStockAdjustmentInvalid  = "STOCK_ADJUSTMENT_INVALID"
+	MsgContractIDNotFound      = "CONTRACT_ID_NOT_FOUND"
+	MsgHistoryIDNotFound       = "HISTORY_ID_NOT_FOUND"
+
+	// Dokumen produk
+	MsgDocumentNotFound       = "DOCUMENT_NOT_FOUND"
+	MsgDocumentFetchFailed    = "DOCUMENT_FETCH_FAILED"
+	MsgDocumentSaveFailed     = "DOCUMENT_SAVE_FAILED"
+	MsgDocumentDeleteFailed   = "DOCUMENT_DELETE_FAILED"
+	MsgDocumentDeleted        = "DOCUMENT_DELETED"
+	MsgDocumentFileRequired   = "DOCUMENT_FILE_REQUIRED"
+	MsgDocumentTypeNotAllowed = "DOCUMENT_TYPE_NOT_ALLOWED"
+	MsgDocumentTooLarge       = "DOCUMENT_TOO_LARGE"
+
+	// Riwayat
+	MsgInvalidHistoryID          = "INVALID_HISTORY_ID"
+	MsgHistoryNotFound           = "HISTORY_NOT_FOUND"
+	MsgHistoryFetchFailed        = "HISTORY_FETCH_FAILED"
+	MsgHistoryUpdateFailed       = "HISTORY_UPDATE_FAILED"
+	MsgHistoryDeleteFailed       = "HISTORY_DELETE_FAILED"
+	MsgHistoryVideosUpdateFailed = "HISTORY_VIDEOS_UPDATE_FAILED"
+	MsgHistoryTagsUpdateFailed   = "HISTORY_TAGS_UPDATE_FAILED"
+	MsgHistorySaved              = "HISTORY_SAVED"
+	MsgHistoryUpdated            = "HISTORY_UPDATED"
+	MsgHistoryTrashed            = "HISTORY_TRASHED"
+
+	// Tag
+	MsgInvalidTagID      = "INVALID_TAG_ID"
+	MsgInvalidTagIDValue = "INVALID_TAG_ID_VALUE"
+	MsgTagNotFound       = "TAG_NOT_FOUND"
+	MsgTagIDNotFound     = "TAG_ID_NOT_FOUND"
+	MsgTagSaveFailed     = "TAG_SAVE_FAILED"
+	MsgTagUpdateFailed   = "TAG_UPDATE_FAILED"
+	MsgTagDeleteFailed   = "TAG_DELETE_FAILED"
+	MsgTagCreated        = "TAG_CREATED"
+	MsgTagUpdated        = "TAG_UPDATED"
+	MsgTagDeleted        = "TAG_DELETED"
+
+	// Video
+	MsgInvalidVideoID      = "INVALID_VIDEO_ID"
+	MsgInvalidVideoIDValue = "INVALID_VIDEO_ID_VALUE"
+	MsgInvalidVideoURL     = "INVALID_VIDEO_URL"
+	MsgVideoNotFound       = "VIDEO_NOT_FOUND"
+	MsgVideoIDNotFound     = "VIDEO_ID_NOT_FOUND"
+	MsgVideoExists         = "VIDEO_EXISTS"
+	MsgVideoInUse          = "VIDEO_IN_USE"
+	MsgVideoFetchFailed    = "VIDEO_FETCH_FAILED"
+	MsgVideoSaveFailed     = "VIDEO_SAVE_FAILED"
+	MsgVideoUpdateFailed   = "VIDEO_UPDATE_FAILED"
+	MsgVideoDeleteFailed   = "VIDEO_DELETE_FAILED"
+	MsgVideoUpdated        = "VIDEO_UPDATED"
+	MsgVideoDeleted        = "VIDEO_DELETED"
+
+	// Slug
+	MsgInvalidSlug = "INVALID_SLUG"
+	MsgSlugTaken   = "SLUG_TAKEN"
+
+	// Katalog, pencarian dan terjemahan
+	MsgCatalogFetchFailed       = "CATALOG_FETCH_FAILED"
+	MsgCatalogFacetsFailed      = "CATALOG_FACETS_FAILED"
+	MsgSearchQueryRequired      = "SEARCH_QUERY_REQUIRED"
+	MsgTranslationsFetchFailed  = "TRANSLATIONS_FETCH_FAILED"
+	MsgTranslationsSaveFailed   = "TRANSLATIONS_SAVE_FAILED"
+	MsgTranslationsSaved        = "TRANSLATIONS_SAVED"
+	MsgInvalidTranslationLocale = "INVALID_TRANSLATION_LOCALE"
+	MsgEntityNotTranslatable    = "ENTITY_NOT_TRANSLATABLE"
+	MsgFieldNotTranslatable     = "FIELD_NOT_TRANSLATABLE"
+
+	// Trash
+	MsgTrashNotFound    = "TRASH_NOT_FOUND"
+	MsgTrashFetchFailed = "TRASH_FETCH_FAILED"
+	MsgTrashInUse       = "TRASH_IN_USE"
+	MsgRestoreFailed    = "RESTORE_FAILED"
+	MsgPurgeFailed      = "PURGE_FAILED"
+	MsgDataRestored     = "DATA_RESTORED"
+	MsgDataPurged       = "DATA_PURGED"
+
+	// Validasi kolom
+	MsgFieldRequired = "FIELD_REQUIRED"
+)
+
+// messageCatalog berisi teks setiap kode pesan per bahasa; argumen diformat dengan fmt
+var messageCatalog = map[string]map[string]string{
+	// Umum
+	MsgInternalError: {
+		models.LocaleIndonesian: "Terjadi kesalahan server",
+		models.LocaleEnglish:    "Internal server error",
+	},
+	MsgSaveChangesFailed: {
+		models.LocaleIndonesian: "Terjadi kesalahan server saat menyimpan perubahan",
+		models.LocaleEnglish:    "Internal server error while saving changes",
+	},
+	MsgInvalidRequestBody: {
+		models.LocaleIndonesian: "Format data yang dikirim tidak valid",
+		models.LocaleEnglish:    "Invalid request body",
+	},
+	MsgValidationFailed: {
+		models.LocaleIndonesian: "Data yang dikirim tidak valid",
+		models.LocaleEnglish:    "The given data was invalid",
+	},
+	MsgInvalidInput: {
+		models.LocaleIndonesian: "Input tidak valid: %s",
+		models.LocaleEnglish:    "Invalid input: %s",
+	},
+	MsgInvalidID: {
+		models.LocaleIndonesian: "ID tidak valid",
+		models.LocaleEnglish:    "Invalid ID",
+	},
+	MsgDataNotFound: {
+		models.LocaleIndonesian: "Data tidak ditemukan",
+		models.LocaleEnglish:    "Data not found",
+	},
+	MsgFileRequired: {
+		models.LocaleIndonesian: "File wajib diunggah",
+		models.LocaleEnglish:    "File is required",
+	},
+	MsgFileSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan file",
+		models.LocaleEnglish:    "Failed to save file",
+	},
+	MsgFileLoadFailed: {
+		models.LocaleIndonesian: "Gagal memuat data file",
+		models.LocaleEnglish:    "Failed to load file data",
+	},
+	MsgFileDeleteFailed: {
+		models.LocaleIndonesian: "Gagal menghapus file",
+		models.LocaleEnglish:    "Failed to delete file",
+	},
+	MsgTitleDescriptionRequired: {
+		models.LocaleIndonesian: "Judul dan deskripsi wajib diisi",
+		models.LocaleEnglish:    "Title and description are required",
+	},
+	MsgSlugGenerateFailed: {
+		models.LocaleIndonesian: "Gagal membuat slug",
+		models.LocaleEnglish:    "Failed to generate slug",
+	},
+	MsgSlugRedirectSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan pengalihan slug",
+		models.LocaleEnglish:    "Failed to save slug redirect",
+	},
+	MsgExportUnsupportedFormat: {
+		models.LocaleIndonesian: "Format ekspor %s tidak didukung, gunakan csv, xlsx atau json",
+		models.LocaleEnglish:    "Export format %s is not supported, use csv, xlsx or json",
+	},
+	MsgExportFailed: {
+		models.LocaleIndonesian: "Gagal mengekspor data",
+		models.LocaleEnglish:    "Failed to export data",
+	},
+
+	// Autentikasi
+	MsgTokenRequired: {
+		models.LocaleIndonesian: "Token diperlukan",
+		models.LocaleEnglish:    "Token is required",
+	},
+	MsgTokenInvalid: {
+		models.LocaleIndonesian: "Token tidak valid",
+		models.LocaleEnglish:    "Invalid token",
+	},
+	MsgTokenUserFailed: {
+		models.LocaleIndonesian: "Gagal mengambil ID pengguna dari token",
+		models.LocaleEnglish:    "Failed to read user ID from token",
+	},
+	MsgAccessDenied: {
+		models.LocaleIndonesian: "Anda tidak memiliki akses",
+		models.LocaleEnglish:    "You do not have access",
+	},
+	MsgInvalidCredentials: {
+		models.LocaleIndonesian: "Email atau kata sandi salah",
+		models.LocaleEnglish:    "Invalid email or password",
+	},
+	MsgJWTSecretMissing: {
+		models.LocaleIndonesian: "Kunci rahasia JWT tidak ditemukan",
+		models.LocaleEnglish:    "JWT secret key not found",
+	},
+	MsgTokenSignFailed: {
+		models.LocaleIndonesian: "Gagal membuat token JWT",
+		models.LocaleEnglish:    "Failed to sign JWT token",
+	},
+	MsgPasswordHashFailed: {
+		models.LocaleIndonesian: "Gagal mengenkripsi kata sandi",
+		models.LocaleEnglish:    "Failed to hash password",
+	},
+
+	// Pengguna
+	MsgInvalidUserID: {
+		models.LocaleIndonesian: "ID pengguna tidak valid",
+		models.LocaleEnglish:    "Invalid user ID",
+	},
+	MsgUserNotFound: {
+		models.LocaleIndonesian: "Pengguna tidak ditemukan",
+		models.LocaleEnglish:    "User not found",
+	},
+	MsgUserIDNotFound: {
+		models.LocaleIndonesian: "Pengguna dengan ID %d tidak ditemukan",
+		models.LocaleEnglish:    "User with ID %d not found",
+	},
+	MsgUserCreateFailed: {
+		models.LocaleIndonesian: "Gagal membuat pengguna",
+		models.LocaleEnglish:    "Failed to create user",
+	},
+	MsgUserSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan pengguna",
+		models.LocaleEnglish:    "Failed to save user",
+	},
+	MsgUserDeleteFailed: {
+		models.LocaleIndonesian: "Gagal menghapus pengguna",
+		models.LocaleEnglish:    "Failed to delete user",
+	},
+	MsgUserCreated: {
+		models.LocaleIndonesian: "Pengguna berhasil dibuat",
+		models.LocaleEnglish:    "User created successfully",
+	},
+	MsgUserUpdated: {
+		models.LocaleIndonesian: "Pengguna berhasil diperbarui",
+		models.LocaleEnglish:    "User updated successfully",
+	},
+	MsgUserDeleted: {
+		models.LocaleIndonesian: "Pengguna berhasil dihapus",
+		models.LocaleEnglish:    "User deleted successfully",
+	},
+	MsgUserTrashed: {
+		models.LocaleIndonesian: "Pengguna dipindahkan ke trash",
+		models.LocaleEnglish:    "User moved to trash",
+	},
+	MsgAvatarDeleted: {
+		models.LocaleIndonesian: "Avatar berhasil dihapus",
+		models.LocaleEnglish:    "Avatar deleted successfully",
+	},
+	MsgAvatarInvalidImage: {
+		models.LocaleIndonesian: "File harus berupa gambar JPEG atau PNG",
+		models.LocaleEnglish:    "File must be a JPEG or PNG image",
+	},
+	MsgCropOutOfBounds: {
+		models.LocaleIndonesian: "Area crop berada di luar gambar",
+		models.LocaleEnglish:    "Crop area is outside the image",
+	},
+	MsgCropUnsupportedFormat: {
+		models.LocaleIndonesian: "Format %s tidak didukung untuk crop",
+		models.LocaleEnglish:    "Format %s is not supported for cropping",
+	},
+
+	// Produk
+	MsgInvalidProductID: {
+		models.LocaleIndonesian: "ID produk tidak valid",
+		models.LocaleEnglish:    "Invalid product ID",
+	},
+	MsgProductNotFound: {
+		models.LocaleIndonesian: "Produk tidak ditemukan",
+		models.LocaleEnglish:    "Product not found",
+	},
+	MsgProductIDNotFound: {
+		models.LocaleIndonesian: "Produk dengan ID %d tidak ditemukan",
+		models.LocaleEnglish:    "Product with ID %d not found",
+	},
+	MsgInvalidProductIDValue: {
+		models.LocaleIndonesian: "ID produk %q tidak valid",
+		models.LocaleEnglish:    "Invalid product ID %q",
+	},
+	MsgCompareIDsRequired: {
+		models.LocaleIndonesian: "ids harus berisi %d sampai %d ID produk",
+		models.LocaleEnglish:    "ids must contain %d to %d product IDs",
+	},
+	MsgRecommendationFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil rekomendasi produk",
+		models.LocaleEnglish:    "Failed to fetch product recommendations",
+	},
+	MsgRecommendationRebuildFailed: {
+		models.LocaleIndonesian: "Gagal menghitung ulang rekomendasi produk",
+		models.LocaleEnglish:    "Failed to rebuild product recommendations",
+	},
+	MsgRecommendationRebuilt: {
+		models.LocaleIndonesian: "Rekomendasi produk berhasil dihitung ulang",
+		models.LocaleEnglish:    "Product recommendations rebuilt successfully",
+	},
+	MsgBrochureFailed: {
+		models.LocaleIndonesian: "Gagal membuat brosur PDF",
+		models.LocaleEnglish:    "Failed to generate PDF brochure",
+	},
+	MsgCatalogEmpty: {
+		models.LocaleIndonesian: "Kategori ini belum memiliki produk yang terbit",
+		models.LocaleEnglish:    "This category has no published products yet",
+	},
+	MsgBrochureDescription: {
+		models.LocaleIndonesian: "Deskripsi",
+		models.LocaleEnglish:    "Description",
+	},
+	MsgBrochureSpecification: {
+		models.LocaleIndonesian: "Spesifikasi",
+		models.LocaleEnglish:    "Specification",
+	},
+	MsgBrochureAttributes: {
+		models.LocaleIndonesian: "Data Teknis",
+		models.LocaleEnglish:    "Technical Data",
+	},
+	MsgBrochureGallery: {
+		models.LocaleIndonesian: "Galeri",
+		models.LocaleEnglish:    "Gallery",
+	},
+	MsgBrochureCatalog: {
+		models.LocaleIndonesian: "Katalog Produk",
+		models.LocaleEnglish:    "Product Catalog",
+	},
+	MsgBrochureProductCount: {
+		models.LocaleIndonesian: "%d produk",
+		models.LocaleEnglish:    "%d products",
+	},
+	MsgBrochureUpdated: {
+		models.LocaleIndonesian: "Diperbarui %s",
+		models.LocaleEnglish:    "Updated %s",
+	},
+	MsgBrochurePage: {
+		models.LocaleIndonesian: "Halaman %d dari %s",
+		models.LocaleEnglish:    "Page %d of %s",
+	},
+	MsgImportUnsupportedFormat: {
+		models.LocaleIndonesian: "Format file %s tidak didukung, gunakan CSV atau XLSX",
+		models.LocaleEnglish:    "File format %s is not supported, use CSV or XLSX",
+	},
+	MsgImportReadFailed: {
+		models.LocaleIndonesian: "File import tidak dapat dibaca",
+		models.LocaleEnglish:    "Import file cannot be read",
+	},
+	MsgImportEmpty: {
+		models.LocaleIndonesian: "File import tidak berisi baris data",
+		models.LocaleEnglish:    "Import file has no data rows",
+	},
+	MsgImportColumnRequired: {
+		models.LocaleIndonesian: "Kolom %s wajib ada pada baris judul",
+		models.LocaleEnglish:    "Column %s is required in the header row",
+	},
+	MsgImportImagesNotZip: {
+		models.LocaleIndonesian: "File gambar harus berupa ZIP",
+		models.LocaleEnglish:    "Images file must be a ZIP archive",
+	},
+	MsgImportHasErrors: {
+		models.LocaleIndonesian: "Import dibatalkan karena %d baris tidak valid",
+		models.LocaleEnglish:    "Import aborted because %d rows are invalid",
+	},
+	MsgImportFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan hasil import",
+		models.LocaleEnglish:    "Failed to save imported products",
+	},
+	MsgImportCategoryNotFound: {
+		models.LocaleIndonesian: "Kategori %q tidak ditemukan",
+		models.LocaleEnglish:    "Category %q not found",
+	},
+	MsgImportSlugDuplicate: {
+		models.LocaleIndonesian: "Slug %q dipakai lebih dari satu baris",
+		models.LocaleEnglish:    "Slug %q is used by more than one row",
+	},
+	MsgImportImageNotInArchive: {
+		models.LocaleIndonesian: "Gambar %q tidak ada di file ZIP",
+		models.LocaleEnglish:    "Image %q is not in the ZIP archive",
+	},
+	MsgImportImageURLInvalid: {
+		models.LocaleIndonesian: "Gambar %q harus berupa URL http(s) atau nama file di ZIP",
+		models.LocaleEnglish:    "Image %q must be an http(s) URL or a file name in the ZIP archive",
+	},
+	MsgImportImageDownloadFailed: {
+		models.LocaleIndonesian: "Gagal mengunduh gambar %q",
+		models.LocaleEnglish:    "Failed to download image %q",
+	},
+	MsgImportImageInvalid: {
+		models.LocaleIndonesian: "File %q bukan gambar yang valid",
+		models.LocaleEnglish:    "File %q is not a valid image",
+	},
+	MsgImportImageTooLarge: {
+		models.LocaleIndonesian: "Gambar %q melebihi %d MB",
+		models.LocaleEnglish:    "Image %q exceeds %d MB",
+	},
+	MsgProductIDsRequired: {
+		models.LocaleIndonesian: "Minimal satu produk harus dipilih",
+		models.LocaleEnglish:    "At least one product is required",
+	},
+	MsgProductFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil produk",
+		models.LocaleEnglish:    "Failed to fetch product",
+	},
+	MsgProductSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan produk",
+		models.LocaleEnglish:    "Failed to save product",
+	},
+	MsgProductUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui produk",
+		models.LocaleEnglish:    "Failed to update product",
+	},
+	MsgProductDeleteFailed: {
+		models.LocaleIndonesian: "Gagal menghapus produk",
+		models.LocaleEnglish:    "Failed to delete product",
+	},
+	MsgProductAttributesSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan atribut produk",
+		models.LocaleEnglish:    "Failed to save product attributes",
+	},
+	MsgProductVideosUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui video produk",
+		models.LocaleEnglish:    "Failed to update product videos",
+	},
+	MsgProductTagsUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui tag produk",
+		models.LocaleEnglish:    "Failed to update product tags",
+	},
+	MsgProductRevisionSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan revisi produk",
+		models.LocaleEnglish:    "Failed to save product revision",
+	},
+	MsgProductSaved: {
+		models.LocaleIndonesian: "Produk berhasil disimpan",
+		models.LocaleEnglish:    "Product saved successfully",
+	},
+	MsgProductUpdated: {
+		models.LocaleIndonesian: "Produk berhasil diperbarui",
+		models.LocaleEnglish:    "Product updated successfully",
+	},
+	MsgProductDeleted: {
+		models.LocaleIndonesian: "Produk berhasil dihapus",
+		models.LocaleEnglish:    "Product deleted successfully",
+	},
+	MsgProductTrashed: {
+		models.LocaleIndonesian: "Produk dipindahkan ke trash",
+		models.LocaleEnglish:    "Product moved to trash",
+	},
+	MsgGalleryFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil galeri",
+		models.LocaleEnglish:    "Failed to fetch gallery",
+	},
+	MsgGallerySaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan galeri",
+		models.LocaleEnglish:    "Failed to save gallery",
+	},
+	MsgHeroFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil hero",
+		models.LocaleEnglish:    "Failed to fetch heroes",
+	},
+	MsgHeroSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan hero",
+		models.LocaleEnglish:    "Failed to save hero",
+	},
+	MsgMediaNotFound: {
+		models.LocaleIndonesian: "Media tidak ditemukan",
+		models.LocaleEnglish:    "Media not found",
+	},
+	MsgMediaFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil media",
+		models.LocaleEnglish:    "Failed to fetch media",
+	},
+	MsgRevisionNotFound: {
+		models.LocaleIndonesian: "Revisi tidak ditemukan",
+		models.LocaleEnglish:    "Revision not found",
+	},
+	MsgRevisionFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil revisi",
+		models.LocaleEnglish:    "Failed to fetch revisions",
+	},
+	MsgRevisionRestoreFailed: {
+		models.LocaleIndonesian: "Gagal memulihkan revisi",
+		models.LocaleEnglish:    "Failed to restore revision",
+	},
+	MsgRevisionRangeRequired: {
+		models.LocaleIndonesian: "from dan to wajib diisi",
+		models.LocaleEnglish:    "from and to are required",
+	},
+	MsgStatusUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui status",
+		models.LocaleEnglish:    "Failed to update status",
+	},
+	MsgInvalidStatus: {
+		models.LocaleIndonesian: "Status harus salah satu dari draft, in_review, published, archived",
+		models.LocaleEnglish:    "Status must be one of draft, in_review, published, archived",
+	},
+	MsgInvalidPublishAt: {
+		models.LocaleIndonesian: "publish_at harus berupa tanggal dan waktu yang valid",
+		models.LocaleEnglish:    "publish_at must be a valid date time",
+	},
+
+	// Kategori dan atribut
+	MsgInvalidCategoryID: {
+		models.LocaleIndonesian: "ID kategori tidak valid",
+		models.LocaleEnglish:    "Invalid category ID",
+	},
+	MsgInvalidCategoryIDValue: {
+		models.LocaleIndonesian: "category_id %q tidak valid",
+		models.LocaleEnglish:    "Invalid category_id %q",
+	},
+	MsgCategoryNotFound: {
+		models.LocaleIndonesian: "Kategori tidak ditemukan",
+		models.LocaleEnglish:    "Category not found",
+	},
+	MsgCategoryFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil kategori",
+		models.LocaleEnglish:    "Failed to fetch category",
+	},
+	MsgCategoryCreated: {
+		models.LocaleIndonesian: "Kategori berhasil dibuat",
+		models.LocaleEnglish:    "Category created successfully",
+	},
+	MsgCategoryUpdated: {
+		models.LocaleIndonesian: "Kategori berhasil diperbarui",
+		models.LocaleEnglish:    "Category updated successfully",
+	},
+	MsgCategoryMoved: {
+		models.LocaleIndonesian: "Kategori berhasil dipindahkan",
+		models.LocaleEnglish:    "Category moved successfully",
+	},
+	MsgCategoryParentNotFound: {
+		models.LocaleIndonesian: "Kategori induk tidak ditemukan",
+		models.LocaleEnglish:    "Parent category not found",
+	},
+	MsgCategoryCycle: {
+		models.LocaleIndonesian: "Kategori tidak dapat dipindahkan ke dirinya sendiri atau turunannya",
+		models.LocaleEnglish:    "Category cannot be moved into itself or its descendants",
+	},
+	MsgCategoryAttributesUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui atribut kategori",
+		models.LocaleEnglish:    "Failed to update category attributes",
+	},
+	MsgAttributeUnknown: {
+		models.LocaleIndonesian: "Atribut %s tidak dikenal",
+		models.LocaleEnglish:    "Unknown attribute %s",
+	},
+	MsgAttributeNotNumber: {
+		models.LocaleIndonesian: "Atribut %s harus berupa angka",
+		models.LocaleEnglish:    "Attribute %s must be a number",
+	},
+	MsgAttributeBoundUnsupported: {
+		models.LocaleIndonesian: "Atribut %s tidak mendukung %s",
+		models.LocaleEnglish:    "Attribute %s does not support %s",
+	},
+	MsgAttributeNotBoolean: {
+		models.LocaleIndonesian: "Atribut %s harus berupa boolean",
+		models.LocaleEnglish:    "Attribute %s must be a boolean",
+	},
+	MsgAttributeNotRange: {
+		models.LocaleIndonesian: "Atribut %s harus berupa rentang dengan min dan max",
+		models.LocaleEnglish:    "Attribute %s must be a range with min and max",
+	},
+	MsgAttributeRangeInverted: {
+		models.LocaleIndonesian: "Nilai min atribut %s tidak boleh lebih besar dari max",
+		models.LocaleEnglish:    "Attribute %s min must not be greater than max",
+	},
+	MsgAttributeNotOption: {
+		models.LocaleIndonesian: "Atribut %s harus salah satu dari %s",
+		models.LocaleEnglish:    "Attribute %s must be one of %s",
+	},
+	MsgAttributeBetween: {
+		models.LocaleIndonesian: "Atribut %s harus di antara %g dan %g",
+		models.LocaleEnglish:    "Attribute %s must be between %g and %g",
+	},
+	MsgAttributeAtLeast: {
+		models.LocaleIndonesian: "Atribut %s minimal %g",
+		models.LocaleEnglish:    "Attribute %s must be at least %g",
+	},
+	MsgAttributeAtMost: {
+		models.LocaleIndonesian: "Atribut %s maksimal %g",
+		models.LocaleEnglish:    "Attribute %s must be at most %g",
+	},
+	MsgAttributeNotDefined: {
+		models.LocaleIndonesian: "Atribut %s tidak didefinisikan untuk kategori ini",
+		models.LocaleEnglish:    "Attribute %s is not defined for this category",
+	},
+	MsgAttributesNotObject: {
+		models.LocaleIndonesian: "attributes harus berupa objek JSON",
+		models.LocaleEnglish:    "attributes must be a JSON object",
+	},
+	MsgAttributeNameInvalid: {
+		models.LocaleIndonesian: "%s hanya boleh berisi huruf kecil, angka atau garis bawah",
+		models.LocaleEnglish:    "%s must be lowercase letters, digits or underscores",
+	},
+	MsgAttributeDuplicated: {
+		models.LocaleIndonesian: "%s duplikat",
+		models.LocaleEnglish:    "%s is duplicated",
+	},
+	MsgAttributeMinGreaterThanMax: {
+		models.LocaleIndonesian: "%s tidak boleh lebih besar dari max",
+		models.LocaleEnglish:    "%s must not be greater than max",
+	},
+
+	// Kontrak
+	MsgInvalidContractID: {
+		models.LocaleIndonesian: "ID kontrak tidak valid",
+		models.LocaleEnglish:    "Invalid contract ID",
+	},
+	MsgContractNotFound: {
+		models.LocaleIndonesian: "Kontrak tidak ditemukan",
+		models.LocaleEnglish:    "Contract not found",
+	},
+	MsgContractFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil kontrak",
+		models.LocaleEnglish:    "Failed to fetch contracts",
+	},
+	MsgContractUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui kontrak",
+		models.LocaleEnglish:    "Failed to update contract",
+	},
+	MsgContractDeleteFailed: {
+		models.LocaleIndonesian: "Gagal menghapus kontrak",
+		models.LocaleEnglish:    "Failed to delete contract",
+	},
+	MsgContractCreated: {
+		models.LocaleIndonesian: "Kontrak berhasil dibuat",
+		models.LocaleEnglish:    "Contract created successfully",
+	},
+	MsgContractUpdated: {
+		models.LocaleIndonesian: "Kontrak berhasil diperbarui",
+		models.LocaleEnglish:    "Contract updated successfully",
+	},
+	MsgContractTrashed: {
+		models.LocaleIndonesian: "Kontrak dipindahkan ke trash",
+		models.LocaleEnglish:    "Contract moved to trash",
+	},
+
+	// Harga dan penawaran
+	MsgInvalidDate: {
+		models.LocaleIndonesian: "Tanggal %s tidak valid, gunakan format YYYY-MM-DD",
+		models.LocaleEnglish:    "Invalid date %s, use the YYYY-MM-DD format",
+	},
+	MsgPriceListNotFound: {
+		models.LocaleIndonesian: "Daftar harga tidak ditemukan",
+		models.LocaleEnglish:    "Price list not found",
+	},
+	MsgPriceListFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil daftar harga",
+		models.LocaleEnglish:    "Failed to fetch price lists",
+	},
+	MsgPriceListSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan daftar harga",
+		models.LocaleEnglish:    "Failed to save price list",
+	},
+	MsgPriceListDeleteFailed: {
+		models.LocaleIndonesian: "Gagal menghapus daftar harga",
+		models.LocaleEnglish:    "Failed to delete price list",
+	},
+	MsgPriceListCreated: {
+		models.LocaleIndonesian: "Daftar harga berhasil dibuat",
+		models.LocaleEnglish:    "Price list created successfully",
+	},
+	MsgPriceListUpdated: {
+		models.LocaleIndonesian: "Daftar harga berhasil diperbarui",
+		models.LocaleEnglish:    "Price list updated successfully",
+	},
+	MsgPriceListDeleted: {
+		models.LocaleIndonesian: "Daftar harga berhasil dihapus",
+		models.LocaleEnglish:    "Price list deleted successfully",
+	},
+	MsgPriceListPeriodInvalid: {
+		models.LocaleIndonesian: "Tanggal akhir berlaku tidak boleh sebelum tanggal mulai berlaku",
+		models.LocaleEnglish:    "The valid until date cannot be before the valid from date",
+	},
+	MsgPriceListDuplicateProduct: {
+		models.LocaleIndonesian: "Produk %d tercantum lebih dari sekali",
+		models.LocaleEnglish:    "Product %d is listed more than once",
+	},
+	MsgPriceNotFound: {
+		models.LocaleIndonesian: "Belum ada harga yang berlaku untuk produk %d",
+		models.LocaleEnglish:    "No price is currently valid for product %d",
+	},
+	MsgQuotationNotFound: {
+		models.LocaleIndonesian: "Penawaran tidak ditemukan",
+		models.LocaleEnglish:    "Quotation not found",
+	},
+	MsgQuotationFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil penawaran",
+		models.LocaleEnglish:    "Failed to fetch quotations",
+	},
+	MsgQuotationSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan penawaran",
+		models.LocaleEnglish:    "Failed to save quotation",
+	},
+	MsgQuotationLocked: {
+		models.LocaleIndonesian: "Penawaran berstatus %s tidak dapat diubah",
+		models.LocaleEnglish:    "A quotation with status %s cannot be changed",
+	},
+	MsgQuotationStatusInvalid: {
+		models.LocaleIndonesian: "Status penawaran tidak dapat diubah dari %s ke %s",
+		models.LocaleEnglish:    "Quotation status cannot change from %s to %s",
+	},
+	MsgQuotationDiscountTooLarge: {
+		models.LocaleIndonesian: "Diskon tidak boleh melebihi subtotal",
+		models.LocaleEnglish:    "The discount cannot exceed the subtotal",
+	},
+	MsgQuotationCurrencyMismatch: {
+		models.LocaleIndonesian: "Harga produk %d dalam %s, sedangkan penawaran dalam %s",
+		models.LocaleEnglish:    "Product %d is priced in %s, but the quotation is in %s",
+	},
+	MsgQuotationNotAccepted: {
+		models.LocaleIndonesian: "Hanya penawaran yang sudah diterima yang dapat dijadikan kontrak",
+		models.LocaleEnglish:    "Only accepted quotations can be converted into a contract",
+	},
+	MsgQuotationVersionNotFound: {
+		models.LocaleIndonesian: "Versi penawaran tidak ditemukan",
+		models.LocaleEnglish:    "Quotation version not found",
+	},
+	MsgQuotationContractDescription: {
+		models.LocaleIndonesian: "Dibuat dari penawaran %s versi %d",
+		models.LocaleEnglish:    "Created from quotation %s version %d",
+	},
+	MsgQuotationDocTitle: {
+		models.LocaleIndonesian: "PENAWARAN HARGA",
+		models.LocaleEnglish:    "QUOTATION",
+	},
+	MsgQuotationDocNumber: {
+		models.LocaleIndonesian: "Nomor",
+		models.LocaleEnglish:    "Number",
+	},
+	MsgQuotationDocDate: {
+		models.LocaleIndonesian: "Tanggal",
+		models.LocaleEnglish:    "Date",
+	},
+	MsgQuotationDocValidUntil: {
+		models.LocaleIndonesian: "Berlaku hingga",
+		models.LocaleEnglish:    "Valid until",
+	},
+	MsgQuotationDocVersion: {
+		models.LocaleIndonesian: "Versi",
+		models.LocaleEnglish:    "Version",
+	},
+	MsgQuotationDocTo: {
+		models.LocaleIndonesian: "Kepada",
+		models.LocaleEnglish:    "To",
+	},
+	MsgQuotationDocProduct: {
+		models.LocaleIndonesian: "Produk",
+		models.LocaleEnglish:    "Product",
+	},
+	MsgQuotationDocQuantity: {
+		models.LocaleIndonesian: "Qty",
+		models.LocaleEnglish:    "Qty",
+	},
+	MsgQuotationDocUnitPrice: {
+		models.LocaleIndonesian: "Harga Satuan",
+		models.LocaleEnglish:    "Unit Price",
+	},
+	MsgQuotationDocDiscount: {
+		models.LocaleIndonesian: "Diskon",
+		models.LocaleEnglish:    "Discount",
+	},
+	MsgQuotationDocAmount: {
+		models.LocaleIndonesian: "Jumlah",
+		models.LocaleEnglish:    "Amount",
+	},
+	MsgQuotationDocSubtotal: {
+		models.LocaleIndonesian: "Subtotal",
+		models.LocaleEnglish:    "Subtotal",
+	},
+	MsgQuotationDocTaxBase: {
+		models.LocaleIndonesian: "DPP",
+		models.LocaleEnglish:    "Tax Base",
+	},
+	MsgQuotationDocTax: {
+		models.LocaleIndonesian: "PPN %s%%",
+		models.LocaleEnglish:    "VAT (PPN) %s%%",
+	},
+	MsgQuotationDocTotal: {
+		models.LocaleIndonesian: "Total",
+		models.LocaleEnglish:    "Total",
+	},
+	MsgQuotationDocNotes: {
+		models.LocaleIndonesian: "Catatan",
+		models.LocaleEnglish:    "Notes",
+	},
+
+	// Inventaris
+	MsgWarehouseNotFound: {
+		models.LocaleIndonesian: "Gudang tidak ditemukan",
+		models.LocaleEnglish:    "Warehouse not found",
+	},
+	MsgWarehouseIDNotFound: {
+		models.LocaleIndonesian: "Gudang dengan ID %d tidak ditemukan",
+		models.LocaleEnglish:    "Warehouse with ID %d not found",
+	},
+	MsgWarehouseExists: {
+		models.LocaleIndonesian: "Kode gudang %s sudah dipakai",
+		models.LocaleEnglish:    "Warehouse code %s is already in use",
+	},
+	MsgWarehouseFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil data gudang",
+		models.LocaleEnglish:    "Failed to fetch warehouses",
+	},
+	MsgWarehouseSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan gudang",
+		models.LocaleEnglish:    "Failed to save warehouse",
+	},
+	MsgWarehouseDeleteFailed: {
+		models.LocaleIndonesian: "Gagal menghapus gudang",
+		models.LocaleEnglish:    "Failed to delete warehouse",
+	},
+	MsgWarehouseCreated: {
+		models.LocaleIndonesian: "Gudang berhasil dibuat",
+		models.LocaleEnglish:    "Warehouse created successfully",
+	},
+	MsgWarehouseUpdated: {
+		models.LocaleIndonesian: "Gudang berhasil diperbarui",
+		models.LocaleEnglish:    "Warehouse updated successfully",
+	},
+	MsgWarehouseDeleted: {
+		models.LocaleIndonesian: "Gudang berhasil dihapus",
+		models.LocaleEnglish:    "Warehouse deleted successfully",
+	},
+	MsgWarehouseInUse: {
+		models.LocaleIndonesian: "Gudang masih menyimpan %d unit",
+		models.LocaleEnglish:    "The warehouse still holds %d units",
+	},
+	MsgStockNotFound: {
+		models.LocaleIndonesian: "Unit tidak ditemukan",
+		models.LocaleEnglish:    "Stock item not found",
+	},
+	MsgStockFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil data stok",
+		models.LocaleEnglish:    "Failed to fetch stock",
+	},
+	MsgStockSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan data stok",
+		models.LocaleEnglish:    "Failed to save stock",
+	},
+	MsgStockSerialDuplicate: {
+		models.LocaleIndonesian: "Nomor seri %s tercantum lebih dari sekali",
+		models.LocaleEnglish:    "Serial number %s is listed more than once",
+	},
+	MsgStockSerialExists: {
+		models.LocaleIndonesian: "Nomor seri %s sudah terdaftar",
+		models.LocaleEnglish:    "Serial number %s is already registered",
+	},
+	MsgStockMoveInvalid: {
+		models.LocaleIndonesian: "Unit berstatus %s tidak dapat diproses dengan alasan %s",
+		models.LocaleEnglish:    "A unit with status %s cannot be processed with reason %s",
+	},
+	MsgStockContractRequired: {
+		models.LocaleIndonesian: "Alasan %s memerlukan kontrak",
+		models.LocaleEnglish:    "Reason %s requires a contract",
+	},
+	MsgStockAssignmentRequired: {
+		models.LocaleIndonesian: "Pemasangan memerlukan kontrak atau riwayat pemasangan",
+		models.LocaleEnglish:    "Installation requires a contract or an installation history",
+	},
+	MsgStockWarehouseRequired: {
+		models.LocaleIndonesian: "Alasan %s memerlukan gudang tujuan",
+		models.LocaleEnglish:    "Reason %s requires a destination warehouse",
+	},
+	MsgStockLocationRequired: {
+		models.LocaleIndonesian: "Perpindahan memerlukan gudang atau lokasi baru",
+		models.LocaleEnglish:    "A transfer requires a new warehouse or location",
+	},
+	MsgStockAdjustmentInvalid: {
+		models.LocaleIndonesian: "Penyesuaian memerlukan status dan catatan",
+		models.LocaleEnglish:    "An adjustment requires a status and a note",
+	},
+	MsgContractIDNotFound: {
+		models.LocaleIndonesian: "Kontrak dengan ID %d tidak ditemukan",
+		models.LocaleEnglish:    "Contract with ID %d not found",
+	},
+	MsgHistoryIDNotFound: {
+		models.LocaleIndonesian: "Riwayat pemasangan dengan ID %d tidak ditemukan",
+		models.LocaleEnglish:    "Installation history with ID %d not found",
+	},
+
+	// Dokumen produk
+	MsgDocumentNotFound: {
+		models.LocaleIndonesian: "Dokumen tidak ditemukan",
+		models.LocaleEnglish:    "Document not found",
+	},
+	MsgDocumentFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil dokumen",
+		models.LocaleEnglish:    "Failed to fetch documents",
+	},
+	MsgDocumentSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan dokumen",
+		models.LocaleEnglish:    "Failed to save document",
+	},
+	MsgDocumentDeleteFailed: {
+		models.LocaleIndonesian: "Gagal menghapus dokumen",
+		models.LocaleEnglish:    "Failed to delete document",
+	},
+	MsgDocumentDeleted: {
+		models.LocaleIndonesian: "Dokumen berhasil dihapus",
+		models.LocaleEnglish:    "Document deleted successfully",
+	},
+	MsgDocumentFileRequired: {
+		models.LocaleIndonesian: "File dokumen wajib diunggah",
+		models.LocaleEnglish:    "A document file is required",
+	},
+	MsgDocumentTypeNotAllowed: {
+		models.LocaleIndonesian: "Jenis file %s tidak diizinkan untuk dokumen",
+		models.LocaleEnglish:    "File type %s is not allowed for documents",
+	},
+	MsgDocumentTooLarge: {
+		models.LocaleIndonesian: "Ukuran dokumen maksimal %d MB",
+		models.LocaleEnglish:    "Documents may be at most %d MB",
+	},
+
+	// Riwayat
+	MsgInvalidHistoryID: {
+		models.LocaleIndonesian: "ID riwayat tidak valid",
+		models.LocaleEnglish:    "Invalid history ID",
+	},
+	MsgHistoryNotFound: {
+		models.LocaleIndonesian: "Riwayat tidak ditemukan",
+		models.LocaleEnglish:    "History not found",
+	},
+	MsgHistoryFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil riwayat",
+		models.LocaleEnglish:    "Failed to fetch histories",
+	},
+	MsgHistoryUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui riwayat",
+		models.LocaleEnglish:    "Failed to update history",
+	},
+	MsgHistoryDeleteFailed: {
+		models.LocaleIndonesian: "Gagal menghapus riwayat",
+		models.LocaleEnglish:    "Failed to delete history",
+	},
+	MsgHistoryVideosUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui video riwayat",
+		models.LocaleEnglish:    "Failed to update history videos",
+	},
+	MsgHistoryTagsUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui tag riwayat",
+		models.LocaleEnglish:    "Failed to update history tags",
+	},
+	MsgHistorySaved: {
+		models.LocaleIndonesian: "Riwayat berhasil disimpan",
+		models.LocaleEnglish:    "History saved successfully",
+	},
+	MsgHistoryUpdated: {
+		models.LocaleIndonesian: "Riwayat berhasil diperbarui",
+		models.LocaleEnglish:    "History updated successfully",
+	},
+	MsgHistoryTrashed: {
+		models.LocaleIndonesian: "Riwayat dipindahkan ke trash",
+		models.LocaleEnglish:    "History moved to trash",
+	},
+
+	// Tag
+	MsgInvalidTagID: {
+		models.LocaleIndonesian: "ID tag tidak valid",
+		models.LocaleEnglish:    "Invalid tag ID",
+	},
+	MsgInvalidTagIDValue: {
+		models.LocaleIndonesian: "ID tag %q tidak valid",
+		models.LocaleEnglish:    "Invalid tag ID %q",
+	},
+	MsgTagNotFound: {
+		models.LocaleIndonesian: "Tag tidak ditemukan",
+		models.LocaleEnglish:    "Tag not found",
+	},
+	MsgTagIDNotFound: {
+		models.LocaleIndonesian: "Tag dengan ID %d tidak ditemukan",
+		models.LocaleEnglish:    "Tag with ID %d not found",
+	},
+	MsgTagSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan tag",
+		models.LocaleEnglish:    "Failed to save tag",
+	},
+	MsgTagUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui tag",
+		models.LocaleEnglish:    "Failed to update tag",
+	},
+	MsgTagDeleteFailed: {
+		models.LocaleIndonesian: "Gagal menghapus tag",
+		models.LocaleEnglish:    "Failed to delete tag",
+	},
+	MsgTagCreated: {
+		models.LocaleIndonesian: "Tag berhasil dibuat",
+		models.LocaleEnglish:    "Tag created successfully",
+	},
+	MsgTagUpdated: {
+		models.LocaleIndonesian: "Tag berhasil diperbarui",
+		models.LocaleEnglish:    "Tag updated successfully",
+	},
+	MsgTagDeleted: {
+		models.LocaleIndonesian: "Tag berhasil dihapus",
+		models.LocaleEnglish:    "Tag deleted successfully",
+	},
+
+	// Video
+	MsgInvalidVideoID: {
+		models.LocaleIndonesian: "ID video tidak valid",
+		models.LocaleEnglish:    "Invalid video ID",
+	},
+	MsgInvalidVideoIDValue: {
+		models.LocaleIndonesian: "ID video %q tidak valid",
+		models.LocaleEnglish:    "Invalid video ID %q",
+	},
+	MsgInvalidVideoURL: {
+		models.LocaleIndonesian: "URL video tidak valid, gunakan tautan atau ID YouTube/Vimeo",
+		models.LocaleEnglish:    "Invalid video URL, use a YouTube/Vimeo link or ID",
+	},
+	MsgVideoNotFound: {
+		models.LocaleIndonesian: "Video tidak ditemukan",
+		models.LocaleEnglish:    "Video not found",
+	},
+	MsgVideoIDNotFound: {
+		models.LocaleIndonesian: "Video dengan ID %d tidak ditemukan",
+		models.LocaleEnglish:    "Video with ID %d not found",
+	},
+	MsgVideoExists: {
+		models.LocaleIndonesian: "Video sudah ada dengan ID %d",
+		models.LocaleEnglish:    "Video already exists with ID %d",
+	},
+	MsgVideoInUse: {
+		models.LocaleIndonesian: "Video masih dipakai oleh %d riwayat dan %d produk",
+		models.LocaleEnglish:    "Video is still used by %d histories and %d products",
+	},
+	MsgVideoFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil video",
+		models.LocaleEnglish:    "Failed to fetch videos",
+	},
+	MsgVideoSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan video",
+		models.LocaleEnglish:    "Failed to save video",
+	},
+	MsgVideoUpdateFailed: {
+		models.LocaleIndonesian: "Gagal memperbarui video",
+		models.LocaleEnglish:    "Failed to update video",
+	},
+	MsgVideoDeleteFailed: {
+		models.LocaleIndonesian: "Gagal menghapus video",
+		models.LocaleEnglish:    "Failed to delete video",
+	},
+	MsgVideoUpdated: {
+		models.LocaleIndonesian: "Video berhasil diperbarui",
+		models.LocaleEnglish:    "Video updated successfully",
+	},
+	MsgVideoDeleted: {
+		models.LocaleIndonesian: "Video berhasil dihapus",
+		models.LocaleEnglish:    "Video deleted successfully",
+	},
+
+	// Slug
+	MsgInvalidSlug: {
+		models.LocaleIndonesian: "Slug harus mengandung huruf atau angka",
+		models.LocaleEnglish:    "Slug must contain letters or digits",
+	},
+	MsgSlugTaken: {
+		models.LocaleIndonesian: "Slug sudah dipakai",
+		models.LocaleEnglish:    "Slug is already used",
+	},
+
+	// Katalog, pencarian dan terjemahan
+	MsgCatalogFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil katalog",
+		models.LocaleEnglish:    "Failed to fetch catalog",
+	},
+	MsgCatalogFacetsFailed: {
+		models.LocaleIndonesian: "Gagal menghitung facet katalog",
+		models.LocaleEnglish:    "Failed to compute catalog facets",
+	},
+	MsgSearchQueryRequired: {
+		models.LocaleIndonesian: "q wajib diisi",
+		models.LocaleEnglish:    "q is required",
+	},
+	MsgTranslationsFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil terjemahan",
+		models.LocaleEnglish:    "Failed to fetch translations",
+	},
+	MsgTranslationsSaveFailed: {
+		models.LocaleIndonesian: "Gagal menyimpan terjemahan",
+		models.LocaleEnglish:    "Failed to save translations",
+	},
+	MsgTranslationsSaved: {
+		models.LocaleIndonesian: "Terjemahan berhasil disimpan",
+		models.LocaleEnglish:    "Translations saved successfully",
+	},
+	MsgInvalidTranslationLocale: {
+		models.LocaleIndonesian: "Locale %q bukan bahasa terjemahan",
+		models.LocaleEnglish:    "Locale %q is not a translation locale",
+	},
+	MsgEntityNotTranslatable: {
+		models.LocaleIndonesian: "Data %q tidak memiliki kolom yang dapat diterjemahkan",
+		models.LocaleEnglish:    "Entity %q has no translatable fields",
+	},
+	MsgFieldNotTranslatable: {
+		models.LocaleIndonesian: "%s tidak dapat diterjemahkan",
+		models.LocaleEnglish:    "%s is not translatable",
+	},
+
+	// Trash
+	MsgTrashNotFound: {
+		models.LocaleIndonesian: "Data tidak ditemukan di trash",
+		models.LocaleEnglish:    "Data not found in trash",
+	},
+	MsgTrashFetchFailed: {
+		models.LocaleIndonesian: "Gagal mengambil trash",
+		models.LocaleEnglish:    "Failed to fetch trash",
+	},
+	MsgTrashInUse: {
+		models.LocaleIndonesian: "Data masih direferensikan data lain",
+		models.LocaleEnglish:    "Data is still referenced by other records",
+	},
+	MsgRestoreFailed: {
+		models.LocaleIndonesian: "Gagal memulihkan data",
+		models.LocaleEnglish:    "Failed to restore data",
+	},
+	MsgPurgeFailed: {
+		models.LocaleIndonesian: "Gagal menghapus data permanen",
+		models.LocaleEnglish:    "Failed to purge data",
+	},
+	MsgDataRestored: {
+		models.LocaleIndonesian: "Data berhasil dipulihkan",
+		models.LocaleEnglish:    "Data restored successfully",
+	},
+	MsgDataPurged: {
+		models.LocaleIndonesian: "Data berhasil dihapus permanen",
+		models.LocaleEnglish:    "Data permanently deleted",
+	},
+
+	// Validasi kolom
+	MsgFieldRequired: {
+		models.LocaleIndonesian: "%s wajib diisi",
+		models.LocaleEnglish:    "%s is required",
+	},
+}
+
+// MessageIn merender kode pesan dalam bahasa tertentu, jatuh ke bahasa bawaan lalu ke kode itu sendiri
+func MessageIn(locale string, code string, args ...interface{}) string {
+	texts, ok := messageCatalog[code]
+	if !ok {
+		return code
+	}
+	text, ok := texts[locale]
+	if !ok {
+		text = texts[models.DefaultLocale]
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Message merender kode pesan dalam bahasa permintaan
+func Message(c *fiber.Ctx, code string, args ...interface{}) string {
+	return MessageIn(Locale(c), code, args...)
+}
+
+// AppError adalah kesalahan yang membawa kode pesan sehingga dapat ditampilkan dalam bahasa permintaan
+type AppError struct {
+	Code string
+	Args []interface{}
+}
+
+// NewError membuat AppError; nilai yang sama dapat dipakai sebagai sentinel dengan errors.Is
+func NewError(code string, args ...interface{}) *AppError {
+	return &AppError{Code: code, Args: args}
+}
+
+// Error mengembalikan pesan dalam bahasa Inggris untuk log
+func (e *AppError) Error() string {
+	return MessageIn(models.LocaleEnglish, e.Code, e.Args...)
+}
+
+// ErrorMessage menerjemahkan AppError ke bahasa permintaan, error lain ditampilkan apa adanya
+func ErrorMessage(c *fiber.Ctx, err error) string {
+	return ErrorMessageIn(Locale(c), err)
+}
+
+// ErrorMessageIn menerjemahkan AppError ke bahasa tertentu, error lain ditampilkan apa adanya
+func ErrorMessageIn(locale string, err error) string {
+	var appError *AppError
+	if errors.As(err, &appError) {
+		return MessageIn(locale, appError.Code, appError.Args...)
+	}
+	return err.Error()
+}
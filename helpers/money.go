@@ -0,0 +1,61 @@
+package helpers
+
+import (
+	"Matahariled/models"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RoundMoney membulatkan nilai uang ke dua desimal, setengah sen dibulatkan menjauhi nol.
+// Pergeseran dua desimal dilakukan pada teks desimalnya agar 1.005 menjadi 1.01, bukan 1 karena galat biner value*100
+func RoundMoney(value float64) float64 {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return value
+	}
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(value, 'e', -1, 64), "e")
+	shift, _ := strconv.Atoi(exponent)
+	scaled, _ := strconv.ParseFloat(mantissa+"e"+strconv.Itoa(shift+2), 64)
+	return math.Round(scaled) / 100
+}
+
+// FormatMoney menulis nilai uang sesuai kebiasaan bahasa, misalnya "Rp 1.250.000,00" atau "IDR 1,250,000.00"
+func FormatMoney(value float64, currency string, locale string) string {
+	if currency == "IDR" && locale == models.LocaleIndonesian {
+		currency = "Rp"
+	}
+	return currency + " " + FormatAmount(value, locale)
+}
+
+// FormatAmount menulis nilai uang tanpa mata uang, dipakai di kolom tabel yang sempit
+func FormatAmount(value float64, locale string) string {
+	thousands, decimal := ",", "."
+	if locale == models.LocaleIndonesian {
+		thousands, decimal = ".", ","
+	}
+
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	text := strconv.FormatFloat(RoundMoney(value), 'f', 2, 64)
+	whole, fraction := text[:len(text)-3], text[len(text)-2:]
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(thousands)
+		}
+		grouped.WriteRune(digit)
+	}
+	return sign + grouped.String() + decimal + fraction
+}
+
+// FormatPercent menulis persentase tanpa nol di belakang koma, misalnya "11" atau "12,5"
+func FormatPercent(value float64, locale string) string {
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	if locale == models.LocaleIndonesian {
+		text = strings.Replace(text, ".", ",", 1)
+	}
+	return text
+}
@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"Matahariled/models"
+	"testing"
+)
+
+func TestRoundMoney(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  float64
+	}{
+		{1.005, 1.01},
+		{1.015, 1.02},
+		{2.675, 2.68},
+		{-3.335, -3.34},
+		{1250000.499, 1250000.5},
+	}
+	for _, tt := range tests {
+		if got := RoundMoney(tt.value); got != tt.want {
+			t.Errorf("RoundMoney(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		value    float64
+		currency string
+		locale   string
+		want     string
+	}{
+		{1250000, "IDR", models.LocaleIndonesian, "Rp 1.250.000,00"},
+		{1250000, "IDR", models.LocaleEnglish, "IDR 1,250,000.00"},
+		{999.999, "USD", models.LocaleEnglish, "USD 1,000.00"},
+		{-1234.5, "IDR", models.LocaleIndonesian, "Rp -1.234,50"},
+		{0, "IDR", models.LocaleEnglish, "IDR 0.00"},
+		{100, "IDR", models.LocaleEnglish, "IDR 100.00"},
+	}
+	for _, tt := range tests {
+		if got := FormatMoney(tt.value, tt.currency, tt.locale); got != tt.want {
+			t.Errorf("FormatMoney(%v, %s, %s) = %q, want %q", tt.value, tt.currency, tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		value  float64
+		locale string
+		want   string
+	}{
+		{11, models.LocaleIndonesian, "11"},
+		{12.5, models.LocaleIndonesian, "12,5"},
+		{12.5, models.LocaleEnglish, "12.5"},
+	}
+	for _, tt := range tests {
+		if got := FormatPercent(tt.value, tt.locale); got != tt.want {
+			t.Errorf("FormatPercent(%v, %s) = %q, want %q", tt.value, tt.locale, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package helpers
+
+import (
+	"Matahariled/models"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// quotationColumns adalah lebar kolom tabel penawaran dalam mm, totalnya sama dengan lebar isi halaman A4
+var quotationColumns = [6]float64{8, 70, 14, 30, 22, 36}
+
+// quotationTableHeader mencetak judul kolom tabel penawaran, diulang di setiap halaman baru
+func (d *brochureDocument) quotationTableHeader(currency string) {
+	pdf := d.pdf
+	labels := [6]string{
+		"#",
+		MessageIn(d.locale, MsgQuotationDocProduct),
+		MessageIn(d.locale, MsgQuotationDocQuantity),
+		MessageIn(d.locale, MsgQuotationDocUnitPrice) + " (" + currency + ")",
+		MessageIn(d.locale, MsgQuotationDocDiscount),
+		MessageIn(d.locale, MsgQuotationDocAmount) + " (" + currency + ")",
+	}
+	aligns := [6]string{"C", "L", "R", "R", "R", "R"}
+	pdf.SetFillColor(d.color[0], d.color[1], d.color[2])
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 9)
+	for i, label := range labels {
+		pdf.CellFormat(quotationColumns[i], 8, d.tr(label), "", 0, aligns[i], true, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// quotationInfo mencetak pasangan label dan nilai di blok identitas penawaran
+func (d *brochureDocument) quotationInfo(x float64, label string, value string) {
+	pdf := d.pdf
+	pdf.SetX(x)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(28, 5, d.tr(label), "", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(0, 5, d.tr(value), "", 1, "L", false, 0, "")
+}
+
+// quotationTotal mencetak satu baris ringkasan nilai di sisi kanan
+func (d *brochureDocument) quotationTotal(label string, value string, bold bool) {
+	pdf := d.pdf
+	pageWidth, _ := pdf.GetPageSize()
+	style := ""
+	if bold {
+		style = "B"
+	}
+	pdf.SetX(pageWidth - brochureMargin - 100)
+	pdf.SetFont("Helvetica", style, 10)
+	pdf.CellFormat(55, 7, d.tr(label), "", 0, "R", false, 0, "")
+	pdf.CellFormat(45, 7, d.tr(value), "", 1, "R", false, 0, "")
+}
+
+// RenderQuotation membuat PDF penawaran harga berisi identitas pelanggan, tabel produk, PPN dan total
+func RenderQuotation(w io.Writer, branding BrochureBranding, locale string, quotation models.Quotation) error {
+	d := newBrochureDocument(branding, locale, quotation.Number)
+	pdf := d.pdf
+	pageWidth, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	pdf.AddPage()
+
+	// Judul dokumen di kiri, nomor dan tanggal di kanan
+	top := pdf.GetY()
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.SetTextColor(d.color[0], d.color[1], d.color[2])
+	pdf.CellFormat(90, 9, d.tr(MessageIn(locale, MsgQuotationDocTitle)), "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(3)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(90, 5, d.tr(MessageIn(locale, MsgQuotationDocTo)), "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.MultiCell(90, 6, d.tr(quotation.Customer), "", "L", false)
+	left := pdf.GetY()
+
+	infoX := pageWidth - brochureMargin - 75
+	pdf.SetY(top)
+	d.quotationInfo(infoX, MessageIn(locale, MsgQuotationDocNumber), quotation.Number)
+	d.quotationInfo(infoX, MessageIn(locale, MsgQuotationDocVersion), strconv.Itoa(quotation.Version))
+	d.quotationInfo(infoX, MessageIn(locale, MsgQuotationDocDate), quotation.IssuedAt.Format("2006-01-02"))
+	if quotation.ValidUntil != nil {
+		d.quotationInfo(infoX, MessageIn(locale, MsgQuotationDocValidUntil), quotation.ValidUntil.Format("2006-01-02"))
+	}
+	if pdf.GetY() < left {
+		pdf.SetY(left)
+	}
+
+	if quotation.Title != "" {
+		pdf.Ln(4)
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.MultiCell(0, 6, d.tr(quotation.Title), "", "L", false)
+	}
+	pdf.Ln(4)
+
+	d.quotationTableHeader(quotation.Currency)
+	pdf.SetFillColor(245, 245, 245)
+	for i, line := range quotation.Lines {
+		pdf.SetFont("Helvetica", "", 9)
+		// Nama produk panjang dibungkus, tinggi baris mengikuti jumlah barisnya
+		wrapped := pdf.SplitText(d.tr(line.Description), quotationColumns[1]-2)
+		height := float64(len(wrapped))*5 + 2
+		if height < 7 {
+			height = 7
+		}
+		if pdf.GetY()+height > pageHeight-bottom {
+			pdf.AddPage()
+			d.quotationTableHeader(quotation.Currency)
+			pdf.SetFillColor(245, 245, 245)
+			pdf.SetFont("Helvetica", "", 9)
+		}
+
+		discount := "-"
+		if line.DiscountPercent > 0 {
+			discount = FormatPercent(line.DiscountPercent, locale) + "%"
+		}
+		fill := i%2 == 1
+		x, y := pdf.GetX(), pdf.GetY()
+		pdf.CellFormat(quotationColumns[0], height, strconv.Itoa(i+1), "", 0, "C", fill, 0, "")
+		pdf.CellFormat(quotationColumns[1], height, "", "", 0, "L", fill, 0, "")
+		pdf.CellFormat(quotationColumns[2], height, strconv.Itoa(line.Quantity), "", 0, "R", fill, 0, "")
+		pdf.CellFormat(quotationColumns[3], height, FormatAmount(line.UnitPrice, locale), "", 0, "R", fill, 0, "")
+		pdf.CellFormat(quotationColumns[4], height, discount, "", 0, "R", fill, 0, "")
+		pdf.CellFormat(quotationColumns[5], height, FormatAmount(line.Total, locale), "", 0, "R", fill, 0, "")
+		for j, text := range wrapped {
+			pdf.SetXY(x+quotationColumns[0]+1, y+1+float64(j)*5)
+			pdf.CellFormat(quotationColumns[1]-2, 5, text, "", 0, "L", false, 0, "")
+		}
+		pdf.SetXY(x, y+height)
+	}
+
+	// Blok total tidak dipisah dari halamannya
+	if pdf.GetY()+45 > pageHeight-bottom {
+		pdf.AddPage()
+	}
+	pdf.SetDrawColor(220, 220, 220)
+	pdf.SetLineWidth(0.2)
+	pdf.Line(brochureMargin, pdf.GetY(), pageWidth-brochureMargin, pdf.GetY())
+	pdf.Ln(2)
+	currency := quotation.Currency
+	d.quotationTotal(MessageIn(locale, MsgQuotationDocSubtotal), FormatMoney(quotation.Subtotal, currency, locale), false)
+	if quotation.Discount > 0 {
+		d.quotationTotal(MessageIn(locale, MsgQuotationDocDiscount), FormatMoney(-quotation.Discount, currency, locale), false)
+	}
+	d.quotationTotal(MessageIn(locale, MsgQuotationDocTaxBase), FormatMoney(quotation.TaxBase, currency, locale), false)
+	d.quotationTotal(MessageIn(locale, MsgQuotationDocTax, FormatPercent(quotation.TaxRate, locale)), FormatMoney(quotation.Tax, currency, locale), false)
+	pdf.SetDrawColor(d.color[0], d.color[1], d.color[2])
+	pdf.Line(pageWidth-brochureMargin-100, pdf.GetY(), pageWidth-brochureMargin, pdf.GetY())
+	d.quotationTotal(MessageIn(locale, MsgQuotationDocTotal), FormatMoney(quotation.Total, currency, locale), true)
+
+	if strings.TrimSpace(quotation.Notes) != "" {
+		d.section(MessageIn(locale, MsgQuotationDocNotes))
+		d.paragraph(quotation.Notes)
+	}
+	return d.output(w)
+}
@@ -1,6 +1,12 @@
 package helpers
 
-import "Matahariled/models"
+import (
+	"Matahariled/models"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
 
 type ResponseGetAll struct {
 	Code   int         `json:"code"`
@@ -14,14 +20,17 @@ type ResponseGetSingle struct {
 }
 
 type ResponseMassage struct {
-	Code    int    `json:"code"`
-	Status  string `json:"status"`
-	Message string `json:"message"`
+	Code        int    `json:"code"`
+	Status      string `json:"status"`
+	MessageCode string `json:"message_code"`
+	Message     string `json:"message"`
 }
 type ResponseError struct {
-	Code   int                 `json:"code"`
-	Status string              `json:"status"`
-	Error  map[string][]string `json:"errors"`
+	Code        int                 `json:"code"`
+	Status      string              `json:"status"`
+	MessageCode string              `json:"message_code"`
+	Message     string              `json:"message"`
+	Error       map[string][]string `json:"errors"`
 }
 
 type GeneralResponse struct {
@@ -42,3 +51,37 @@ type DataTableResponse struct {
 	Total        int           `json:"total"`
 	Data         []interface{} `json:"data"` // Gunakan interface{} sebagai tipe data untuk data yang bervariasi
 }
+
+// NewResponseMassage menyusun respons pesan dengan kode pesan dan teks dalam bahasa permintaan
+func NewResponseMassage(c *fiber.Ctx, code int, messageCode string, args ...interface{}) ResponseMassage {
+	return ResponseMassage{
+		Code:        code,
+		Status:      utils.StatusMessage(code),
+		MessageCode: messageCode,
+		Message:     Message(c, messageCode, args...),
+	}
+}
+
+// NewErrorMassage menyusun respons pesan dari error; AppError memakai kodenya sendiri,
+// error lain ditampilkan sebagai input tidak valid atau kesalahan server sesuai kode HTTP
+func NewErrorMassage(c *fiber.Ctx, code int, err error) ResponseMassage {
+	var appError *AppError
+	if errors.As(err, &appError) {
+		return NewResponseMassage(c, code, appError.Code, appError.Args...)
+	}
+	if code >= fiber.StatusInternalServerError {
+		return NewResponseMassage(c, code, MsgInternalError)
+	}
+	return NewResponseMassage(c, code, MsgInvalidInput, err.Error())
+}
+
+// NewResponseError menyusun respons validasi 400 dengan pesan per kolom
+func NewResponseError(c *fiber.Ctx, fieldErrors map[string][]string) ResponseError {
+	return ResponseError{
+		Code:        fiber.StatusBadRequest,
+		Status:      utils.StatusMessage(fiber.StatusBadRequest),
+		MessageCode: MsgValidationFailed,
+		Message:     Message(c, MsgValidationFailed),
+		Error:       fieldErrors,
+	}
+}
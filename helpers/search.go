@@ -0,0 +1,424 @@
+package helpers
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchField adalah satu bagian teks dokumen beserta bobotnya
+type SearchField struct {
+	Name  string
+	Text  string
+	Boost float64
+}
+
+// SearchDocument adalah data yang diindeks; Meta ikut dikembalikan apa adanya pada hasil pencarian
+type SearchDocument struct {
+	Type   string
+	Id     int64
+	Title  string
+	Fields []SearchField
+	Meta   map[string]interface{}
+}
+
+type SearchQuery struct {
+	Text   string
+	Types  []string
+	Limit  int
+	Offset int
+}
+
+type SearchHit struct {
+	Type       string                 `json:"type"`
+	Id         int64                  `json:"id"`
+	Title      string                 `json:"title"`
+	Score      float64                `json:"score"`
+	Highlights map[string]string      `json:"highlights"`
+	Meta       map[string]interface{} `json:"meta,omitempty"`
+}
+
+type SearchResult struct {
+	Total int         `json:"total"`
+	Hits  []SearchHit `json:"hits"`
+}
+
+type searchEntry struct {
+	doc       SearchDocument
+	fieldLens map[string]int
+	terms     map[string]bool
+}
+
+// SearchIndex adalah indeks terbalik di memori dengan skor BM25, stemming bahasa Indonesia dan toleransi salah ketik
+type SearchIndex struct {
+	mu          sync.RWMutex
+	entries     map[string]*searchEntry
+	postings    map[string]map[string]map[string]int // term -> dokumen -> field -> frekuensi
+	fieldTotals map[string]int
+	fieldCounts map[string]int
+}
+
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		entries:     make(map[string]*searchEntry),
+		postings:    make(map[string]map[string]map[string]int),
+		fieldTotals: make(map[string]int),
+		fieldCounts: make(map[string]int),
+	}
+}
+
+func searchKey(docType string, id int64) string {
+	return docType + ":" + strconv.FormatInt(id, 10)
+}
+
+type searchToken struct {
+	term  string
+	start int
+	end   int
+}
+
+// tokenize memecah teks menjadi term ter-stem beserta posisi byte aslinya untuk highlight
+func tokenize(text string) []searchToken {
+	var tokens []searchToken
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		from := start
+		start = -1
+		word := strings.ToLower(text[from:end])
+		if searchStopwords[word] {
+			return
+		}
+		tokens = append(tokens, searchToken{term: StemIndonesian(word), start: from, end: end})
+	}
+
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		// Angka desimal seperti 3.9 atau 2,5 tetap satu token
+		if !isWord && (r == '.' || r == ',') && start >= 0 && i > 0 && unicode.IsDigit(rune(text[i-1])) {
+			if next, _ := utf8.DecodeRuneInString(text[i+1:]); unicode.IsDigit(next) {
+				continue
+			}
+		}
+		if isWord {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+	return tokens
+}
+
+// Put menambahkan atau mengganti dokumen pada indeks
+func (idx *SearchIndex) Put(doc SearchDocument) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	key := searchKey(doc.Type, doc.Id)
+	idx.removeLocked(key)
+
+	entry := &searchEntry{doc: doc, fieldLens: make(map[string]int), terms: make(map[string]bool)}
+	for _, field := range doc.Fields {
+		tokens := tokenize(field.Text)
+		entry.fieldLens[field.Name] += len(tokens)
+		for _, token := range tokens {
+			docs := idx.postings[token.term]
+			if docs == nil {
+				docs = make(map[string]map[string]int)
+				idx.postings[token.term] = docs
+			}
+			if docs[key] == nil {
+				docs[key] = make(map[string]int)
+			}
+			docs[key][field.Name]++
+			entry.terms[token.term] = true
+		}
+	}
+	for name, length := range entry.fieldLens {
+		idx.fieldTotals[name] += length
+		idx.fieldCounts[name]++
+	}
+	idx.entries[key] = entry
+}
+
+// Remove menghapus dokumen dari indeks
+func (idx *SearchIndex) Remove(docType string, id int64) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.removeLocked(searchKey(docType, id))
+}
+
+// RemoveType menghapus semua dokumen dengan tipe tertentu, dipakai sebelum membangun ulang
+func (idx *SearchIndex) RemoveType(docType string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for key, entry := range idx.entries {
+		if entry.doc.Type == docType {
+			idx.removeLocked(key)
+		}
+	}
+}
+
+func (idx *SearchIndex) removeLocked(key string) {
+	entry, ok := idx.entries[key]
+	if !ok {
+		return
+	}
+	for term := range entry.terms {
+		delete(idx.postings[term], key)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	for name, length := range entry.fieldLens {
+		idx.fieldTotals[name] -= length
+		idx.fieldCounts[name]--
+	}
+	delete(idx.entries, key)
+}
+
+// Len mengembalikan jumlah dokumen di indeks
+func (idx *SearchIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.entries)
+}
+
+// editDistance menghitung jarak Levenshtein dan berhenti lebih awal jika melebihi max
+func editDistance(a string, b string, max int) int {
+	if a == b {
+		return 0
+	}
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > max || -diff > max {
+		return max + 1
+	}
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// expandTerm mencari term di indeks yang cocok persis, berawalan sama (kata terakhir) atau mirip (salah ketik)
+func (idx *SearchIndex) expandTerm(term string, prefix bool) map[string]float64 {
+	matches := make(map[string]float64)
+	if _, ok := idx.postings[term]; ok {
+		matches[term] = 1
+	}
+
+	maxDistance := 0
+	switch n := utf8.RuneCountInString(term); {
+	case n >= 8:
+		maxDistance = 2
+	case n >= 4:
+		maxDistance = 1
+	}
+
+	for candidate := range idx.postings {
+		if candidate == term {
+			continue
+		}
+		if prefix && len(term) >= 2 && strings.HasPrefix(candidate, term) {
+			matches[candidate] = math.Max(matches[candidate], 0.8)
+			continue
+		}
+		if maxDistance > 0 {
+			if d := editDistance(term, candidate, maxDistance); d <= maxDistance {
+				matches[candidate] = math.Max(matches[candidate], math.Pow(0.5, float64(d)))
+			}
+		}
+	}
+	return matches
+}
+
+// Search mencari dokumen dengan skor BM25 per field, dokumen yang memuat lebih banyak kata kueri diberi nilai lebih tinggi
+func (idx *SearchIndex) Search(query SearchQuery) SearchResult {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	queryTokens := tokenize(query.Text)
+	if len(queryTokens) == 0 {
+		return SearchResult{Hits: []SearchHit{}}
+	}
+
+	allowedTypes := make(map[string]bool)
+	for _, t := range query.Types {
+		allowedTypes[t] = true
+	}
+
+	const k1, b = 1.2, 0.75
+	totalDocs := float64(len(idx.entries))
+	scores := make(map[string]float64)
+	matchedQueryTerms := make(map[string]int)
+	matchedTerms := make(map[string]map[string]bool)
+
+	seen := make(map[string]bool)
+	for i, token := range queryTokens {
+		if seen[token.term] {
+			continue
+		}
+		seen[token.term] = true
+		// Kata terakhir diperlakukan sebagai awalan untuk pencarian saat mengetik
+		expansions := idx.expandTerm(token.term, i == len(queryTokens)-1)
+
+		termMatched := make(map[string]bool)
+		for term, weight := range expansions {
+			docs := idx.postings[term]
+			idf := math.Log(1 + (totalDocs-float64(len(docs))+0.5)/(float64(len(docs))+0.5))
+			for key, fields := range docs {
+				entry := idx.entries[key]
+				if len(allowedTypes) > 0 && !allowedTypes[entry.doc.Type] {
+					continue
+				}
+				var score float64
+				for _, field := range entry.doc.Fields {
+					tf := float64(fields[field.Name])
+					if tf == 0 {
+						continue
+					}
+					avg := 1.0
+					if idx.fieldCounts[field.Name] > 0 {
+						avg = float64(idx.fieldTotals[field.Name]) / float64(idx.fieldCounts[field.Name])
+					}
+					norm := 1 - b + b*float64(entry.fieldLens[field.Name])/math.Max(avg, 1)
+					score += field.Boost * tf * (k1 + 1) / (tf + k1*norm)
+				}
+				scores[key] += idf * score * weight
+				termMatched[key] = true
+				if matchedTerms[key] == nil {
+					matchedTerms[key] = make(map[string]bool)
+				}
+				matchedTerms[key][term] = true
+			}
+		}
+		for key := range termMatched {
+			matchedQueryTerms[key]++
+		}
+	}
+
+	hits := make([]SearchHit, 0, len(scores))
+	for key, score := range scores {
+		entry := idx.entries[key]
+		coverage := float64(matchedQueryTerms[key]) / float64(len(seen))
+		hits = append(hits, SearchHit{
+			Type:  entry.doc.Type,
+			Id:    entry.doc.Id,
+			Title: entry.doc.Title,
+			Score: math.Round(score*coverage*coverage*1000) / 1000,
+			Meta:  entry.doc.Meta,
+		})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Type != hits[j].Type {
+			return hits[i].Type < hits[j].Type
+		}
+		return hits[i].Id < hits[j].Id
+	})
+
+	result := SearchResult{Total: len(hits)}
+	if query.Offset >= len(hits) {
+		result.Hits = []SearchHit{}
+		return result
+	}
+	hits = hits[query.Offset:]
+	if query.Limit > 0 && len(hits) > query.Limit {
+		hits = hits[:query.Limit]
+	}
+
+	// Highlight hanya dibuat untuk hasil yang dikembalikan
+	for i := range hits {
+		key := searchKey(hits[i].Type, hits[i].Id)
+		entry := idx.entries[key]
+		hits[i].Highlights = make(map[string]string)
+		for _, field := range entry.doc.Fields {
+			if snippet, ok := highlight(field.Text, matchedTerms[key]); ok {
+				hits[i].Highlights[field.Name] = snippet
+			}
+		}
+	}
+	result.Hits = hits
+	return result
+}
+
+const highlightContext = 80
+
+// highlight membungkus kata yang cocok dengan <mark> dan memotong teks di sekitar kecocokan pertama
+func highlight(text string, terms map[string]bool) (string, bool) {
+	tokens := tokenize(text)
+	var marked []searchToken
+	for _, token := range tokens {
+		if terms[token.term] {
+			marked = append(marked, token)
+		}
+	}
+	if len(marked) == 0 {
+		return "", false
+	}
+
+	from, to := 0, len(text)
+	if marked[0].start > highlightContext {
+		from = marked[0].start - highlightContext
+	}
+	if marked[0].end+highlightContext*2 < len(text) {
+		to = marked[0].end + highlightContext*2
+	}
+	// Jangan memotong di tengah karakter UTF-8
+	for from > 0 && !utf8.RuneStart(text[from]) {
+		from--
+	}
+	for to < len(text) && !utf8.RuneStart(text[to]) {
+		to++
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, token := range marked {
+		if token.start < from || token.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:token.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[token.start:token.end]))
+		b.WriteString("</mark>")
+		pos = token.end
+	}
+	b.WriteString(html.EscapeString(text[pos:to]))
+	if to < len(text) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
@@ -0,0 +1,135 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func searchFixture() *SearchIndex {
+	idx := NewSearchIndex()
+	docs := []SearchDocument{
+		{Type: "product", Id: 1, Title: "Videotron Outdoor P10", Fields: []SearchField{
+			{Name: "title", Text: "Videotron Outdoor P10", Boost: 3},
+			{Name: "description", Text: "Layar LED untuk papan iklan jalan raya", Boost: 1},
+		}},
+		{Type: "product", Id: 2, Title: "Running Text Indoor", Fields: []SearchField{
+			{Name: "title", Text: "Running Text Indoor", Boost: 3},
+			{Name: "description", Text: "Cocok dipasang di samping videotron indoor dan outdoor", Boost: 1},
+		}},
+		{Type: "product", Id: 3, Title: "Videotron Indoor P3.9", Fields: []SearchField{
+			{Name: "title", Text: "Videotron Indoor P3.9", Boost: 3},
+			{Name: "description", Text: "Layar <LED> resolusi tinggi & ringan", Boost: 1},
+		}},
+		{Type: "history", Id: 1, Title: "Pemasangan videotron alun-alun", Fields: []SearchField{
+			{Name: "title", Text: "Pemasangan videotron alun-alun", Boost: 3},
+		}},
+	}
+	for _, doc := range docs {
+		idx.Put(doc)
+	}
+	return idx
+}
+
+func hitKeys(result SearchResult) []string {
+	keys := make([]string, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		keys = append(keys, searchKey(hit.Type, hit.Id))
+	}
+	return keys
+}
+
+func TestSearchIndexRanking(t *testing.T) {
+	idx := searchFixture()
+	tests := []struct {
+		name  string
+		query SearchQuery
+		want  []string
+		total int
+	}{
+		{"title match ranks above description", SearchQuery{Text: "outdoor"}, []string{"product:1", "product:2"}, 2},
+		{"documents matching every term rank first", SearchQuery{Text: "videotron p10"}, []string{"product:1"}, 4},
+		{"typo tolerance", SearchQuery{Text: "vidiotron outdor"}, []string{"product:1", "product:2"}, 4},
+		{"last word is a prefix", SearchQuery{Text: "runn"}, []string{"product:2"}, 1},
+		{"decimal numbers stay one token", SearchQuery{Text: "p3.9"}, []string{"product:3"}, 1},
+		{"type filter", SearchQuery{Text: "videotron", Types: []string{"history"}}, []string{"history:1"}, 1},
+		{"limit", SearchQuery{Text: "videotron p10", Limit: 1}, []string{"product:1"}, 4},
+		{"offset past the end", SearchQuery{Text: "videotron", Offset: 10}, []string{}, 4},
+		{"stopwords only", SearchQuery{Text: "dan di"}, []string{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := idx.Search(tt.query)
+			got := hitKeys(result)
+			if len(got) > len(tt.want) {
+				got = got[:len(tt.want)]
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hits = %v, want %v", hitKeys(result), tt.want)
+			}
+			if tt.query.Limit > 0 && len(result.Hits) > tt.query.Limit {
+				t.Errorf("hits = %d, want at most %d", len(result.Hits), tt.query.Limit)
+			}
+			if result.Total != tt.total {
+				t.Errorf("total = %d, want %d", result.Total, tt.total)
+			}
+		})
+	}
+}
+
+func TestSearchIndexPagination(t *testing.T) {
+	idx := searchFixture()
+	all := hitKeys(idx.Search(SearchQuery{Text: "videotron"}))
+	if len(all) != 4 {
+		t.Fatalf("hits = %v", all)
+	}
+	if got := hitKeys(idx.Search(SearchQuery{Text: "videotron", Limit: 2, Offset: 1})); !reflect.DeepEqual(got, all[1:3]) {
+		t.Errorf("page = %v, want %v", got, all[1:3])
+	}
+}
+
+func TestSearchIndexUpdateAndRemove(t *testing.T) {
+	idx := searchFixture()
+	idx.Put(SearchDocument{Type: "product", Id: 1, Title: "Baliho", Fields: []SearchField{{Name: "title", Text: "Baliho", Boost: 3}}})
+	if got := hitKeys(idx.Search(SearchQuery{Text: "outdoor"})); !reflect.DeepEqual(got, []string{"product:2"}) {
+		t.Errorf("after update hits = %v", got)
+	}
+	idx.Remove("product", 2)
+	idx.RemoveType("history")
+	if idx.Len() != 2 {
+		t.Errorf("len = %d, want 2", idx.Len())
+	}
+	if result := idx.Search(SearchQuery{Text: "outdoor"}); result.Total != 0 {
+		t.Errorf("removed document still found: %v", hitKeys(result))
+	}
+}
+
+func TestSearchHighlight(t *testing.T) {
+	result := searchFixture().Search(SearchQuery{Text: "resolusi led"})
+	if len(result.Hits) == 0 || result.Hits[0].Id != 3 {
+		t.Fatalf("hits = %v", hitKeys(result))
+	}
+	want := "Layar &lt;<mark>LED</mark>&gt; <mark>resolusi</mark> tinggi &amp; ringan"
+	if got := result.Hits[0].Highlights["description"]; got != want {
+		t.Errorf("highlight = %q, want %q", got, want)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		max  int
+		want int
+	}{
+		{"videotron", "videotron", 2, 0},
+		{"vidiotron", "videotron", 2, 1},
+		{"outdor", "outdoor", 1, 1},
+		{"indoor", "outdoor", 1, 2},
+		{"led", "ledakan", 2, 3},
+		{"layar", "láyar", 1, 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b, tt.max); got != tt.want {
+			t.Errorf("editDistance(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.max, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+const slugMaxLength = 100
+
+// Huruf beraksen yang umum dipakai di nama produk dan proyek
+var slugTransliterations = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i",
+	"ò", "o", "ó", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c", "ß", "ss", "&", " dan ", "²", "2", "³", "3",
+)
+
+// Slugify mengubah teks menjadi slug huruf kecil yang dipisahkan tanda hubung
+func Slugify(text string) string {
+	text = slugTransliterations.Replace(strings.ToLower(text))
+
+	var b strings.Builder
+	dash := false
+	for _, r := range text {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	slug := strings.Trim(b.String(), "-")
+	if len(slug) > slugMaxLength {
+		slug = strings.TrimRight(slug[:slugMaxLength], "-")
+	}
+	return slug
+}
+
+// UniqueSlug membuat slug dari teks dan menambahkan akhiran -2, -3, ... jika sudah dipakai baris lain pada tabel
+func UniqueSlug(db *gorm.DB, table string, idColumn string, text string, fallback string, excludeId int64) (string, error) {
+	base := Slugify(text)
+	if base == "" {
+		base = fallback
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		var count int64
+		if err := db.Table(table).Where("slug = ? AND "+idColumn+" <> ?", slug, excludeId).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
@@ -0,0 +1,488 @@
+package helpers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadSpreadsheet membaca file CSV atau lembar pertama file XLSX menjadi baris sel teks
+func ReadSpreadsheet(filename string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return readCSV(data)
+	case ".xlsx":
+		return readXLSX(data)
+	}
+	return nil, NewError(MsgImportUnsupportedFormat, filepath.Ext(filename))
+}
+
+// readCSV membaca CSV berpemisah koma atau titik koma (format ekspor Excel berbahasa Indonesia)
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	firstLine := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		firstLine = data[:i]
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return trimSpreadsheetRows(rows), nil
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		Id   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxText adalah teks sel yang bisa berupa <t> tunggal atau beberapa potongan rich text <r><t>
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var text strings.Builder
+	for _, run := range t.Runs {
+		text.WriteString(run.T)
+	}
+	return text.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string    `xml:"r,attr"`
+			Type   string    `xml:"t,attr"`
+			Value  string    `xml:"v"`
+			Inline *xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX membaca lembar pertama workbook XLSX tanpa dependensi tambahan
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var shared xlsxSharedStrings
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeZipXML(file, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetPath := "xl/worksheets/sheet1.xml"
+	var workbook xlsxWorkbook
+	var relationships xlsxRelationships
+	if file, ok := files["xl/workbook.xml"]; ok && decodeZipXML(file, &workbook) == nil && len(workbook.Sheets) > 0 {
+		if file, ok := files["xl/_rels/workbook.xml.rels"]; ok && decodeZipXML(file, &relationships) == nil {
+			for _, relationship := range relationships.Relationships {
+				if relationship.Id == workbook.Sheets[0].Id {
+					// Target relatif terhadap folder xl, kecuali diawali "/"
+					if strings.HasPrefix(relationship.Target, "/") {
+						sheetPath = strings.TrimPrefix(relationship.Target, "/")
+					} else {
+						sheetPath = path.Join("xl", relationship.Target)
+					}
+				}
+			}
+		}
+	}
+	file, ok := files[sheetPath]
+	if !ok {
+		return nil, NewError(MsgImportUnsupportedFormat, ".xlsx")
+	}
+	var sheet xlsxSheet
+	if err := decodeZipXML(file, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		// Baris kosong tidak ditulis di XLSX, isi ulang agar nomor baris sama dengan di Excel
+		for row.Index > 0 && len(rows) < row.Index-1 {
+			rows = append(rows, nil)
+		}
+		var cells []string
+		for i, cell := range row.Cells {
+			column := i
+			if cell.Ref != "" {
+				column = xlsxColumnIndex(cell.Ref)
+			}
+			for len(cells) <= column {
+				cells = append(cells, "")
+			}
+			switch cell.Type {
+			case "s":
+				if index, err := strconv.Atoi(cell.Value); err == nil && index < len(shared.Items) {
+					cells[column] = shared.Items[index].String()
+				}
+			case "inlineStr":
+				if cell.Inline != nil {
+					cells[column] = cell.Inline.String()
+				}
+			case "b":
+				cells[column] = map[string]string{"1": "true", "0": "false"}[cell.Value]
+			default:
+				cells[column] = cell.Value
+			}
+		}
+		rows = append(rows, cells)
+	}
+	return trimSpreadsheetRows(rows), nil
+}
+
+func decodeZipXML(file *zip.File, target interface{}) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return xml.NewDecoder(io.LimitReader(reader, 100<<20)).Decode(target)
+}
+
+// xlsxColumnIndex mengubah referensi sel seperti "AB12" menjadi indeks kolom berbasis nol
+func xlsxColumnIndex(ref string) int {
+	column := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A'+1)
+	}
+	return column - 1
+}
+
+// trimSpreadsheetRows merapikan spasi sel dan membuang baris kosong di akhir file
+func trimSpreadsheetRows(rows [][]string) [][]string {
+	for _, row := range rows {
+		for i := range row {
+			row[i] = strings.TrimSpace(row[i])
+		}
+	}
+	for len(rows) > 0 && strings.Join(rows[len(rows)-1], "") == "" {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+// TableWriter menulis tabel ekspor baris demi baris sehingga data besar tidak perlu dimuat sekaligus
+type TableWriter interface {
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+// exportContentTypes adalah format ekspor yang didukung beserta content type-nya
+var exportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"json": "application/json; charset=utf-8",
+}
+
+// ExportContentType mengembalikan content type format ekspor, false jika format tidak didukung
+func ExportContentType(format string) (string, bool) {
+	contentType, ok := exportContentTypes[format]
+	return contentType, ok
+}
+
+// NewTableWriter membuat penulis tabel; keys dipakai sebagai nama field JSON dan headers sebagai judul kolom CSV/XLSX
+func NewTableWriter(format string, w io.Writer, sheet string, keys []string, headers []string) (TableWriter, error) {
+	switch format {
+	case "csv":
+		return newCSVTableWriter(w, headers)
+	case "xlsx":
+		return newXLSXTableWriter(w, sheet, headers)
+	case "json":
+		return newJSONTableWriter(w, keys)
+	}
+	return nil, NewError(MsgExportUnsupportedFormat, format)
+}
+
+// SpreadsheetText mengubah nilai sel menjadi teks, waktu memakai format yang dikenali Excel
+func SpreadsheetText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format("2006-01-02 15:04:05")
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return SpreadsheetText(*v)
+	case []string:
+		return strings.Join(v, ", ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	if ref := reflect.ValueOf(value); ref.Kind() == reflect.Pointer {
+		if ref.IsNil() {
+			return ""
+		}
+		return SpreadsheetText(ref.Elem().Interface())
+	}
+	return fmt.Sprint(value)
+}
+
+type csvTableWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVTableWriter(w io.Writer, headers []string) (*csvTableWriter, error) {
+	// BOM membuat Excel membaca CSV sebagai UTF-8
+	if _, err := io.WriteString(w, "\xef\xbb\xbf"); err != nil {
+		return nil, err
+	}
+	writer := &csvTableWriter{writer: csv.NewWriter(w)}
+	if err := writer.writer.Write(headers); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (t *csvTableWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = SpreadsheetText(value)
+	}
+	return t.writer.Write(record)
+}
+
+func (t *csvTableWriter) Close() error {
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+type jsonTableWriter struct {
+	w     io.Writer
+	keys  []string
+	count int
+}
+
+func newJSONTableWriter(w io.Writer, keys []string) (*jsonTableWriter, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return nil, err
+	}
+	return &jsonTableWriter{w: w, keys: keys}, nil
+}
+
+// WriteRow menulis satu objek dengan urutan field mengikuti urutan kolom
+func (t *jsonTableWriter) WriteRow(values []interface{}) error {
+	var row bytes.Buffer
+	if t.count > 0 {
+		row.WriteString(",")
+	}
+	row.WriteString("\n{")
+	for i, key := range t.keys {
+		if i > 0 {
+			row.WriteString(",")
+		}
+		name, _ := json.Marshal(key)
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		row.Write(name)
+		row.WriteString(":")
+		row.Write(value)
+	}
+	row.WriteString("}")
+	t.count++
+	_, err := t.w.Write(row.Bytes())
+	return err
+}
+
+func (t *jsonTableWriter) Close() error {
+	_, err := io.WriteString(t.w, "\n]\n")
+	return err
+}
+
+const xlsxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+// xlsxStaticParts adalah bagian workbook yang tidak bergantung pada isi tabel
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xlsxHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xlsxHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/_rels/workbook.xml.rels", xlsxHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`},
+	// Gaya 1 dipakai untuk baris judul kolom (huruf tebal)
+	{"xl/styles.xml", xlsxHeader + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`},
+}
+
+type xlsxTableWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	row     int
+}
+
+// newXLSXTableWriter menulis workbook satu lembar; isi lembar ditulis bertahap ke dalam arsip zip
+func newXLSXTableWriter(w io.Writer, sheetName string, headers []string) (*xlsxTableWriter, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxStaticParts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	var name bytes.Buffer
+	xml.EscapeText(&name, []byte(sheetName))
+	workbook, err := archive.Create("xl/workbook.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(workbook, xlsxHeader+`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" `+
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`+
+		`<sheets><sheet name="`+name.String()+`" sheetId="1" r:id="rId1"/></sheets></workbook>`); err != nil {
+		return nil, err
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	// Baris judul dibekukan agar tetap terlihat saat menggulir
+	if _, err := io.WriteString(sheet, xlsxHeader+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`+
+		`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`+
+		`<sheetData>`); err != nil {
+		return nil, err
+	}
+	writer := &xlsxTableWriter{archive: archive, sheet: sheet}
+	values := make([]interface{}, len(headers))
+	for i, header := range headers {
+		values[i] = header
+	}
+	if err := writer.writeRow(values, 1); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (t *xlsxTableWriter) WriteRow(values []interface{}) error {
+	return t.writeRow(values, 0)
+}
+
+func (t *xlsxTableWriter) writeRow(values []interface{}, style int) error {
+	t.row++
+	var row bytes.Buffer
+	fmt.Fprintf(&row, `<row r="%d">`, t.row)
+	for i, value := range values {
+		ref := xlsxColumnName(i) + strconv.Itoa(t.row)
+		styleAttr := ""
+		if style > 0 {
+			styleAttr = fmt.Sprintf(` s="%d"`, style)
+		}
+		if pointer := reflect.ValueOf(value); pointer.Kind() == reflect.Pointer && !pointer.IsNil() {
+			value = pointer.Elem().Interface()
+		}
+		switch v := value.(type) {
+		case int, int32, int64, uint, uint32, uint64, float32, float64:
+			fmt.Fprintf(&row, `<c r="%s"%s><v>%v</v></c>`, ref, styleAttr, v)
+		case bool:
+			flag := 0
+			if v {
+				flag = 1
+			}
+			fmt.Fprintf(&row, `<c r="%s"%s t="b"><v>%d</v></c>`, ref, styleAttr, flag)
+		default:
+			text := SpreadsheetText(value)
+			if text == "" {
+				continue
+			}
+			fmt.Fprintf(&row, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">`, ref, styleAttr)
+			xml.EscapeText(&row, []byte(text))
+			row.WriteString(`</t></is></c>`)
+		}
+	}
+	row.WriteString(`</row>`)
+	_, err := t.sheet.Write(row.Bytes())
+	return err
+}
+
+func (t *xlsxTableWriter) Close() error {
+	if _, err := io.WriteString(t.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return t.archive.Close()
+}
+
+// xlsxColumnName mengubah indeks kolom berbasis nol menjadi nama kolom seperti "AB"
+func xlsxColumnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
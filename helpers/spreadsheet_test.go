@@ -0,0 +1,100 @@
+package helpers
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// xlsxFixture membuat workbook minimal dengan lembar pertama di xl/worksheets/data.xml
+func xlsxFixture(t *testing.T, sheet string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Produk" sheetId="1" r:id="rId7"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId7" Target="worksheets/data.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>title</t></si><si><t>price</t></si><si><r><t>Videotron </t></r><r><t>P10</t></r></si></sst>`,
+		"xl/worksheets/data.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + sheet + `</sheetData></worksheet>`,
+	}
+	for name, content := range parts {
+		file, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Write([]byte(content))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadSpreadsheet(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		want     [][]string
+	}{
+		{
+			name:     "csv with comma",
+			filename: "produk.csv",
+			data:     []byte("title,price\n Videotron ,1500000\n\n"),
+			want:     [][]string{{"title", "price"}, {"Videotron", "1500000"}},
+		},
+		{
+			name:     "csv with semicolon and BOM",
+			filename: "PRODUK.CSV",
+			data:     []byte("\xef\xbb\xbftitle;price\n\"LED; outdoor\";1,5\n"),
+			want:     [][]string{{"title", "price"}, {"LED; outdoor", "1,5"}},
+		},
+		{
+			name:     "xlsx shared, rich, inline and boolean cells",
+			filename: "produk.xlsx",
+			data: xlsxFixture(t, `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>`+
+				`<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2"><v>1500000</v></c><c r="D2" t="b"><v>1</v></c></row>`+
+				`<row r="4"><c r="B4" t="inlineStr"><is><t> tanpa judul </t></is></c></row>`+
+				`<row r="6"><c r="A6" t="inlineStr"><is><t> </t></is></c></row>`),
+			want: [][]string{
+				{"title", "price"},
+				{"Videotron P10", "1500000", "", "true"},
+				nil,
+				{"", "tanpa judul"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ReadSpreadsheet(tt.filename, tt.data)
+			if err != nil {
+				t.Fatalf("ReadSpreadsheet: %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Errorf("rows = %q, want %q", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSpreadsheetRejectsUnknownFormat(t *testing.T) {
+	_, err := ReadSpreadsheet("produk.ods", []byte("x"))
+	var appErr *AppError
+	if !errors.As(err, &appErr) || appErr.Code != MsgImportUnsupportedFormat {
+		t.Errorf("err = %v, want %s", err, MsgImportUnsupportedFormat)
+	}
+}
+
+func TestXLSXColumnIndex(t *testing.T) {
+	for ref, want := range map[string]int{"A1": 0, "Z9": 25, "AA10": 26, "AB12": 27, "XFD1": 16383} {
+		if got := xlsxColumnIndex(ref); got != want {
+			t.Errorf("xlsxColumnIndex(%q) = %d, want %d", ref, got, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package helpers
+
+import "strings"
+
+// Kata umum yang tidak diindeks karena tidak membantu pencarian
+var searchStopwords = map[string]bool{
+	"yang": true, "dan": true, "di": true, "ke": true, "dari": true, "untuk": true, "dengan": true,
+	"ini": true, "itu": true, "atau": true, "pada": true, "adalah": true, "dalam": true, "juga": true,
+	"akan": true, "oleh": true, "sebagai": true, "tidak": true, "ada": true, "bisa": true, "serta": true,
+	"the": true, "and": true, "of": true, "for": true, "with": true, "a": true, "an": true, "in": true,
+	"to": true, "on": true, "is": true,
+}
+
+func isVowel(b byte) bool {
+	return b == 'a' || b == 'i' || b == 'u' || b == 'e' || b == 'o'
+}
+
+// StemIndonesian memotong partikel, kata ganti milik, awalan dan akhiran bahasa Indonesia.
+// Tanpa kamus kata dasar hasilnya tidak selalu benar secara bahasa, tetapi konsisten
+// sehingga "pemasangan", "memasang" dan "dipasang" menjadi "pasang".
+func StemIndonesian(word string) string {
+	if len(word) <= 4 || strings.ContainsAny(word, "0123456789") {
+		return word
+	}
+
+	// Partikel -lah, -kah, -tah, -pun
+	for _, suffix := range []string{"lah", "kah", "tah", "pun"} {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 4 {
+			word = strings.TrimSuffix(word, suffix)
+			break
+		}
+	}
+	// Kata ganti milik -ku, -mu, -nya
+	for _, suffix := range []string{"nya", "ku", "mu"} {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 4 {
+			word = strings.TrimSuffix(word, suffix)
+			break
+		}
+	}
+
+	// Awalan, maksimal dua lapis (mis. "memper-", "diper-")
+	for i := 0; i < 2; i++ {
+		stripped := stripIndonesianPrefix(word)
+		if stripped == word || len(stripped) < 4 {
+			break
+		}
+		word = stripped
+	}
+
+	// Akhiran -kan, -an, -i
+	for _, suffix := range []string{"kan", "an", "i"} {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= 4 {
+			word = strings.TrimSuffix(word, suffix)
+			break
+		}
+	}
+	return word
+}
+
+func stripIndonesianPrefix(word string) string {
+	switch {
+	case strings.HasPrefix(word, "meny") && len(word) > 4 && isVowel(word[4]):
+		return "s" + word[4:]
+	case strings.HasPrefix(word, "peny") && len(word) > 4 && isVowel(word[4]):
+		return "s" + word[4:]
+	case strings.HasPrefix(word, "meng"), strings.HasPrefix(word, "peng"):
+		return word[4:]
+	case strings.HasPrefix(word, "mem") && len(word) > 3 && isVowel(word[3]):
+		return "p" + word[3:]
+	case strings.HasPrefix(word, "pem") && len(word) > 3 && isVowel(word[3]):
+		return "p" + word[3:]
+	case strings.HasPrefix(word, "men") && len(word) > 3 && isVowel(word[3]):
+		return "t" + word[3:]
+	case strings.HasPrefix(word, "pen") && len(word) > 3 && isVowel(word[3]):
+		return "t" + word[3:]
+	case strings.HasPrefix(word, "mem"), strings.HasPrefix(word, "men"), strings.HasPrefix(word, "pem"), strings.HasPrefix(word, "pen"):
+		return word[3:]
+	case strings.HasPrefix(word, "per"), strings.HasPrefix(word, "ber"), strings.HasPrefix(word, "ter"):
+		return word[3:]
+	case strings.HasPrefix(word, "me"), strings.HasPrefix(word, "pe"), strings.HasPrefix(word, "be"), strings.HasPrefix(word, "te"),
+		strings.HasPrefix(word, "di"), strings.HasPrefix(word, "ke"), strings.HasPrefix(word, "se"):
+		return word[2:]
+	}
+	return word
+}
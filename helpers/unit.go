@@ -0,0 +1,48 @@
+package helpers
+
+import (
+	"math"
+	"strings"
+)
+
+// unitConversion mengubah satu satuan ke satuan dasar kelompoknya
+type unitConversion struct {
+	base   string
+	factor float64
+}
+
+// unitConversions berisi satuan yang umum dipakai pada spesifikasi layar LED
+var unitConversions = map[string]unitConversion{
+	// Panjang, misalnya pixel pitch dan dimensi kabinet
+	"mm":   {"mm", 1},
+	"cm":   {"mm", 10},
+	"m":    {"mm", 1000},
+	"in":   {"mm", 25.4},
+	"inch": {"mm", 25.4},
+	// Daya
+	"w":  {"W", 1},
+	"kw": {"W", 1000},
+	// Berat
+	"g":   {"kg", 0.001},
+	"kg":  {"kg", 1},
+	"ton": {"kg", 1000},
+	// Kecerahan
+	"nit":   {"nits", 1},
+	"nits":  {"nits", 1},
+	"cd/m2": {"nits", 1},
+	"cd/m²": {"nits", 1},
+	// Frekuensi, misalnya refresh rate
+	"hz":  {"Hz", 1},
+	"khz": {"Hz", 1000},
+	"mhz": {"Hz", 1000000},
+}
+
+// NormalizeUnit mengubah nilai ke satuan dasar; satuan yang tidak dikenal dikembalikan apa adanya.
+// Hasil dibulatkan ke 6 desimal agar sisa pembulatan float tidak dianggap sebagai perbedaan.
+func NormalizeUnit(value float64, unit string) (float64, string) {
+	conversion, ok := unitConversions[strings.ToLower(strings.TrimSpace(unit))]
+	if !ok {
+		return value, strings.TrimSpace(unit)
+	}
+	return math.Round(value*conversion.factor*1e6) / 1e6, conversion.base
+}
@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"Matahariled/models"
+	"errors"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+	"github.com/gofiber/fiber/v2"
+)
+
+var universalTranslator = ut.New(id.New(), id.New(), en.New())
+
+// RegisterValidatorTranslations mendaftarkan pesan validator bawaan untuk setiap bahasa yang didukung
+func RegisterValidatorTranslations(v *validator.Validate) error {
+	idTranslator, _ := universalTranslator.GetTranslator(models.LocaleIndonesian)
+	if err := id_translations.RegisterDefaultTranslations(v, idTranslator); err != nil {
+		return err
+	}
+	enTranslator, _ := universalTranslator.GetTranslator(models.LocaleEnglish)
+	return en_translations.RegisterDefaultTranslations(v, enTranslator)
+}
+
+// ValidatorTranslator mengembalikan penerjemah pesan validator sesuai bahasa permintaan
+func ValidatorTranslator(c *fiber.Ctx) ut.Translator {
+	translator, _ := universalTranslator.GetTranslator(Locale(c))
+	return translator
+}
+
+// ValidationMessages menerjemahkan kesalahan validator menjadi daftar pesan per kolom.
+// Kolom di dalam slice atau struct bersarang memakai jalurnya, misalnya Attributes[0].Name
+func ValidationMessages(c *fiber.Ctx, err error) map[string][]string {
+	messages := make(map[string][]string)
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		messages["body"] = append(messages["body"], Message(c, MsgInvalidRequestBody))
+		return messages
+	}
+	translator := ValidatorTranslator(c)
+	for _, fieldError := range fieldErrors {
+		field := fieldError.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		messages[field] = append(messages[field], fieldError.Translate(translator))
+	}
+	return messages
+}
@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+func TestValidationMessages(t *testing.T) {
+	type line struct {
+		Name string `validate:"required"`
+	}
+	type request struct {
+		Title string `validate:"required"`
+		Lines []line `validate:"dive"`
+	}
+
+	v := validator.New()
+	if err := RegisterValidatorTranslations(v); err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+	c.Request().Header.Set(fiber.HeaderAcceptLanguage, "en")
+
+	tests := []struct {
+		name string
+		body request
+		want []string
+	}{
+		{"top level field", request{Lines: []line{{Name: "a"}}}, []string{"Title"}},
+		{"nested field keeps its path", request{Title: "a", Lines: []line{{Name: "a"}, {}}}, []string{"Lines[1].Name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := ValidationMessages(c, v.Struct(tt.body))
+			fields := make([]string, 0, len(messages))
+			for field, list := range messages {
+				fields = append(fields, field)
+				if len(list) != 1 || list[0] == "" {
+					t.Errorf("%s messages = %q", field, list)
+				}
+			}
+			if !reflect.DeepEqual(fields, tt.want) {
+				t.Errorf("fields = %v, want %v", fields, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	VideoProviderYoutube = "youtube"
+	VideoProviderVimeo   = "vimeo"
+)
+
+var (
+	ErrInvalidVideoURL = NewError(MsgInvalidVideoURL)
+
+	youtubeIdPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoIdPattern   = regexp.MustCompile(`^[0-9]{6,12}$`)
+	iframeSrcPattern = regexp.MustCompile(`(?i)<iframe[^>]*\ssrc\s*=\s*["']([^"']+)["']`)
+)
+
+// VideoSource adalah hasil normalisasi input video menjadi provider dan ID
+type VideoSource struct {
+	Provider string
+	Id       string
+}
+
+// ParseVideoSource menerima URL, ID, atau potongan iframe lama dari YouTube/Vimeo
+func ParseVideoSource(input string) (VideoSource, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return VideoSource{}, ErrInvalidVideoURL
+	}
+
+	// Data lama berupa HTML iframe, yang dipakai hanya atribut src-nya
+	if match := iframeSrcPattern.FindStringSubmatch(input); match != nil {
+		input = html.UnescapeString(match[1])
+	}
+
+	// ID tanpa URL
+	if youtubeIdPattern.MatchString(input) && !vimeoIdPattern.MatchString(input) {
+		return VideoSource{Provider: VideoProviderYoutube, Id: input}, nil
+	}
+	if vimeoIdPattern.MatchString(input) {
+		return VideoSource{Provider: VideoProviderVimeo, Id: input}, nil
+	}
+
+	if strings.HasPrefix(input, "//") {
+		input = "https:" + input
+	} else if !strings.Contains(input, "://") {
+		input = "https://" + input
+	}
+	u, err := url.Parse(input)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return VideoSource{}, ErrInvalidVideoURL
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var source VideoSource
+	switch host {
+	case "youtube.com", "youtube-nocookie.com", "music.youtube.com":
+		source.Provider = VideoProviderYoutube
+		switch {
+		case segments[0] == "watch":
+			source.Id = u.Query().Get("v")
+		case len(segments) >= 2 && (segments[0] == "embed" || segments[0] == "shorts" || segments[0] == "live" || segments[0] == "v"):
+			source.Id = segments[1]
+		}
+	case "youtu.be":
+		source.Provider = VideoProviderYoutube
+		source.Id = segments[0]
+	case "vimeo.com":
+		source.Provider = VideoProviderVimeo
+		// vimeo.com/123456 atau vimeo.com/channels/nama/123456
+		source.Id = segments[len(segments)-1]
+	case "player.vimeo.com":
+		source.Provider = VideoProviderVimeo
+		if len(segments) >= 2 && segments[0] == "video" {
+			source.Id = segments[1]
+		}
+	default:
+		return VideoSource{}, ErrInvalidVideoURL
+	}
+
+	if source.Provider == VideoProviderYoutube && !youtubeIdPattern.MatchString(source.Id) {
+		return VideoSource{}, ErrInvalidVideoURL
+	}
+	if source.Provider == VideoProviderVimeo && !vimeoIdPattern.MatchString(source.Id) {
+		return VideoSource{}, ErrInvalidVideoURL
+	}
+	return source, nil
+}
+
+// WatchURL adalah tautan kanonik ke halaman video
+func (s VideoSource) WatchURL() string {
+	if s.Provider == VideoProviderVimeo {
+		return "https://vimeo.com/" + s.Id
+	}
+	return "https://www.youtube.com/watch?v=" + s.Id
+}
+
+// EmbedURL adalah alamat player yang dipakai pada iframe
+func (s VideoSource) EmbedURL() string {
+	if s.Provider == VideoProviderVimeo {
+		return "https://player.vimeo.com/video/" + s.Id
+	}
+	return "https://www.youtube-nocookie.com/embed/" + s.Id
+}
+
+// EmbedHTML membuat iframe kanonik di server sehingga tidak ada HTML dari pengguna yang dirender
+func (s VideoSource) EmbedHTML(title string) string {
+	return fmt.Sprintf(
+		`<iframe src="%s" title="%s" width="560" height="315" frameborder="0" allow="accelerometer; autoplay; clipboard-write; encrypted-media; gyroscope; picture-in-picture" allowfullscreen></iframe>`,
+		html.EscapeString(s.EmbedURL()),
+		html.EscapeString(title),
+	)
+}
+
+// OEmbed adalah bagian respons oEmbed yang disimpan
+type OEmbed struct {
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+var oembedClient = &http.Client{Timeout: 5 * time.Second}
+
+// oembedEndpoint bisa diarahkan ke stub lokal lewat OEMBED_YOUTUBE_URL / OEMBED_VIMEO_URL
+func oembedEndpoint(provider string) string {
+	if provider == VideoProviderVimeo {
+		if endpoint := os.Getenv("OEMBED_VIMEO_URL"); endpoint != "" {
+			return endpoint
+		}
+		return "https://vimeo.com/api/oembed.json"
+	}
+	if endpoint := os.Getenv("OEMBED_YOUTUBE_URL"); endpoint != "" {
+		return endpoint
+	}
+	return "https://www.youtube.com/oembed"
+}
+
+// FetchOEmbed mengambil judul dan thumbnail video dari provider
+func FetchOEmbed(source VideoSource) (OEmbed, error) {
+	var result OEmbed
+
+	endpoint, err := url.Parse(oembedEndpoint(source.Provider))
+	if err != nil {
+		return result, err
+	}
+	query := endpoint.Query()
+	query.Set("url", source.WatchURL())
+	query.Set("format", "json")
+	endpoint.RawQuery = query.Encode()
+
+	resp, err := oembedClient.Get(endpoint.String())
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("oembed %s: status %d", source.Provider, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,237 @@
+package initialize
+
+import (
+	"Matahariled/helpers"
+	"Matahariled/models"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// prepareFileSizeColumn membersihkan kolom size lama (varchar) agar bisa diubah menjadi bigint oleh AutoMigrate
+func prepareFileSizeColumn(db *gorm.DB) {
+	if !db.Migrator().HasTable(&models.File{}) {
+		return
+	}
+	columnTypes, err := db.Migrator().ColumnTypes(&models.File{})
+	if err != nil {
+		return
+	}
+	for _, column := range columnTypes {
+		if column.Name() != "size" {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(column.DatabaseTypeName()), "char") {
+			return
+		}
+		// Nilai yang bukan angka tidak bisa dikonversi, jadikan 0 lalu dihitung ulang oleh backfill
+		db.Exec("UPDATE files SET size = '0' WHERE size IS NULL OR size NOT REGEXP '^[0-9]+$'")
+		return
+	}
+}
+
+// backfillMediaMetadata melengkapi metadata file lama dan menautkan gallery serta hero ke tabel files
+func backfillMediaMetadata(db *gorm.DB) {
+	// File lama belum memiliki checksum. File yang gagal dibaca ditandai agar tidak dicoba ulang setiap boot
+	var files []models.File
+	db.Where("(checksum IS NULL OR checksum = '') AND probe_failed = ?", false).Find(&files)
+	for _, file := range files {
+		info, err := helpers.ProbeMediaFile("."+file.Path, "")
+		if err != nil {
+			log.Printf("backfill media: file %d (%s): %v", file.FileId, file.Path, err)
+			db.Model(&file).Update("probe_failed", true)
+			continue
+		}
+		db.Model(&file).Updates(map[string]interface{}{
+			"size":      info.Size,
+			"format":    info.Format,
+			"mime_type": info.MimeType,
+			"checksum":  info.Checksum,
+			"width":     info.Width,
+			"height":    info.Height,
+			"duration":  info.Duration,
+		})
+	}
+
+	// Gallery dan hero lama menyimpan metadata sendiri, pindahkan ke tabel files
+	var galleries []models.Gallery
+	db.Where("file_id IS NULL OR file_id = 0").Find(&galleries)
+	for _, gallery := range galleries {
+		file, ok := backfillFileRecord(db, gallery.Path, gallery.Gallery_name)
+		if ok {
+			db.Model(&gallery).Update("file_id", file.FileId)
+		}
+	}
+
+	var heroes []models.Hero
+	db.Where("file_id IS NULL OR file_id = 0").Find(&heroes)
+	for _, hero := range heroes {
+		file, ok := backfillFileRecord(db, hero.Path, hero.Hero_name)
+		if ok {
+			db.Model(&hero).Update("file_id", file.FileId)
+		}
+	}
+}
+
+// backfillFileRecord membuat baris files untuk path lama. Jika file gagal dibaca, baris tetap dibuat dengan tanda
+// probe_failed agar gallery atau hero tetap tertaut dan tidak diproses ulang pada boot berikutnya
+func backfillFileRecord(db *gorm.DB, path string, originalName string) (models.File, bool) {
+	info, err := helpers.ProbeMediaFile("."+path, "")
+	if err != nil {
+		log.Printf("backfill media: %s: %v", path, err)
+		file := models.File{Path: path, File_name: filepath.Base(path), OriginalName: originalName, ProbeFailed: true}
+		if err := db.Create(&file).Error; err != nil {
+			log.Printf("backfill media: %s: %v", path, err)
+			return models.File{}, false
+		}
+		return file, true
+	}
+	file := models.File{
+		Path:         path,
+		File_name:    filepath.Base(path),
+		OriginalName: originalName,
+		Size:         info.Size,
+		Format:       info.Format,
+		MimeType:     info.MimeType,
+		Checksum:     info.Checksum,
+		Width:        info.Width,
+		Height:       info.Height,
+		Duration:     info.Duration,
+	}
+	if err := db.Create(&file).Error; err != nil {
+		log.Printf("backfill media: %s: %v", path, err)
+		return models.File{}, false
+	}
+	return file, true
+}
+
+// backfillVideoEmbeds mengganti HTML embed lama dengan iframe kanonik hasil normalisasi
+func backfillVideoEmbeds(db *gorm.DB) {
+	var videos []models.Video
+	db.Where("(provider IS NULL OR provider = '') AND embed <> ''").Find(&videos)
+	for _, video := range videos {
+		source, err := helpers.ParseVideoSource(video.Embed)
+		if err != nil {
+			// HTML yang tidak dikenali tidak boleh dirender lagi
+			log.Printf("backfill video %d: embed tidak dikenali, dikosongkan", video.VideoId)
+			db.Model(&video).Update("embed", "")
+			continue
+		}
+		db.Model(&video).Updates(map[string]interface{}{
+			"provider":          source.Provider,
+			"provider_video_id": source.Id,
+			"url":               source.WatchURL(),
+			"embed":             source.EmbedHTML(video.Title),
+		})
+	}
+}
+
+// migrateHistoryVideos memindahkan relasi satu-ke-satu histories.video_id ke tabel HistoryVideo
+func migrateHistoryVideos(db *gorm.DB) {
+	if !db.Migrator().HasColumn(&models.History{}, "video_id") {
+		return
+	}
+	err := db.Exec("INSERT IGNORE INTO HistoryVideo (history_history_id, video_video_id) " +
+		"SELECT h.history_id, h.video_id FROM histories h JOIN videos v ON v.video_id = h.video_id").Error
+	if err != nil {
+		log.Printf("migrate history videos: %v", err)
+		return
+	}
+	if db.Migrator().HasConstraint(&models.History{}, "fk_histories_video") {
+		db.Migrator().DropConstraint(&models.History{}, "fk_histories_video")
+	}
+	if err := db.Migrator().DropColumn(&models.History{}, "video_id"); err != nil {
+		log.Printf("migrate history videos: %v", err)
+	}
+}
+
+// prepareUserAvatars menghapus foreign key lama users -> files (ON DELETE CASCADE) agar dibuat ulang sebagai SET NULL,
+// lalu melepas foto default bersama (dulu FileId 1) dan referensi yatim sehingga avatar inisial dipakai sebagai gantinya
+func prepareUserAvatars(db *gorm.DB) {
+	if !db.Migrator().HasTable(&models.User{}) || !db.Migrator().HasTable(&models.File{}) {
+		return
+	}
+	if db.Migrator().HasConstraint(&models.User{}, "fk_users_file") {
+		db.Migrator().DropConstraint(&models.User{}, "fk_users_file")
+	}
+	err := db.Exec("UPDATE users SET file_id = NULL WHERE file_id = 0"+
+		" OR file_id NOT IN (SELECT file_id FROM files)"+
+		" OR file_id IN (SELECT file_id FROM files WHERE path = ?)"+
+		" OR file_id IN (SELECT file_id FROM (SELECT file_id FROM users WHERE file_id IS NOT NULL GROUP BY file_id HAVING COUNT(*) > 1) AS shared)",
+		"/public/image_notfound.jpg").Error
+	if err != nil {
+		log.Printf("prepare user avatars: %v", err)
+	}
+}
+
+// prepareSlugColumns menambahkan kolom slug dan mengisinya untuk data lama sebelum AutoMigrate membuat unique index
+func prepareSlugColumns(db *gorm.DB) {
+	type slugSource struct {
+		model    interface{}
+		table    string
+		idColumn string
+		title    string
+		fallback string
+	}
+	sources := []slugSource{
+		{&models.Product{}, "products", "product_id", "title", models.SlugEntityProduct},
+		{&models.Category{}, "categories", "category_id", "category", models.SlugEntityCategory},
+		{&models.History{}, "histories", "history_id", "title", models.SlugEntityHistory},
+	}
+
+	for _, source := range sources {
+		if !db.Migrator().HasTable(source.model) {
+			continue
+		}
+		if !db.Migrator().HasColumn(source.model, "Slug") {
+			if err := db.Migrator().AddColumn(source.model, "Slug"); err != nil {
+				log.Printf("prepare slug %s: %v", source.table, err)
+				continue
+			}
+		}
+
+		var rows []struct {
+			Id    int64
+			Title string
+		}
+		db.Table(source.table).Select(source.idColumn + " AS id, " + source.title + " AS title").
+			Where("slug IS NULL OR slug = ''").Order(source.idColumn).Scan(&rows)
+		for _, row := range rows {
+			slug, err := helpers.UniqueSlug(db, source.table, source.idColumn, row.Title, source.fallback, row.Id)
+			if err != nil {
+				log.Printf("prepare slug %s %d: %v", source.table, row.Id, err)
+				continue
+			}
+			db.Table(source.table).Where(source.idColumn+" = ?", row.Id).Update("slug", slug)
+		}
+	}
+}
+
+// prepareStatusColumns menambahkan kolom status dengan nilai published untuk produk dan riwayat lama yang dibuat
+// sebelum alur draft/published ada. Setelah itu AutoMigrate mengganti nilai bawaan kolom menjadi draft untuk data baru.
+func prepareStatusColumns(db *gorm.DB) {
+	for _, table := range []string{"products", "histories"} {
+		if !db.Migrator().HasTable(table) || db.Migrator().HasColumn(table, "status") {
+			continue
+		}
+		err := db.Exec("ALTER TABLE " + table +
+			" ADD COLUMN status ENUM('draft', 'in_review', 'published', 'archived') DEFAULT 'published'").Error
+		if err != nil {
+			log.Printf("prepare status %s: %v", table, err)
+		}
+	}
+}
+
+// backfillPublishedAt menandai produk dan riwayat lama (status published dari prepareStatusColumns) sebagai sudah terbit
+// agar tetap tampil di endpoint publik setelah alur draft/published diterapkan
+func backfillPublishedAt(db *gorm.DB) {
+	for _, table := range []string{"products", "histories"} {
+		err := db.Exec("UPDATE " + table + " SET published_at = created_at " +
+			"WHERE status = 'published' AND published_at IS NULL AND publish_at IS NULL").Error
+		if err != nil {
+			log.Printf("backfill published_at %s: %v", table, err)
+		}
+	}
+}
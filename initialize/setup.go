@@ -29,15 +29,33 @@ func ConnectDatabase() {
 		panic("Failed to connect to database!")
 	}
 
+	prepareUserAvatars(db)
+	prepareSlugColumns(db)
+	prepareStatusColumns(db)
 	db.AutoMigrate(&models.User{})
+	prepareFileSizeColumn(db)
 	db.AutoMigrate(&models.File{})
+	db.AutoMigrate(&models.Video{})
 	db.AutoMigrate(&models.Product{})
 	db.AutoMigrate(&models.Category{})
 	db.AutoMigrate(&models.Contract{})
 	db.AutoMigrate(&models.History{})
-	db.AutoMigrate(&models.Video{})
 	db.AutoMigrate(&models.Tag{})
 	db.AutoMigrate(&models.Gallery{})
 	db.AutoMigrate(&models.Hero{})
+	db.AutoMigrate(&models.CategoryAttribute{})
+	db.AutoMigrate(&models.ProductAttributeValue{})
+	db.AutoMigrate(&models.SlugRedirect{})
+	db.AutoMigrate(&models.ProductRevision{})
+	db.AutoMigrate(&models.Translation{})
+	db.AutoMigrate(&models.ProductRecommendation{})
+	db.AutoMigrate(&models.PriceList{}, &models.PriceListItem{})
+	db.AutoMigrate(&models.Quotation{}, &models.QuotationLine{}, &models.QuotationVersion{}, &models.QuotationSequence{})
+	db.AutoMigrate(&models.Warehouse{}, &models.StockItem{}, &models.StockMovement{})
+	db.AutoMigrate(&models.ProductDocument{})
+	backfillMediaMetadata(db)
+	backfillVideoEmbeds(db)
+	migrateHistoryVideos(db)
+	backfillPublishedAt(db)
 	DB = db
 }
@@ -17,7 +17,18 @@ func main() {
 	port := os.Getenv("PORT")
 	initialize.ConnectDatabase()
 
-	app := fiber.New()
+	// Perintah CLI, misalnya: go run . import -dry-run produk.xlsx
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(controllers.RunImportCommand(os.Args[2:]))
+	}
+
+	controllers.StartSearchIndex()
+	controllers.StartTrashRetention()
+	controllers.StartPublishScheduler()
+	controllers.StartRecommendationJob()
+
+	// Batas body dinaikkan agar dokumen produk hingga 25 MB bisa diunggah
+	app := fiber.New(fiber.Config{BodyLimit: 32 << 20})
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
 		AllowMethods: "*",
@@ -29,6 +40,15 @@ func main() {
 	// Dashboard
 	api.Get("/dashboard", controllers.GetDashboard)
 
+	// Search
+	api.Get("/search", controllers.Search)
+
+	// Catalog
+	api.Get("/catalog", controllers.GetCatalog)
+
+	// Translation
+	api.Get("/translation/missing", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.GetMissingTranslations)
+
 	// Group Auth
 	auth := api.Group("/auth")
 	auth.Get("/profile", middleware.MultiRoleMiddleware("Customer", "Admin", "SuperAdmin"), controllers.GetProfile)
@@ -40,28 +60,66 @@ func main() {
 	user.Delete("/", controllers.DeleteUserT)
 	user.Get("/all", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.Index)
 	user.Get("/count", controllers.GetCountUser)
-	user.Put("/", controllers.EditUser)
+	user.Put("/", middleware.OptionalAuthMiddleware(), controllers.EditUser)
 	user.Get("/", controllers.GetUserById)
 	user.Get("/datatable", controllers.UserDatatable)
 	user.Get("/label", controllers.GetUsersLabel)
 	user.Post("/", controllers.CreateUserForm)
+	user.Get("/:id/avatar", controllers.GetUserAvatar)
+	user.Get("/trash", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.GetTrash("user"))
+	user.Put("/:id/restore", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.RestoreTrash("user"))
+	user.Delete("/:id/purge", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.PurgeTrash("user"))
+	// Group Me
+	me := api.Group("/me", middleware.MultiRoleMiddleware("Customer", "Admin", "SuperAdmin"))
+	me.Post("/avatar", controllers.UploadMyAvatar)
+	me.Delete("/avatar", controllers.DeleteMyAvatar)
 	// Group Products
-	product := api.Group("/product")
+	product := api.Group("/product", middleware.OptionalAuthMiddleware())
 	product.Get("/all", controllers.GetAllProducts)
 	product.Get("/count", controllers.GetCountProduct)
 	product.Get("/datatable", controllers.GetDatatableProducts)
 	product.Get("/label", controllers.GetProductsLabel)
+	product.Get("/compare", controllers.CompareProducts)
+	product.Post("/recommendations/rebuild", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.RebuildRecommendations)
+	product.Post("/import", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.ImportProducts)
 	product.Post("/", controllers.CreateProductT)
 	product.Put("/:id", controllers.UpdateProduct)
 	product.Delete("/", controllers.DeleteProductT)
 	product.Post("/hero", controllers.CreateHero)
 	product.Get("/hero", controllers.GetHero)
+	product.Get("/slug/:slug", controllers.GetProductBySlug)
+	product.Get("/trash", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.GetTrash("product"))
+	product.Put("/:id/restore", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.RestoreTrash("product"))
+	product.Delete("/:id/purge", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.PurgeTrash("product"))
+	product.Put("/:id/status", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.UpdatePublishStatus("product"))
+	product.Get("/:id/translations", controllers.GetTranslations("product"))
+	product.Put("/:id/translations/:locale", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.UpdateTranslations("product"))
+	product.Get("/:id/media.zip", controllers.DownloadProductMediaKit)
+	product.Get("/:id/brochure.pdf", controllers.GetProductBrochure)
+	product.Get("/:id/recommendations", controllers.GetProductRecommendations)
+	product.Get("/:id/documents", controllers.GetProductDocuments)
+	product.Get("/:id/documents/:documentId/download", controllers.DownloadProductDocument)
+	product.Post("/:id/documents", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.CreateProductDocument)
+	product.Put("/:id/documents/:documentId", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.UpdateProductDocument)
+	product.Delete("/:id/documents/:documentId", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.DeleteProductDocument)
+	product.Get("/:id/revisions", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.GetProductRevisions)
+	product.Get("/:id/revisions/diff", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.DiffProductRevisions)
+	product.Get("/:id/revisions/:number", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.GetProductRevision)
+	product.Post("/:id/revisions/:number/restore", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.RestoreProductRevision)
 	product.Get("/:id", controllers.GetProductById)
 	// Group Category
 	category := api.Group("/category")
 	category.Get("/label", controllers.GetCategoriesLabel)
 	category.Get("/count", controllers.GetCountCategory)
 	category.Post("/", controllers.CreateCategory)
+	category.Get("/tree", controllers.GetCategoryTree)
+	category.Get("/slug/:slug", controllers.GetCategoryBySlug)
+	category.Get("/:id/attributes", controllers.GetCategoryAttributes)
+	category.Get("/:id/catalog.pdf", controllers.GetCategoryCatalog)
+	category.Put("/:id/attributes", controllers.UpdateCategoryAttributes)
+	category.Put("/:id/move", controllers.MoveCategory)
+	category.Get("/:id/translations", controllers.GetTranslations("category"))
+	category.Put("/:id/translations/:locale", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.UpdateTranslations("category"))
 	category.Put("/", controllers.UpdateCategory)
 
 	// Group Contract
@@ -72,13 +130,62 @@ func main() {
 	contract.Post("/", controllers.CreateContract)
 	contract.Put("/:id", controllers.UpdateContract)
 	contract.Delete("/:id", controllers.DeleteContract)
+	contract.Get("/trash", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.GetTrash("contract"))
+	contract.Put("/:id/restore", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.RestoreTrash("contract"))
+	contract.Delete("/:id/purge", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.PurgeTrash("contract"))
+	contract.Get("/:id/stock", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.GetAssignedStock("contract"))
+
+	// Group Price List
+	priceList := api.Group("/pricelist", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"))
+	priceList.Get("/", controllers.GetPriceLists)
+	priceList.Get("/resolve", controllers.ResolvePrices)
+	priceList.Post("/", controllers.CreatePriceList)
+	priceList.Get("/:id", controllers.GetPriceList)
+	priceList.Put("/:id", controllers.UpdatePriceList)
+	priceList.Delete("/:id", controllers.DeletePriceList)
+
+	// Group Quotation
+	quotation := api.Group("/quotation", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"))
+	quotation.Get("/", controllers.GetQuotations)
+	quotation.Post("/", controllers.CreateQuotation)
+	quotation.Get("/:id", controllers.GetQuotation)
+	quotation.Put("/:id", controllers.UpdateQuotation)
+	quotation.Put("/:id/status", controllers.UpdateQuotationStatus)
+	quotation.Post("/:id/convert", controllers.ConvertQuotation)
+	quotation.Get("/:id/versions", controllers.GetQuotationVersions)
+	quotation.Get("/:id/versions/:version", controllers.GetQuotationVersion)
+	quotation.Get("/:id/quotation.pdf", controllers.GetQuotationPDF)
+
+	// Group Warehouse
+	warehouse := api.Group("/warehouse", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"))
+	warehouse.Get("/", controllers.GetWarehouses)
+	warehouse.Post("/", controllers.CreateWarehouse)
+	warehouse.Put("/:id", controllers.UpdateWarehouse)
+	warehouse.Delete("/:id", controllers.DeleteWarehouse)
+
+	// Group Stock
+	stock := api.Group("/stock", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"))
+	stock.Get("/", controllers.GetStockItems)
+	stock.Get("/summary", controllers.GetStockSummary)
+	stock.Get("/serial/:serial", controllers.GetStockItemBySerial)
+	stock.Post("/receive", controllers.ReceiveStock)
+	stock.Get("/:id", controllers.GetStockItem)
+	stock.Post("/:id/move", controllers.MoveStockItem)
 
 	//Group History
-	history := api.Group("/history")
+	history := api.Group("/history", middleware.OptionalAuthMiddleware())
 	history.Get("/all", controllers.GetAllHistories)
 	history.Get("/count", controllers.GetCountHistory)
 	history.Get("/datatable", controllers.GetDatatableHistories)
 	history.Get("/user", controllers.GetAllUserPortfolios)
+	history.Get("/slug/:slug", controllers.GetHistoryBySlug)
+	history.Get("/trash", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.GetTrash("history"))
+	history.Put("/:id/restore", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.RestoreTrash("history"))
+	history.Delete("/:id/purge", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.PurgeTrash("history"))
+	history.Put("/:id/status", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.UpdatePublishStatus("history"))
+	history.Get("/:id/stock", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.GetAssignedStock("history"))
+	history.Get("/:id/translations", controllers.GetTranslations("history"))
+	history.Put("/:id/translations/:locale", middleware.MultiRoleMiddleware("Admin", "SuperAdmin"), controllers.UpdateTranslations("history"))
 	history.Get("/:id", controllers.GetHistoryById)
 	history.Get("/product/:id", controllers.GetHistoryByIdProduct)
 	history.Post("/", controllers.CreateHistory)
@@ -93,10 +200,17 @@ func main() {
 	video := api.Group("/video")
 	video.Get("/all", controllers.GetAllVideos)
 	video.Get("/datatable", controllers.GetDatatableVideos)
+	video.Post("/", controllers.CreateVideo)
+	video.Get("/:id", controllers.GetVideoById)
+	video.Put("/:id", controllers.UpdateVideo)
+	video.Delete("/:id", controllers.DeleteVideo)
 
 	gallery := api.Group("/gallery")
 	gallery.Get("/:id", controllers.GetGalleryById)
 
+	media := api.Group("/media", middleware.OptionalAuthMiddleware())
+	media.Get("/:id", controllers.GetMediaById)
+
 	app.Listen(":" + port)
 
 }
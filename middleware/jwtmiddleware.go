@@ -17,21 +17,13 @@ func MultiRoleMiddleware(allowedRoles ...string) fiber.Handler {
 
 		// Periksa apakah token kosong
 		if tokenString == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(helpers.ResponseMassage{
-				Code:    fiber.StatusBadRequest,
-				Status:  "Bad Request",
-				Message: "Token Diperlukan",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(helpers.NewResponseMassage(c, fiber.StatusUnauthorized, helpers.MsgTokenRequired))
 		}
 
 		// Pisahkan nilai token JWT dari string "Bearer <token>"
 		tokenParts := strings.Split(tokenString, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			return c.Status(fiber.StatusUnauthorized).JSON(helpers.ResponseMassage{
-				Code:    fiber.StatusUnauthorized,
-				Status:  "Unauthorized",
-				Message: "Invalid Token Bearrer",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(helpers.NewResponseMassage(c, fiber.StatusUnauthorized, helpers.MsgTokenInvalid))
 		}
 		jwtToken := tokenParts[1]
 
@@ -44,20 +36,12 @@ func MultiRoleMiddleware(allowedRoles ...string) fiber.Handler {
 			return jwtSecret, nil
 		})
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(helpers.ResponseMassage{
-				Code:    fiber.StatusUnauthorized,
-				Status:  "Unauthorized",
-				Message: "Terjadi Kesalahan",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(helpers.NewResponseMassage(c, fiber.StatusUnauthorized, helpers.MsgInternalError))
 		}
 
 		// Periksa apakah token valid
 		if !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(helpers.ResponseMassage{
-				Code:    fiber.StatusUnauthorized,
-				Status:  "Unauthorized",
-				Message: "Token Tidak Valid",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(helpers.NewResponseMassage(c, fiber.StatusUnauthorized, helpers.MsgTokenInvalid))
 		}
 
 		// Ambil klaim dari token
@@ -65,6 +49,7 @@ func MultiRoleMiddleware(allowedRoles ...string) fiber.Handler {
 		c.Locals("userID", claims["user_id"])
 		// Periksa apakah peran pengguna ada di dalam daftar peran yang diperbolehkan
 		userRole := claims["role"].(string)
+		c.Locals("role", userRole)
 		roleAllowed := false
 		for _, allowedRole := range allowedRoles {
 			if userRole == allowedRole {
@@ -75,14 +60,36 @@ func MultiRoleMiddleware(allowedRoles ...string) fiber.Handler {
 
 		// Jika peran pengguna tidak diizinkan, kembalikan status Unauthorized
 		if !roleAllowed {
-			return c.Status(fiber.StatusUnauthorized).JSON(helpers.ResponseMassage{
-				Code:    fiber.StatusUnauthorized,
-				Status:  "Unauthorized",
-				Message: "Anda Tidak Ada Akses",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(helpers.NewResponseMassage(c, fiber.StatusUnauthorized, helpers.MsgAccessDenied))
 		}
 
 		// Jika peran pengguna diizinkan, lanjutkan ke handler berikutnya
 		return c.Next()
 	}
 }
+
+// OptionalAuthMiddleware mencatat userID dari token yang valid tanpa menolak request tanpa token,
+// dipakai pada endpoint publik yang tetap ingin mengetahui pengubah data
+func OptionalAuthMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tokenParts := strings.Split(c.Get("Authorization"), " ")
+		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+			return c.Next()
+		}
+
+		var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
+		token, err := jwt.Parse(tokenParts[1], func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("metode signing tidak valid: %v", token.Header["alg"])
+			}
+			return jwtSecret, nil
+		})
+		if err == nil && token.Valid {
+			if claims, ok := token.Claims.(jwt.MapClaims); ok {
+				c.Locals("userID", claims["user_id"])
+				c.Locals("role", claims["role"])
+			}
+		}
+		return c.Next()
+	}
+}
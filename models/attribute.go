@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+const (
+	AttributeTypeNumber  = "number"
+	AttributeTypeEnum    = "enum"
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeRange   = "range"
+)
+
+// CategoryAttribute adalah satu atribut spesifikasi terstruktur yang didefinisikan oleh kategori
+type CategoryAttribute struct {
+	AttributeId int64     `gorm:"primaryKey" json:"attribute_id"`
+	CategoryId  int64     `gorm:"uniqueIndex:idx_category_attribute" json:"category_id"`
+	Name        string    `gorm:"type:varchar(50);uniqueIndex:idx_category_attribute;index" json:"name"`
+	Label       string    `gorm:"type:varchar(100)" json:"label"`
+	Type        string    `gorm:"type:ENUM('number', 'enum', 'boolean', 'range')" json:"type"`
+	Unit        string    `gorm:"type:varchar(20)" json:"unit"`
+	Options     []string  `gorm:"type:text;serializer:json" json:"options"`
+	Min         *float64  `json:"min"`
+	Max         *float64  `json:"max"`
+	Required    bool      `gorm:"default:false" json:"required"`
+	Position    int       `gorm:"default:0" json:"position"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// ProductAttributeValue menyimpan nilai atribut produk pada kolom sesuai tipenya agar bisa difilter dan diurutkan
+type ProductAttributeValue struct {
+	ProductId   int64             `gorm:"primaryKey" json:"product_id"`
+	AttributeId int64             `gorm:"primaryKey" json:"attribute_id"`
+	Attribute   CategoryAttribute `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	NumberValue *float64          `gorm:"index" json:"number_value"`
+	MaxValue    *float64          `gorm:"index" json:"max_value"`
+	TextValue   string            `gorm:"type:varchar(100);index" json:"text_value"`
+	BoolValue   *bool             `gorm:"index" json:"bool_value"`
+}
+
+type CategoryAttributeRequest struct {
+	Name     string   `json:"name" validate:"required,max=50"`
+	Label    string   `json:"label" validate:"required,max=100"`
+	Type     string   `json:"type" validate:"required,oneof=number enum boolean range"`
+	Unit     string   `json:"unit" validate:"max=20"`
+	Options  []string `json:"options" validate:"required_if=Type enum,dive,required,max=100"`
+	Min      *float64 `json:"min"`
+	Max      *float64 `json:"max"`
+	Required bool     `json:"required"`
+}
+
+type CategoryAttributesRequest struct {
+	Attributes []CategoryAttributeRequest `json:"attributes" validate:"dive"`
+}
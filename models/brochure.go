@@ -0,0 +1,27 @@
+package models
+
+// BrochureImage adalah gambar yang dicetak pada brosur; Checksum membuat cache berganti saat isi file berubah
+type BrochureImage struct {
+	Path     string `json:"path"`
+	MimeType string `json:"mime_type"`
+	Checksum string `json:"checksum"`
+}
+
+// BrochureAttribute adalah satu baris tabel atribut teknis pada brosur
+type BrochureAttribute struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// BrochureProduct adalah isi brosur satu produk yang sudah diterjemahkan
+type BrochureProduct struct {
+	ProductId     int64               `json:"product_id"`
+	Title         string              `json:"title"`
+	Category      string              `json:"category"`
+	Description   string              `json:"description"`
+	Specification string              `json:"specification"`
+	Attributes    []BrochureAttribute `json:"attributes"`
+	Image         *BrochureImage      `json:"image"`
+	Gallery       []BrochureImage     `json:"gallery"`
+	UpdatedAt     string              `json:"updated_at"`
+}
@@ -5,11 +5,16 @@ import (
 )
 
 type Category struct {
-	CategoryId int64     `gorm:"primaryKey" json:"category_id"`
-	Category   string    `gorm:"type:varchar(255);index" json:"category" validate:"required"`
-	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	Tags       []Tag     `gorm:"many2many:CategoryTag;constraint:OnDelete:CASCADE" json:"tags"`
+	CategoryId int64               `gorm:"primaryKey" json:"category_id"`
+	Category   string              `gorm:"type:varchar(255);index" json:"category" validate:"required"`
+	Slug       string              `gorm:"type:varchar(150);uniqueIndex" json:"slug"`
+	ParentId   *int64              `gorm:"index" json:"parent_id"`
+	Position   int                 `gorm:"default:0" json:"position"`
+	Children   []Category          `gorm:"foreignKey:ParentId;constraint:OnDelete:SET NULL" json:"children,omitempty"`
+	CreatedAt  time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+	Tags       []Tag               `gorm:"many2many:CategoryTag;constraint:OnDelete:CASCADE" json:"tags"`
+	Attributes []CategoryAttribute `gorm:"foreignKey:CategoryId;constraint:OnDelete:CASCADE" json:"attributes"`
 }
 
 type CategoryTag struct {
@@ -19,5 +24,32 @@ type CategoryTag struct {
 
 type CategoryRequest struct {
 	Category string  `gorm:"type:varchar(255);index" json:"category" validate:"required"`
+	Slug     string  `json:"slug"`
+	ParentId *int64  `json:"parent_id"`
 	TagIDs   []int64 `json:"tag_ids"`
 }
+
+// CategoryMoveRequest memindahkan kategori ke induk lain; parent_id null berarti menjadi kategori utama
+type CategoryMoveRequest struct {
+	ParentId *int64 `json:"parent_id"`
+	Position *int   `json:"position"`
+}
+
+// CategoryNode adalah satu simpul pada pohon kategori
+type CategoryNode struct {
+	CategoryId        int64           `json:"category_id"`
+	Category          string          `json:"category"`
+	Slug              string          `json:"slug"`
+	ParentId          *int64          `json:"parent_id"`
+	Position          int             `json:"position"`
+	ProductCount      int64           `json:"product_count"`
+	TotalProductCount int64           `json:"total_product_count"`
+	Children          []*CategoryNode `json:"children"`
+}
+
+// CategoryBreadcrumb adalah satu langkah jalur kategori dari kategori utama
+type CategoryBreadcrumb struct {
+	CategoryId int64  `json:"category_id"`
+	Category   string `json:"category"`
+	Slug       string `json:"slug"`
+}
@@ -0,0 +1,33 @@
+package models
+
+// CompareProduct adalah ringkasan produk yang dibandingkan
+type CompareProduct struct {
+	ProductId  int64  `json:"product_id"`
+	Title      string `json:"title"`
+	Slug       string `json:"slug"`
+	CategoryId int64  `json:"category_id"`
+	Category   string `json:"category"`
+	PathFile   string `json:"path_file"`
+}
+
+// CompareValue adalah nilai atribut satu produk setelah satuannya dinormalisasi, Value nil jika produk tidak memilikinya
+type CompareValue struct {
+	ProductId int64       `json:"product_id"`
+	Value     interface{} `json:"value"`
+	Unit      string      `json:"unit,omitempty"`
+}
+
+// CompareAttribute adalah satu baris perbandingan yang disejajarkan berdasarkan nama atribut
+type CompareAttribute struct {
+	Name      string         `json:"name"`
+	Label     string         `json:"label"`
+	Type      string         `json:"type"`
+	Unit      string         `json:"unit,omitempty"`
+	Different bool           `json:"different"`
+	Values    []CompareValue `json:"values"`
+}
+
+type CompareResponse struct {
+	Products   []CompareProduct   `json:"products"`
+	Attributes []CompareAttribute `json:"attributes"`
+}
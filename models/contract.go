@@ -1,14 +1,17 @@
 package models
 
+import "gorm.io/gorm"
+
 type Contract struct {
-	ContractId  int64     `gorm:"primaryKey" json:"contract_id"`
-	Title       string    `gorm:"type:varchar(255)" json:"title" validate:"required"`
-	Description string    `gorm:"type:text" json:"description"`
-	StartDate   string    `gorm:"type:varchar(20)" json:"start_date" validate:"required"`
-	EndDate     string    `gorm:"type:varchar(20)" json:"end_date" validate:"required"`
-	UserID      int64     `json:"user_id" form:"user_id" validate:"required"`
-	User        User      `json:"user"`
-	Products    []Product `gorm:"many2many:ContractProduct;constraint:OnDelete:CASCADE" json:"products"`
+	ContractId  int64          `gorm:"primaryKey" json:"contract_id"`
+	Title       string         `gorm:"type:varchar(255)" json:"title" validate:"required"`
+	Description string         `gorm:"type:text" json:"description"`
+	StartDate   string         `gorm:"type:varchar(20)" json:"start_date" validate:"required"`
+	EndDate     string         `gorm:"type:varchar(20)" json:"end_date" validate:"required"`
+	UserID      int64          `json:"user_id" form:"user_id" validate:"required"`
+	User        User           `json:"user"`
+	Products    []Product      `gorm:"many2many:ContractProduct;constraint:OnDelete:CASCADE" json:"products"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 }
 
 type ContractProduct struct {
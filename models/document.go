@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+const (
+	DocumentTypeDatasheet   = "datasheet"
+	DocumentTypeManual      = "manual"
+	DocumentTypeCertificate = "certificate"
+	DocumentTypeCAD         = "cad"
+	DocumentTypeOther       = "other"
+)
+
+const (
+	DocumentVisibilityPublic  = "public"
+	DocumentVisibilityPrivate = "private"
+)
+
+// ProductDocument adalah lampiran produk seperti datasheet, manual, sertifikat CE/SNI atau gambar CAD.
+// File disimpan di luar folder public sehingga hanya bisa diunduh lewat endpoint yang memeriksa visibilitas.
+// Locale kosong berarti dokumen berlaku untuk semua bahasa.
+type ProductDocument struct {
+	ProductDocumentId int64     `gorm:"primaryKey" json:"product_document_id"`
+	ProductId         int64     `gorm:"index" json:"product_id"`
+	Product           Product   `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Type              string    `gorm:"type:ENUM('datasheet', 'manual', 'certificate', 'cad', 'other');default:'other';index" json:"type"`
+	Title             string    `gorm:"type:varchar(255)" json:"title"`
+	Locale            string    `gorm:"type:varchar(5);index" json:"locale"`
+	Version           string    `gorm:"type:varchar(30)" json:"version"`
+	Visibility        string    `gorm:"type:ENUM('public', 'private');default:'private';index" json:"visibility"`
+	StorageName       string    `gorm:"type:varchar(100)" json:"-"`
+	OriginalName      string    `gorm:"type:varchar(255)" json:"original_name"`
+	MimeType          string    `gorm:"type:varchar(100)" json:"mime_type"`
+	Size              int64     `json:"size"`
+	Checksum          string    `gorm:"type:char(64)" json:"checksum"`
+	UploadedBy        *int64    `gorm:"index" json:"uploaded_by"`
+	DownloadURL       string    `gorm:"-" json:"download_url"`
+	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// ProductDocumentRequest dikirim sebagai multipart form bersama field file; file boleh kosong saat memperbarui
+type ProductDocumentRequest struct {
+	Type       string `form:"type" validate:"required,oneof=datasheet manual certificate cad other"`
+	Title      string `form:"title" validate:"required,max=255"`
+	Locale     string `form:"locale" validate:"omitempty,oneof=id en"`
+	Version    string `form:"version" validate:"max=30"`
+	Visibility string `form:"visibility" validate:"required,oneof=public private"`
+}
@@ -3,11 +3,30 @@ package models
 import "time"
 
 type File struct {
-	FileId    int64      `gorm:"primaryKey" json:"file_id"`
-	Path      string     `gorm:"type:varchar(255)" json:"path"`
-	File_name string     `gorm:"type:varchar(255)" json:"file_name"`
-	Size      string     `gorm:"type:varchar(255)" json:"size"`
-	Format    string     `gorm:"type:varchar(10)" json:"format"`
-	CreatedAt *time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt *time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	FileId       int64      `gorm:"primaryKey" json:"file_id"`
+	Path         string     `gorm:"type:varchar(255)" json:"path"`
+	File_name    string     `gorm:"type:varchar(255)" json:"file_name"`
+	OriginalName string     `gorm:"type:varchar(255)" json:"original_name"`
+	Size         int64      `gorm:"default:0" json:"size"`
+	Format       string     `gorm:"type:varchar(10)" json:"format"`
+	MimeType     string     `gorm:"type:varchar(100);index" json:"mime_type"`
+	Checksum     string     `gorm:"type:char(64);index" json:"checksum"`
+	Width        int        `json:"width"`
+	Height       int        `json:"height"`
+	Duration     float64    `json:"duration"`
+	CapturedAt   *time.Time `json:"captured_at"`
+	Latitude     *float64   `json:"-"`
+	Longitude    *float64   `json:"-"`
+	UploadedBy   *int64     `gorm:"index" json:"uploaded_by"`
+	ProbeFailed  bool       `gorm:"default:false" json:"-"`
+	CreatedAt    *time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    *time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// FileLocation adalah File beserta lokasi pengambilan foto, hanya dikirim ke admin.
+// Lokasi bisa berupa alamat pelanggan sehingga tidak ikut di JSON File biasa.
+type FileLocation struct {
+	File
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
 }
@@ -6,8 +6,8 @@ type Gallery struct {
 	GalleryId    int64      `gorm:"primaryKey" json:"gallery_id"`
 	Path         string     `gorm:"type:varchar(255)" json:"path"`
 	Gallery_name string     `gorm:"type:varchar(255)" json:"gallery"`
-	Size         string     `gorm:"type:varchar(255)" json:"size"`
-	Format       string     `gorm:"type:varchar(10)" json:"format"`
+	FileId       int64      `gorm:"index" json:"file_id"`
+	File         File       `json:"file"`
 	CreatedAt    *time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt    *time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 	ProductId    int64      `json:"product_id"`
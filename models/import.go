@@ -0,0 +1,20 @@
+package models
+
+// ImportRowResult adalah hasil validasi atau penyimpanan satu baris file import.
+// Row adalah nomor baris pada file, baris judul adalah baris 1.
+type ImportRowResult struct {
+	Row       int                 `json:"row"`
+	Title     string              `json:"title"`
+	Slug      string              `json:"slug,omitempty"`
+	ProductId int64               `json:"product_id,omitempty"`
+	Errors    map[string][]string `json:"errors,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Valid   int               `json:"valid"`
+	Invalid int               `json:"invalid"`
+	Created int               `json:"created"`
+	Rows    []ImportRowResult `json:"rows"`
+}
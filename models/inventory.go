@@ -0,0 +1,157 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrStockMovementImmutable = errors.New("stock movements cannot be changed")
+
+const (
+	StockStatusInStock   = "in_stock"
+	StockStatusReserved  = "reserved"
+	StockStatusInstalled = "installed"
+	StockStatusInRepair  = "in_repair"
+	StockStatusScrapped  = "scrapped"
+)
+
+// StockStatuses adalah status unit fisik yang valid
+var StockStatuses = []string{StockStatusInStock, StockStatusReserved, StockStatusInstalled, StockStatusInRepair, StockStatusScrapped}
+
+const (
+	StockReasonReceived   = "received"
+	StockReasonTransfer   = "transfer"
+	StockReasonReserve    = "reserve"
+	StockReasonRelease    = "release"
+	StockReasonInstall    = "install"
+	StockReasonUninstall  = "uninstall"
+	StockReasonRepair     = "repair"
+	StockReasonRepaired   = "repaired"
+	StockReasonScrap      = "scrap"
+	StockReasonAdjustment = "adjustment"
+)
+
+// StockMovementRule adalah status asal yang diizinkan dan status tujuan untuk satu alasan perpindahan.
+// To kosong berarti status tidak berubah, From kosong berarti boleh dari status apa pun.
+type StockMovementRule struct {
+	From []string
+	To   string
+}
+
+// StockMovementRules mengatur perpindahan unit; received hanya dipakai saat unit diterima pertama kali
+var StockMovementRules = map[string]StockMovementRule{
+	StockReasonTransfer:   {From: []string{StockStatusInStock, StockStatusReserved, StockStatusInRepair}},
+	StockReasonReserve:    {From: []string{StockStatusInStock}, To: StockStatusReserved},
+	StockReasonRelease:    {From: []string{StockStatusReserved}, To: StockStatusInStock},
+	StockReasonInstall:    {From: []string{StockStatusInStock, StockStatusReserved}, To: StockStatusInstalled},
+	StockReasonUninstall:  {From: []string{StockStatusInstalled}, To: StockStatusInStock},
+	StockReasonRepair:     {From: []string{StockStatusInStock, StockStatusReserved, StockStatusInstalled}, To: StockStatusInRepair},
+	StockReasonRepaired:   {From: []string{StockStatusInRepair}, To: StockStatusInStock},
+	StockReasonScrap:      {From: []string{StockStatusInStock, StockStatusReserved, StockStatusInstalled, StockStatusInRepair}, To: StockStatusScrapped},
+	StockReasonAdjustment: {},
+}
+
+// Warehouse adalah gudang tempat unit disimpan
+type Warehouse struct {
+	WarehouseId int64     `gorm:"primaryKey" json:"warehouse_id"`
+	Code        string    `gorm:"type:varchar(30);uniqueIndex" json:"code"`
+	Name        string    `gorm:"type:varchar(150)" json:"name"`
+	Address     string    `gorm:"type:text" json:"address"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// StockItem adalah satu unit fisik (modul LED, kabinet) dengan nomor seri unik.
+// Location adalah rak atau bin di dalam gudang; ContractId dan HistoryId menunjukkan ke mana unit dialokasikan atau dipasang.
+type StockItem struct {
+	StockItemId  int64      `gorm:"primaryKey" json:"stock_item_id"`
+	ProductId    int64      `gorm:"index" json:"product_id"`
+	Product      Product    `gorm:"constraint:OnDelete:RESTRICT" json:"-"`
+	ProductTitle string     `gorm:"-" json:"product"`
+	SerialNumber string     `gorm:"type:varchar(100);uniqueIndex" json:"serial_number"`
+	Status       string     `gorm:"type:ENUM('in_stock', 'reserved', 'installed', 'in_repair', 'scrapped');default:'in_stock';index" json:"status"`
+	WarehouseId  *int64     `gorm:"index" json:"warehouse_id"`
+	Warehouse    *Warehouse `gorm:"constraint:OnDelete:RESTRICT" json:"warehouse,omitempty"`
+	Location     string     `gorm:"type:varchar(100)" json:"location"`
+	ContractId   *int64     `gorm:"index" json:"contract_id"`
+	HistoryId    *int64     `gorm:"index" json:"history_id"`
+	Notes        string     `gorm:"type:text" json:"notes"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// StockMovement mencatat setiap perubahan status, lokasi atau alokasi unit, tidak pernah diubah setelah dibuat
+type StockMovement struct {
+	StockMovementId int64     `gorm:"primaryKey" json:"stock_movement_id"`
+	StockItemId     int64     `gorm:"index" json:"stock_item_id"`
+	StockItem       StockItem `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Reason          string    `gorm:"type:varchar(20);index" json:"reason"`
+	FromStatus      string    `gorm:"type:varchar(20)" json:"from_status"`
+	ToStatus        string    `gorm:"type:varchar(20)" json:"to_status"`
+	FromWarehouseId *int64    `json:"from_warehouse_id"`
+	ToWarehouseId   *int64    `json:"to_warehouse_id"`
+	FromLocation    string    `gorm:"type:varchar(100)" json:"from_location"`
+	ToLocation      string    `gorm:"type:varchar(100)" json:"to_location"`
+	ContractId      *int64    `gorm:"index" json:"contract_id"`
+	HistoryId       *int64    `gorm:"index" json:"history_id"`
+	Note            string    `gorm:"type:varchar(255)" json:"note"`
+	UserId          *int64    `gorm:"index" json:"user_id"`
+	User            *User     `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+	Author          string    `gorm:"-" json:"author"`
+	CreatedAt       time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+func (m *StockMovement) BeforeUpdate(tx *gorm.DB) error {
+	return ErrStockMovementImmutable
+}
+
+func (m *StockMovement) AfterFind(tx *gorm.DB) error {
+	if m.User != nil {
+		m.Author = m.User.FullName
+	}
+	return nil
+}
+
+func (s *StockItem) AfterFind(tx *gorm.DB) error {
+	if s.Product.ProductId != 0 {
+		s.ProductTitle = s.Product.Title
+	}
+	return nil
+}
+
+type WarehouseRequest struct {
+	Code    string `json:"code" validate:"required,max=30"`
+	Name    string `json:"name" validate:"required,max=150"`
+	Address string `json:"address"`
+}
+
+// StockReceiveRequest mendaftarkan unit baru yang masuk ke gudang, satu unit per nomor seri
+type StockReceiveRequest struct {
+	ProductId     int64    `json:"product_id" validate:"required"`
+	WarehouseId   int64    `json:"warehouse_id" validate:"required"`
+	Location      string   `json:"location" validate:"max=100"`
+	SerialNumbers []string `json:"serial_numbers" validate:"required,min=1,dive,required,max=100"`
+	Note          string   `json:"note" validate:"max=255"`
+}
+
+// StockMovementRequest memindahkan satu unit. warehouse_id dan location kosong berarti tidak berubah;
+// status hanya dipakai untuk reason adjustment.
+type StockMovementRequest struct {
+	Reason      string  `json:"reason" validate:"required,oneof=transfer reserve release install uninstall repair repaired scrap adjustment"`
+	WarehouseId *int64  `json:"warehouse_id"`
+	Location    *string `json:"location" validate:"omitempty,max=100"`
+	ContractId  *int64  `json:"contract_id"`
+	HistoryId   *int64  `json:"history_id"`
+	Status      string  `json:"status" validate:"omitempty,oneof=in_stock reserved installed in_repair scrapped"`
+	Note        string  `json:"note" validate:"max=255"`
+}
+
+// StockSummary adalah jumlah unit per produk dan status
+type StockSummary struct {
+	ProductId int64  `json:"product_id"`
+	Product   string `json:"product"`
+	Status    string `json:"status"`
+	Total     int64  `json:"total"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// PriceList adalah daftar harga dengan masa berlaku. Tier kosong berlaku untuk semua pelanggan,
+// sedangkan tier lain hanya untuk pelanggan dengan User.PriceTier yang sama.
+type PriceList struct {
+	PriceListId int64           `gorm:"primaryKey" json:"price_list_id"`
+	Name        string          `gorm:"type:varchar(150)" json:"name"`
+	Tier        string          `gorm:"type:varchar(30);index" json:"tier"`
+	Currency    string          `gorm:"type:varchar(3);default:'IDR'" json:"currency"`
+	ValidFrom   *time.Time      `gorm:"index" json:"valid_from"`
+	ValidUntil  *time.Time      `gorm:"index" json:"valid_until"`
+	Items       []PriceListItem `gorm:"foreignKey:PriceListId;constraint:OnDelete:CASCADE" json:"items"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// PriceListItem adalah harga satuan satu produk pada daftar harga, belum termasuk PPN
+type PriceListItem struct {
+	PriceListItemId int64   `gorm:"primaryKey" json:"price_list_item_id"`
+	PriceListId     int64   `gorm:"uniqueIndex:idx_price_list_product" json:"price_list_id"`
+	ProductId       int64   `gorm:"uniqueIndex:idx_price_list_product;index" json:"product_id"`
+	Product         Product `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Title           string  `gorm:"-" json:"title,omitempty"`
+	Price           float64 `gorm:"type:decimal(15,2)" json:"price"`
+}
+
+type PriceListItemRequest struct {
+	ProductId int64   `json:"product_id" validate:"required"`
+	Price     float64 `json:"price" validate:"gte=0"`
+}
+
+// PriceListRequest memakai tanggal berformat YYYY-MM-DD; tanggal kosong berarti tanpa batas
+type PriceListRequest struct {
+	Name       string                 `json:"name" validate:"required"`
+	Tier       string                 `json:"tier" validate:"max=30"`
+	Currency   string                 `json:"currency" validate:"omitempty,len=3"`
+	ValidFrom  string                 `json:"valid_from"`
+	ValidUntil string                 `json:"valid_until"`
+	Items      []PriceListItemRequest `json:"items" validate:"dive"`
+}
+
+// ResolvedPrice adalah harga yang berlaku untuk satu produk pada tanggal dan tier tertentu
+type ResolvedPrice struct {
+	ProductId   int64   `json:"product_id"`
+	PriceListId int64   `json:"price_list_id"`
+	PriceList   string  `json:"price_list"`
+	Tier        string  `json:"tier"`
+	Currency    string  `json:"currency"`
+	Price       float64 `json:"price"`
+}
@@ -2,32 +2,51 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Product struct {
-	ProductId     int64     `gorm:"primaryKey" json:"product_id" form:"product_id"`
-	Title         string    `gorm:"type:varchar(255);index" json:"title" form:"title" validate:"required"`
-	Description   string    `gorm:"type:text" json:"description"`
-	Specification string    `gorm:"type:text" json:"specification"`
-	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	FileId        int64     `json:"file_id"`
-	File          File      `gorm:"constraint:OnDelete:CASCADE;OnUpdate:CASCADE" json:"file"`
-	CategoryId    int64     `json:"category_id" form:"category_id"`
-	Category      Category  `json:"category"`
-	Gallery       []Gallery `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE;OnUpdate:CASCADE" json:"gallery"`
+	ProductId     int64                   `gorm:"primaryKey" json:"product_id" form:"product_id"`
+	Title         string                  `gorm:"type:varchar(255);index" json:"title" form:"title" validate:"required"`
+	Slug          string                  `gorm:"type:varchar(150);uniqueIndex" json:"slug" form:"slug"`
+	Description   string                  `gorm:"type:text" json:"description"`
+	Specification string                  `gorm:"type:text" json:"specification"`
+	CreatedAt     time.Time               `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time               `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt          `gorm:"index" json:"deleted_at"`
+	Status        string                  `gorm:"type:ENUM('draft', 'in_review', 'published', 'archived');default:'draft';index" json:"status"`
+	PublishAt     *time.Time              `gorm:"index" json:"publish_at"`
+	PublishedAt   *time.Time              `gorm:"index" json:"published_at"`
+	FileId        int64                   `json:"file_id"`
+	File          File                    `gorm:"constraint:OnDelete:CASCADE;OnUpdate:CASCADE" json:"file"`
+	CategoryId    int64                   `json:"category_id" form:"category_id"`
+	Category      Category                `json:"category"`
+	Gallery       []Gallery               `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE;OnUpdate:CASCADE" json:"gallery"`
+	Videos        []Video                 `gorm:"many2many:ProductVideo;constraint:OnDelete:CASCADE" json:"videos"`
+	Tags          []Tag                   `gorm:"many2many:ProductTag;constraint:OnDelete:CASCADE" json:"tags"`
+	Attributes    []ProductAttributeValue `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE" json:"attributes"`
+	Revisions     []ProductRevision       `gorm:"foreignKey:ProductId;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 type ProductResponse struct {
-	ProductId     int64     `json:"product_id"`
-	Title         string    `json:"title"`	
-	Specification string    `json:"specification"`
-	Description   string    `json:"description"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	FileId        int64     `json:"file_id"`
-	CategoryId    int64     `json:"category_id"`
-	PathFile      string    `json:"path_file"`
-	Category      string    `json:"category"`
-	PathGallery       []string  `json:"PathGallery"`
+	ProductId     int64                  `json:"product_id"`
+	Title         string                 `json:"title"`
+	Slug          string                 `json:"slug"`
+	Specification string                 `json:"specification"`
+	Description   string                 `json:"description"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+	Status        string                 `json:"status"`
+	PublishAt     *time.Time             `json:"publish_at"`
+	PublishedAt   *time.Time             `json:"published_at"`
+	FileId        int64                  `json:"file_id"`
+	CategoryId    int64                  `json:"category_id"`
+	PathFile      string                 `json:"path_file"`
+	Category      string                 `json:"category"`
+	Breadcrumb    []CategoryBreadcrumb   `json:"breadcrumb,omitempty"`
+	PathGallery   []string               `json:"PathGallery"`
+	Videos        []Video                `json:"videos,omitempty"`
+	Tags          []Tag                  `json:"tags,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
 }
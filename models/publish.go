@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+const (
+	StatusDraft     = "draft"
+	StatusInReview  = "in_review"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// PublishStatuses adalah status alur kerja yang valid untuk produk dan portofolio
+var PublishStatuses = []string{StatusDraft, StatusInReview, StatusPublished, StatusArchived}
+
+// PublishRequest mengubah status; publish_at di masa depan menjadwalkan publikasi oleh worker
+type PublishRequest struct {
+	Status    string     `json:"status" validate:"required,oneof=draft in_review published archived"`
+	PublishAt *time.Time `json:"publish_at"`
+}
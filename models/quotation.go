@@ -0,0 +1,123 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	QuotationStatusDraft     = "draft"
+	QuotationStatusSent      = "sent"
+	QuotationStatusAccepted  = "accepted"
+	QuotationStatusRejected  = "rejected"
+	QuotationStatusConverted = "converted"
+)
+
+// QuotationTransitions adalah perubahan status penawaran yang diizinkan; converted hanya lewat konversi ke kontrak
+var QuotationTransitions = map[string][]string{
+	QuotationStatusDraft:    {QuotationStatusSent},
+	QuotationStatusSent:     {QuotationStatusDraft, QuotationStatusAccepted, QuotationStatusRejected},
+	QuotationStatusRejected: {QuotationStatusDraft},
+}
+
+// Quotation adalah penawaran harga untuk satu pelanggan. Nilai uang dihitung ulang oleh server setiap kali disimpan.
+type Quotation struct {
+	QuotationId int64           `gorm:"primaryKey" json:"quotation_id"`
+	Number      string          `gorm:"type:varchar(30);uniqueIndex" json:"number"`
+	Version     int             `gorm:"default:1" json:"version"`
+	UserId      int64           `gorm:"index" json:"user_id"`
+	User        User            `gorm:"constraint:OnDelete:RESTRICT" json:"-"`
+	Customer    string          `gorm:"-" json:"customer"`
+	Title       string          `gorm:"type:varchar(255)" json:"title"`
+	Notes       string          `gorm:"type:text" json:"notes"`
+	Status      string          `gorm:"type:ENUM('draft', 'sent', 'accepted', 'rejected', 'converted');default:'draft';index" json:"status"`
+	Currency    string          `gorm:"type:varchar(3);default:'IDR'" json:"currency"`
+	IssuedAt    time.Time       `json:"issued_at"`
+	ValidUntil  *time.Time      `json:"valid_until"`
+	Subtotal    float64         `gorm:"type:decimal(15,2)" json:"subtotal"`
+	Discount    float64         `gorm:"type:decimal(15,2)" json:"discount"`
+	TaxBase     float64         `gorm:"type:decimal(15,2)" json:"tax_base"`
+	TaxRate     float64         `gorm:"type:decimal(5,2)" json:"tax_rate"`
+	Tax         float64         `gorm:"type:decimal(15,2)" json:"tax"`
+	Total       float64         `gorm:"type:decimal(15,2)" json:"total"`
+	Lines       []QuotationLine `gorm:"foreignKey:QuotationId;constraint:OnDelete:CASCADE" json:"lines"`
+	ContractId  *int64          `gorm:"index" json:"contract_id"`
+	CreatedBy   *int64          `json:"created_by"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// QuotationLine adalah satu baris produk; Description menyimpan nama produk saat penawaran dibuat
+type QuotationLine struct {
+	QuotationLineId int64   `gorm:"primaryKey" json:"quotation_line_id"`
+	QuotationId     int64   `gorm:"index" json:"quotation_id"`
+	Position        int     `json:"position"`
+	ProductId       int64   `gorm:"index" json:"product_id"`
+	Description     string  `gorm:"type:varchar(255)" json:"description"`
+	Quantity        int     `json:"quantity"`
+	UnitPrice       float64 `gorm:"type:decimal(15,2)" json:"unit_price"`
+	DiscountPercent float64 `gorm:"type:decimal(5,2)" json:"discount_percent"`
+	DiscountAmount  float64 `gorm:"type:decimal(15,2)" json:"discount_amount"`
+	Total           float64 `gorm:"type:decimal(15,2)" json:"total"`
+}
+
+// QuotationVersion adalah salinan penawaran setiap kali disimpan, tidak pernah diubah setelah dibuat
+type QuotationVersion struct {
+	QuotationVersionId int64     `gorm:"primaryKey" json:"quotation_version_id"`
+	QuotationId        int64     `gorm:"uniqueIndex:idx_quotation_version" json:"quotation_id"`
+	Version            int       `gorm:"uniqueIndex:idx_quotation_version" json:"version"`
+	Snapshot           Quotation `gorm:"type:mediumtext;serializer:json" json:"snapshot"`
+	UserId             *int64    `gorm:"index" json:"user_id"`
+	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (q *Quotation) AfterFind(tx *gorm.DB) error {
+	if q.User.UserId != 0 {
+		q.Customer = q.User.FullName
+	}
+	return nil
+}
+
+func (v *QuotationVersion) BeforeUpdate(tx *gorm.DB) error {
+	return ErrRevisionImmutable
+}
+
+// QuotationSequence menyimpan nomor urut terakhir penawaran per tahun
+type QuotationSequence struct {
+	Year int `gorm:"primaryKey;autoIncrement:false" json:"year"`
+	Last int `json:"last"`
+}
+
+// QuotationLineRequest: unit_price kosong berarti harga diambil dari daftar harga yang berlaku untuk pelanggan
+type QuotationLineRequest struct {
+	ProductId       int64    `json:"product_id" validate:"required"`
+	Description     string   `json:"description" validate:"max=255"`
+	Quantity        int      `json:"quantity" validate:"required,gt=0"`
+	UnitPrice       *float64 `json:"unit_price" validate:"omitempty,gte=0"`
+	DiscountPercent float64  `json:"discount_percent" validate:"gte=0,lte=100"`
+}
+
+// QuotationRequest: tax_rate kosong memakai tarif PPN bawaan, valid_until berformat YYYY-MM-DD.
+// currency kosong mengikuti mata uang daftar harga; unit_price manual dianggap dalam mata uang penawaran
+type QuotationRequest struct {
+	UserId     int64                  `json:"user_id" validate:"required"`
+	Title      string                 `json:"title" validate:"required,max=255"`
+	Notes      string                 `json:"notes"`
+	Currency   string                 `json:"currency" validate:"omitempty,len=3"`
+	ValidUntil string                 `json:"valid_until"`
+	Discount   float64                `json:"discount" validate:"gte=0"`
+	TaxRate    *float64               `json:"tax_rate" validate:"omitempty,gte=0,lte=100"`
+	Lines      []QuotationLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+type QuotationStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=draft sent accepted rejected"`
+}
+
+// QuotationConvertRequest membuat kontrak dari penawaran yang diterima; judul kosong memakai judul penawaran
+type QuotationConvertRequest struct {
+	Title     string `json:"title"`
+	StartDate string `json:"start_date" validate:"required"`
+	EndDate   string `json:"end_date" validate:"required"`
+}
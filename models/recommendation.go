@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+const (
+	RecommendationReasonCategory = "category"
+	RecommendationReasonTags     = "tags"
+	RecommendationReasonContract = "contract"
+	RecommendationReasonHistory  = "history"
+)
+
+// ProductRecommendation adalah hasil perhitungan produk terkait yang disimpan oleh job rekomendasi
+type ProductRecommendation struct {
+	ProductId     int64     `gorm:"primaryKey;autoIncrement:false" json:"product_id"`
+	RecommendedId int64     `gorm:"primaryKey;autoIncrement:false" json:"recommended_id"`
+	Score         float64   `json:"score"`
+	Reasons       []string  `gorm:"type:text;serializer:json" json:"reasons"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type ProductRecommendationResponse struct {
+	ProductId  int64    `json:"product_id"`
+	Title      string   `json:"title"`
+	Slug       string   `json:"slug"`
+	CategoryId int64    `json:"category_id"`
+	Category   string   `json:"category"`
+	PathFile   string   `json:"path_file"`
+	Score      float64  `json:"score"`
+	Reasons    []string `json:"reasons"`
+}
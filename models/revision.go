@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrRevisionImmutable = errors.New("product revisions cannot be changed")
+
+// RevisionFile adalah referensi file media pada saat revisi dibuat
+type RevisionFile struct {
+	FileId int64  `json:"file_id"`
+	Path   string `json:"path"`
+	Name   string `json:"name,omitempty"`
+}
+
+// ProductRevision adalah salinan lengkap produk setiap kali disimpan, tidak pernah diubah setelah dibuat
+type ProductRevision struct {
+	RevisionId    int64                  `gorm:"primaryKey" json:"revision_id"`
+	ProductId     int64                  `gorm:"uniqueIndex:idx_product_revision" json:"product_id"`
+	Number        int                    `gorm:"uniqueIndex:idx_product_revision" json:"number"`
+	Title         string                 `gorm:"type:varchar(255)" json:"title"`
+	Slug          string                 `gorm:"type:varchar(150)" json:"slug"`
+	Description   string                 `gorm:"type:text" json:"description"`
+	Specification string                 `gorm:"type:text" json:"specification"`
+	CategoryId    int64                  `json:"category_id"`
+	Category      string                 `gorm:"type:varchar(255)" json:"category"`
+	File          RevisionFile           `gorm:"type:text;serializer:json" json:"file"`
+	Gallery       []RevisionFile         `gorm:"type:text;serializer:json" json:"gallery"`
+	VideoIds      []int64                `gorm:"type:text;serializer:json" json:"video_ids"`
+	TagIds        []int64                `gorm:"type:text;serializer:json" json:"tag_ids"`
+	Attributes    map[string]interface{} `gorm:"type:text;serializer:json" json:"attributes"`
+	Note          string                 `gorm:"type:varchar(255)" json:"note"`
+	UserId        *int64                 `gorm:"index" json:"user_id"`
+	User          *User                  `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+	Author        string                 `gorm:"-" json:"author"`
+	CreatedAt     time.Time              `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (r *ProductRevision) BeforeUpdate(tx *gorm.DB) error {
+	return ErrRevisionImmutable
+}
+
+func (r *ProductRevision) AfterFind(tx *gorm.DB) error {
+	if r.User != nil {
+		r.Author = r.User.FullName
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+const (
+	SlugEntityProduct  = "product"
+	SlugEntityCategory = "category"
+	SlugEntityHistory  = "history"
+)
+
+// SlugRedirect mencatat slug lama agar tautan yang sudah tersebar tetap diarahkan ke data yang sama
+type SlugRedirect struct {
+	RedirectId int64     `gorm:"primaryKey" json:"redirect_id"`
+	Entity     string    `gorm:"type:varchar(20);uniqueIndex:idx_slug_redirect" json:"entity"`
+	OldSlug    string    `gorm:"type:varchar(150);uniqueIndex:idx_slug_redirect" json:"old_slug"`
+	EntityId   int64     `gorm:"index" json:"entity_id"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -12,3 +12,22 @@ type Tag struct {
 type TagRequest struct {
 	Tag string `gorm:"type:varchar(255)" json:"tag" validate:"required"`
 }
+
+// ProductTag dan HistoryTag adalah tabel penghubung tag langsung pada produk dan riwayat
+type ProductTag struct {
+	ProductProductId int64 `gorm:"primaryKey"`
+	TagTagId         int64 `gorm:"primaryKey"`
+}
+
+func (ProductTag) TableName() string {
+	return "ProductTag"
+}
+
+type HistoryTag struct {
+	HistoryHistoryId int64 `gorm:"primaryKey"`
+	TagTagId         int64 `gorm:"primaryKey"`
+}
+
+func (HistoryTag) TableName() string {
+	return "HistoryTag"
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+const (
+	LocaleIndonesian = "id"
+	LocaleEnglish    = "en"
+	// DefaultLocale adalah bahasa kolom asli pada tabel produk, kategori dan riwayat
+	DefaultLocale = LocaleIndonesian
+)
+
+// Locales adalah bahasa yang didukung, bahasa bawaan lebih dulu
+var Locales = []string{LocaleIndonesian, LocaleEnglish}
+
+// TranslatableFields adalah kolom yang dapat diterjemahkan untuk setiap jenis data
+var TranslatableFields = map[string][]string{
+	SlugEntityProduct:  {"title", "description", "specification"},
+	SlugEntityCategory: {"category"},
+	SlugEntityHistory:  {"title", "description"},
+}
+
+// Translation menyimpan terjemahan satu kolom data untuk satu bahasa selain bahasa bawaan
+type Translation struct {
+	TranslationId int64     `gorm:"primaryKey" json:"translation_id"`
+	Entity        string    `gorm:"type:varchar(20);uniqueIndex:idx_translation" json:"entity"`
+	EntityId      int64     `gorm:"uniqueIndex:idx_translation" json:"entity_id"`
+	Locale        string    `gorm:"type:varchar(5);uniqueIndex:idx_translation" json:"locale"`
+	Field         string    `gorm:"type:varchar(50);uniqueIndex:idx_translation" json:"field"`
+	Value         string    `gorm:"type:text" json:"value"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// MissingTranslation adalah satu data yang belum lengkap terjemahannya
+type MissingTranslation struct {
+	Entity   string   `json:"entity"`
+	EntityId int64    `json:"entity_id"`
+	Title    string   `json:"title"`
+	Missing  []string `json:"missing"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// TrashItem adalah ringkasan data yang dihapus sementara dan masih bisa dipulihkan
+type TrashItem struct {
+	Id        int64      `json:"id"`
+	Title     string     `json:"title"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `gorm:"-" json:"purge_at"`
+}
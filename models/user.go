@@ -1,23 +1,43 @@
 package models
 
 import (
+	"fmt"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type User struct {
-	UserId      int64      `gorm:"primaryKey" json:"user_id"`
-	FullName    string     `gorm:"type:varchar(100);index" json:"full_name"`
-	UserName    string     `gorm:"type:varchar(100);index" json:"username" `
-	PhoneNumber string     `gorm:"type:varchar(100)" json:"phone_number" `
-	Password    string     `gorm:"type:varchar(100)" json:"password"`
-	Email       string     `gorm:"type:varchar(100)" json:"email"`
-	Address     *string    `gorm:"type:varchar(300)" json:"address"`
-	Role        string     `gorm:"type:ENUM('Admin', 'Customer', 'SuperAdmin'); default:'Customer'" json:"role"`
-	FileId      int64      `json:"file_id"`
-	File        File       `gorm:"constraint:OnDelete:CASCADE;OnUpdate:CASADE" json:"file"`
-	CreatedAt   *time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   *time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	UserId      int64          `gorm:"primaryKey" json:"user_id"`
+	FullName    string         `gorm:"type:varchar(100);index" json:"full_name"`
+	UserName    string         `gorm:"type:varchar(100);index" json:"username" `
+	PhoneNumber string         `gorm:"type:varchar(100)" json:"phone_number" `
+	Password    string         `gorm:"type:varchar(100)" json:"password"`
+	Email       string         `gorm:"type:varchar(100)" json:"email"`
+	Address     *string        `gorm:"type:varchar(300)" json:"address"`
+	Role        string         `gorm:"type:ENUM('Admin', 'Customer', 'SuperAdmin'); default:'Customer'" json:"role"`
+	PriceTier   string         `gorm:"type:varchar(30);index" json:"price_tier"`
+	FileId      *int64         `json:"file_id"`
+	File        File           `gorm:"constraint:OnDelete:SET NULL;OnUpdate:CASCADE" json:"file"`
+	AvatarUrl   string         `gorm:"-" json:"avatar_url"`
+	CreatedAt   *time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   *time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 }
+
+// AvatarPath mengembalikan path foto jika File sudah di-preload, selain itu endpoint avatar yang membuat gambar inisial
+func (u User) AvatarPath() string {
+	if u.FileId != nil && u.File.Path != "" {
+		return u.File.Path
+	}
+	return fmt.Sprintf("/api/user/%d/avatar", u.UserId)
+}
+
+func (u *User) AfterFind(tx *gorm.DB) error {
+	u.AvatarUrl = u.AvatarPath()
+	return nil
+}
+
 type UserResponse struct {
 	UserId      int64      `gorm:"primaryKey" json:"user_id"`
 	FullName    string     `gorm:"type:varchar(100);index" json:"full_name" validate:"required"`
@@ -27,6 +47,7 @@ type UserResponse struct {
 	Email       string     `gorm:"type:varchar(100)" json:"email" validate:"required"`
 	Address     *string    `gorm:"type:varchar(300)" json:"address"`
 	Role        string     `gorm:"type:ENUM('Admin', 'Customer', 'Superadmin'); default:'Customer'" json:"role"`
+	PriceTier   string     `gorm:"type:varchar(30)" json:"price_tier"`
 	CreatedAt   *time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   *time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -3,9 +3,37 @@ package models
 import "time"
 
 type Video struct {
-	VideoId   int64     `gorm:"primaryKey" json:"video_id"`
-	Title     string    `gorm:"type:varchar(255);index" json:"video_title" validate:"required"`
-	Embed     string    `gorm:"type:text;index" json:"embed" validate:"required"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	VideoId         int64     `gorm:"primaryKey" json:"video_id"`
+	Title           string    `gorm:"type:varchar(255);index" json:"video_title" validate:"required"`
+	Provider        string    `gorm:"type:varchar(20);index:idx_video_source" json:"provider"`
+	ProviderVideoId string    `gorm:"type:varchar(32);index:idx_video_source" json:"provider_video_id"`
+	Url             string    `gorm:"type:varchar(255)" json:"url"`
+	ThumbnailUrl    string    `gorm:"type:varchar(255)" json:"thumbnail_url"`
+	Embed           string    `gorm:"type:text;index" json:"embed" validate:"required"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// HistoryVideo dan ProductVideo adalah tabel penghubung pustaka video
+type HistoryVideo struct {
+	HistoryHistoryId int64 `gorm:"primaryKey"`
+	VideoVideoId     int64 `gorm:"primaryKey"`
+}
+
+func (HistoryVideo) TableName() string {
+	return "HistoryVideo"
+}
+
+type ProductVideo struct {
+	ProductProductId int64 `gorm:"primaryKey"`
+	VideoVideoId     int64 `gorm:"primaryKey"`
+}
+
+func (ProductVideo) TableName() string {
+	return "ProductVideo"
+}
+
+type VideoRequest struct {
+	Title string `json:"video_title" form:"video_title"`
+	Url   string `json:"url" form:"url" validate:"required"`
 }